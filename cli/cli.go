@@ -22,7 +22,14 @@ import (
 	"os"
 
 	"github.com/artem-sidorenko/chagen/cli/commands"
-	_ "github.com/artem-sidorenko/chagen/cli/commands/generate" // enable generate subcommand
+	_ "github.com/artem-sidorenko/chagen/cli/commands/connectors"   // enable connectors subcommand
+	_ "github.com/artem-sidorenko/chagen/cli/commands/export"       // enable export subcommand
+	_ "github.com/artem-sidorenko/chagen/cli/commands/generate"     // enable generate subcommand
+	_ "github.com/artem-sidorenko/chagen/cli/commands/nextversion"  // enable next-version subcommand
+	_ "github.com/artem-sidorenko/chagen/cli/commands/publish"      // enable publish subcommand
+	_ "github.com/artem-sidorenko/chagen/cli/commands/release"      // enable release subcommand
+	_ "github.com/artem-sidorenko/chagen/cli/commands/releasenotes" // enable release-notes subcommand
+	_ "github.com/artem-sidorenko/chagen/cli/commands/stats"        // enable stats subcommand
 	"github.com/artem-sidorenko/chagen/internal/info"
 
 	"github.com/urfave/cli"
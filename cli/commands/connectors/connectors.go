@@ -0,0 +1,96 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package connectors implements the connectors command
+package connectors
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/artem-sidorenko/chagen/cli/commands"
+	_ "github.com/artem-sidorenko/chagen/datasource" // register the connectors
+	dconnectors "github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/internal/output"
+
+	"github.com/urfave/cli"
+)
+
+// Stdout references the Stdout writer for connectors command
+var Stdout io.Writer = output.Stdout // nolint: gochecknoglobals
+
+// Connectors implements the CLI subcommand connectors,
+// it lists the registered connectors with their flags and optional features
+func Connectors(_ *cli.Context) error {
+	for i, id := range dconnectors.RegisteredConnectors() {
+		info, err := dconnectors.Info(id)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := fmt.Fprintln(Stdout); err != nil {
+				return err
+			}
+		}
+		if err := writeInfo(Stdout, info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeInfo writes the human readable description of connector,
+// the flag descriptions are aligned
+func writeInfo(w io.Writer, info dconnectors.ConnectorInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "%v (%v)\n", info.ID, info.Name) // nolint: errcheck
+	fmt.Fprintln(tw, "  Flags:")                     // nolint: errcheck
+	if len(info.CLIFlags) == 0 {
+		fmt.Fprintln(tw, "    none") // nolint: errcheck
+	}
+	for _, f := range info.CLIFlags {
+		fmt.Fprintf(tw, "    %v\n", f) // nolint: errcheck
+	}
+	fmt.Fprintf(tw, "  Environment variables: %v\n", joinOrNone(info.EnvVars)) // nolint: errcheck
+	fmt.Fprintf(tw, "  Optional features: %v\n", joinOrNone(info.Features))    // nolint: errcheck
+
+	return tw.Flush()
+}
+
+// joinOrNone joins the given values or returns none if there are no values
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
+
+func init() { // nolint: gochecknoinits
+	commands.RegisterCommand(cli.Command{
+		Name:      "connectors",
+		Usage:     "List the supported endpoints with their flags and features",
+		ArgsUsage: " ", // we do not have any args (only flags), so avoid this help message
+		Action: func(c *cli.Context) error {
+			if err := Connectors(c); err != nil { // exit 1 and error message if we get any error reported
+				return cli.NewExitError(err, 1)
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,52 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package connectors_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/cli/commands/connectors"
+	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+	_ "github.com/artem-sidorenko/chagen/internal/testing/testconnector"
+)
+
+func TestConnectors(t *testing.T) {
+	output := &bytes.Buffer{}
+	connectors.Stdout = output
+
+	if err := connectors.Connectors(tcli.TestContext(nil, nil)); err != nil {
+		t.Fatalf("Connectors() error = %v", err)
+	}
+
+	out := output.String()
+	// nolint: lll
+	for _, s := range []string{
+		"github (GitHub)\n  Flags:\n    --github-owner value  Owner/organisation where repository belongs to\n",
+		"  Environment variables: CHAGEN_GITHUB_TOKEN\n",
+		"  Environment variables: CHAGEN_GITLAB_TOKEN\n",
+		"testconnector (TestConnector)\n  Flags:\n    none\n  Environment variables: none\n  Optional features: direct commits, compare links, publishing of releases\n",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Connectors() output = %v, should contain %v", out, s)
+		}
+	}
+	if strings.Index(out, "file (File)") > strings.Index(out, "github (GitHub)") {
+		t.Errorf("Connectors() output = %v, should be sorted by id", out)
+	}
+}
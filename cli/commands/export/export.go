@@ -0,0 +1,110 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package export implements the export command
+package export
+
+import (
+	"io"
+	"os"
+
+	"github.com/artem-sidorenko/chagen/cli/commands"
+	"github.com/artem-sidorenko/chagen/datasource"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/file"
+	"github.com/artem-sidorenko/chagen/internal/output"
+
+	"github.com/urfave/cli"
+)
+
+// Stdout references the Stdout writer for export command
+var Stdout io.Writer = output.Stdout // nolint: gochecknoglobals
+// ProgressWriter references the writer for progress information
+var ProgressWriter io.Writer = output.Stderr // nolint: gochecknoglobals
+
+// Export implements the CLI subcommand export.
+// All data is exported unfiltered, so the filters can be applied
+// later, when the snapshot is used via file endpoint
+func Export(ctx *cli.Context) (err error) {
+	opts, err := datasource.NewEndpointOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	conn, err := datasource.NewConnector(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	tags, issues, mrs, err := datasource.GetConnectorData(conn, opts, ProgressWriter)
+	if err != nil {
+		return err
+	}
+
+	newTagURL, err := conn.GetNewTagURL(file.NewTagPlaceholder)
+	if err != nil {
+		return err
+	}
+
+	// use stdout if - is given, otherwise create a new file
+	filename := ctx.String("output")
+	var wr io.Writer
+	if filename != "-" {
+		var f *os.File
+		if f, err = os.Create(filename); err != nil {
+			return err
+		}
+
+		defer func() {
+			if cerr := f.Close(); err == nil && cerr != nil {
+				err = cerr
+			}
+		}()
+
+		wr = f
+	} else {
+		wr = Stdout
+	}
+
+	return file.WriteSnapshot(wr, newTagURL, tags, issues, mrs)
+}
+
+// CLIFlags returns the possible CLI flags for this command
+func CLIFlags() []cli.Flag {
+	return append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "File name of data snapshot, - is accepted for stdout",
+			Value: "data.json",
+		},
+	}, datasource.EndpointCLIFlags()...)
+}
+
+func init() { // nolint: gochecknoinits
+	flags := append(CLIFlags(), datasource.ConnectorCLIFlags()...)
+
+	commands.RegisterCommand(cli.Command{
+		Name:      "export",
+		Usage:     "Export the fetched data to a JSON snapshot, which can be used with the file endpoint",
+		ArgsUsage: " ", // we do not have any args (only flags), so avoid this help message
+		Flags:     flags,
+		Action: func(c *cli.Context) error {
+			if err := Export(c); err != nil { // exit 1 and error message if we get any error reported
+				return cli.NewExitError(err, 1)
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,105 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package export_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/cli/commands/export"
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/file"
+	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+	"github.com/artem-sidorenko/chagen/internal/testing/testconnector"
+	"github.com/artem-sidorenko/chagen/internal/testing/testdata"
+)
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		name                 string
+		endpoint             string
+		repositoryExistsFail bool
+		wantErr              error
+	}{
+		{
+			name:     "Proper export",
+			endpoint: "testconnector",
+		},
+		{
+			name:     "Wrong endpoint",
+			endpoint: "wrongendpoint",
+			wantErr:  errors.New("given endpoint isn't supported: wrongendpoint"),
+		},
+		{
+			name:                 "Repository not found",
+			endpoint:             "testconnector",
+			repositoryExistsFail: true,
+			wantErr:              errors.New("project not found"),
+		},
+	}
+	for _, tt := range tests {
+		ctx := tcli.TestContext(export.CLIFlags(), map[string]string{
+			"output":   "-",
+			"endpoint": tt.endpoint,
+		})
+
+		output := &bytes.Buffer{}
+		export.Stdout = output
+		export.ProgressWriter = &bytes.Buffer{}
+
+		testconnector.RetTestingTag = false
+		testconnector.RepositoryExistsFail = tt.repositoryExistsFail
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := export.Export(ctx)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Export() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			s, err := file.ReadSnapshot(output)
+			if err != nil {
+				t.Fatalf("ReadSnapshot() error = %v", err)
+			}
+
+			want := &file.Snapshot{
+				SchemaVersion: file.SchemaVersion,
+				NewTagURL:     "http://test.example.com/releases/" + file.NewTagPlaceholder,
+				Tags:          testdata.DataTags(),
+				Issues:        testdata.DataIssues(),
+				MRs:           testdata.DataMRs(),
+			}
+			// the merge commits and closed issues are provided by the connector
+			for i, m := range testdata.MRs() {
+				want.MRs[i].MergeCommit = m.MergeCommitSHA
+				want.MRs[i].Closes = testdata.MRClosedIssues()[m.ID]
+			}
+			// JSON provides the dates always in UTC
+			data.UTCDate(want.Tags, want.Issues, want.MRs)
+			// the pipeline links the issues with the MRs
+			data.LinkIssues(want.Issues, want.MRs)
+
+			if !reflect.DeepEqual(s, want) {
+				t.Errorf("Export() = %+v, want %+v", s, want)
+			}
+		})
+	}
+}
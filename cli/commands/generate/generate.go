@@ -18,23 +18,19 @@
 package generate
 
 import (
-	"context"
 	"fmt"
 	"io"
 	"os"
-	"regexp"
+	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/artem-sidorenko/chagen/cli/commands"
 	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource"
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
 	"github.com/artem-sidorenko/chagen/generator"
 	"github.com/artem-sidorenko/chagen/internal/output"
 
-	// some of connectors control functionality will be moved to source at some point
-	_ "github.com/artem-sidorenko/chagen/datasource"
-	"github.com/artem-sidorenko/chagen/datasource/connectors"
-
 	"github.com/urfave/cli"
 )
 
@@ -43,266 +39,339 @@ var Stdout io.Writer = output.Stdout // nolint: gochecknoglobals
 // ProgressWriter references the writer for progress information
 var ProgressWriter io.Writer = output.Stderr // nolint: gochecknoglobals
 
-// Generate implements the CLI subcommand generate
-func Generate(ctx *cli.Context) error { // nolint: gocyclo
-	var filterRe *regexp.Regexp
-	var excludeLabels []string
-	var err error
-
-	// verify the given endpoint, it should be one of supported connectors
-	if ctx.String("endpoint") == "" {
-		return fmt.Errorf("endpoint type is missing")
+// optionalFeatures maps the CLI flags to the optional connector features they need
+var optionalFeatures = []struct { // nolint: gochecknoglobals
+	flag    string
+	feature string
+}{
+	{"releases", connectors.FeatureReleaseNotes},
+	{"commits", connectors.FeatureCommits},
+	{"compare-links", connectors.FeatureCompareURLs},
+	{"milestones", connectors.FeatureMilestones},
+}
+
+// changelogData contains the fetched data and the generator settings configured via CLI flags
+type changelogData struct {
+	opts            *datasource.Options
+	conn            connectors.Connector
+	tags            data.Tags
+	issues          data.Issues
+	mrs             data.MRs
+	commits         data.Commits
+	milestones      data.Milestones
+	compareURLs     connectors.CompareURLProvider
+	collapseAuthors *data.AuthorMatcher
+	linkedIssues    string
+}
+
+// fetchChangelogData fetches and prepares the data configured via CLI flags,
+// the progress is printed to the given writer
+func fetchChangelogData(ctx *cli.Context, progress io.Writer) (*changelogData, error) {
+	opts, err := datasource.NewOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	linkedIssues := ctx.String("linked-issues")
+	switch linkedIssues {
+	case generator.LinkedIssuesList, generator.LinkedIssuesNest, generator.LinkedIssuesHide:
+	default:
+		return nil, fmt.Errorf("unsupported mode for linked issues: %v", linkedIssues)
+	}
+
+	excludeAuthors, err := data.NewAuthorMatcher(
+		datasource.SplitList(ctx.String("exclude-authors")), ctx.Bool("exclude-bots"))
+	if err != nil {
+		return nil, err
+	}
+	collapseAuthors, err := data.NewAuthorMatcher(
+		datasource.SplitList(ctx.String("collapse-authors")), ctx.Bool("collapse-bots"))
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := datasource.NewConnector(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	printUnsupportedFeatures(ctx, conn, progress)
+
+	tags, issues, mrs, commits, err := datasource.GetConnectorDataWithCommits(conn, opts, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	mrs = data.FilterMRsByAuthor(mrs, excludeAuthors)
+
+	// the issues are linked with the MRs by the pipeline before filtering
+	if ctx.Bool("only-completed-issues") {
+		completed := data.FilterCompletedIssues(issues)
+		fmt.Fprintf(progress, // nolint: errcheck
+			"Excluded %v issues, which were not completed\n", len(issues)-len(completed))
+		issues = completed
 	}
-	connector := ctx.String("endpoint")
-	if !connectors.ConnectorRegistered(connector) {
-		return fmt.Errorf("given endpoint isn't supported: %v", connector)
+
+	d := &changelogData{
+		opts:            opts,
+		conn:            conn,
+		tags:            tags,
+		issues:          issues,
+		mrs:             mrs,
+		commits:         commits,
+		collapseAuthors: collapseAuthors,
+		linkedIssues:    linkedIssues,
 	}
 
-	if !ctx.Bool("no-filter-tags") { // if the flag is not there, lets apply the filter
-		filterReStr := ctx.String("filter-tags")
-		if filterReStr == "" {
-			return fmt.Errorf("regular expression for tag filtering should be defined")
+	if provider, ok := conn.(connectors.MilestoneProvider); ok && ctx.Bool("milestones") {
+		if d.milestones, err = provider.Milestones(); err != nil {
+			return nil, err
 		}
-		if filterRe, err = regexp.Compile(filterReStr); err != nil {
-			return fmt.Errorf("can't compile the regular expression: %v", err)
+	}
+	if provider, ok := conn.(connectors.CompareURLProvider); ok && ctx.Bool("compare-links") {
+		d.compareURLs = provider
+	}
+
+	return d, nil
+}
+
+// printUnsupportedFeatures prints the requested features,
+// which are not supported by the connector and are skipped
+func printUnsupportedFeatures(ctx *cli.Context, conn connectors.Connector, progress io.Writer) {
+	var unsupported []string
+	for _, f := range optionalFeatures {
+		if ctx.Bool(f.flag) && !connectors.Supports(conn, f.feature) {
+			unsupported = append(unsupported, f.feature)
 		}
 	}
 
-	ls := ctx.String("exclude-labels")
-	if ls != "" {
-		excludeLabels = strings.Split(ls, ",")
-		//trim spaces from label names
-		for i := range excludeLabels {
-			excludeLabels[i] = strings.Trim(excludeLabels[i], " ")
+	if len(unsupported) > 0 {
+		fmt.Fprintf(progress, // nolint: errcheck
+			"Endpoint %v does not support %v, skipping\n",
+			ctx.String("endpoint"), strings.Join(unsupported, ", "))
+	}
+}
+
+// generator returns the generator for given data configured via CLI flags
+func (d *changelogData) generator(
+	ctx *cli.Context,
+	tags data.Tags,
+	issues data.Issues,
+	mrs data.MRs,
+) (*generator.Generator, error) {
+	releases := data.NewReleases(tags, issues, mrs)
+	data.CollapseMRsByAuthor(releases, d.collapseAuthors)
+	if d.commits != nil {
+		data.AddCommits(releases, tags, d.commits)
+	}
+	data.AddMilestones(releases, d.milestones)
+	if d.compareURLs != nil {
+		if err := data.AddCompareURLs(releases, d.compareURLs.CompareURL); err != nil {
+			return nil, err
 		}
 	}
 
-	conn, err := connectors.NewConnector(connector, ctx)
+	gen := generator.New(releases)
+	gen.ShowDescriptions = ctx.Bool("release-descriptions")
+	gen.LinkedIssues = d.linkedIssues
+	gen.ShowContributors = ctx.Bool("contributors")
+
+	return gen, nil
+}
+
+// NewGenerator fetches the data and returns the generator configured via CLI flags
+// and the used connector, the progress is printed to the given writer.
+// It is used by the commands rendering single releases
+func NewGenerator(
+	ctx *cli.Context,
+	progress io.Writer,
+) (*generator.Generator, connectors.Connector, error) {
+	d, err := fetchChangelogData(ctx, progress)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	exists, err := conn.RepositoryExists()
+	gen, err := d.generator(ctx, d.tags, d.issues, d.mrs)
+	return gen, d.conn, err
+}
+
+// Generate implements the CLI subcommand generate
+func Generate(ctx *cli.Context) error {
+	d, err := fetchChangelogData(ctx, ProgressWriter)
 	if err != nil {
 		return err
 	}
 
-	if !exists {
-		// TODO: this should provide detailed information about repository: owner, repo name
-		return fmt.Errorf("project not found")
+	if data.HasComponents(d.opts.TagsFilter) {
+		return generateComponents(ctx, d)
 	}
 
-	tags, issues, mrs, err := getConnectorData(
-		conn,
-		filterRe,
-		excludeLabels,
-		ctx.String("new-release"),
-	)
+	gen, err := d.generator(ctx, d.tags, d.issues, d.mrs)
 	if err != nil {
 		return err
 	}
 
-	gen := generator.New(data.NewReleases(tags, issues, mrs))
+	return writeChangelog(ctx.String("file"), gen)
+}
+
+// generateComponents writes a separate changelog for each component of monorepo,
+// the issues and MRs are assigned to the components via labels or changed paths
+func generateComponents(ctx *cli.Context, d *changelogData) error {
+	if d.commits != nil || d.compareURLs != nil {
+		return fmt.Errorf("direct commits and compare links are not supported for monorepo components")
+	}
+
+	labels, err := parseComponentList("labels", ctx.StringSlice("component-labels"))
+	if err != nil {
+		return err
+	}
+	paths, err := parseComponentList("paths", ctx.StringSlice("component-paths"))
+	if err != nil {
+		return err
+	}
+	matcher, err := data.NewComponentMatcher(labels, paths)
+	if err != nil {
+		return err
+	}
 
-	// use stdout if - is given, otherwise create a new file
-	filename := ctx.String("file")
-	var wr io.Writer
-	if filename != "-" {
-		var file *os.File
-		if file, err = os.Create(filename); err != nil {
+	tags := data.TagsByComponent(d.tags, d.opts.TagsFilter)
+	for _, component := range data.Components(tags) {
+		gen, err := d.generator(ctx,
+			tags[component],
+			data.FilterIssuesByComponent(d.issues, matcher, component),
+			data.FilterMRsByComponent(d.mrs, matcher, component),
+		)
+		if err != nil {
 			return err
 		}
 
-		defer func() {
-			if cerr := file.Close(); err == nil && cerr != nil {
-				err = cerr
-			}
-		}()
-
-		wr = file
-	} else {
-		wr = Stdout
+		filename := strings.Replace(ctx.String("component-file"), "{component}", component, -1)
+		if err = os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return err
+		}
+		if err = writeChangelog(filename, gen); err != nil {
+			return err
+		}
+		fmt.Fprintf(ProgressWriter, "Changelog of component %v written to %v\n", // nolint: errcheck
+			component, filename)
 	}
 
-	err = gen.Render(wr)
-
-	return err
+	return nil
 }
 
-// collectData fans-in data from different channels to the data structures
-func collectData( // nolint: gocyclo
-	ctx context.Context,
-	ctags <-chan data.Tag,
-	cissues <-chan data.Issue,
-	cmrs <-chan data.MR,
-	cerr <-chan error,
-) (
-	data.Tags,
-	data.Issues,
-	data.MRs,
-	error,
-) {
-	var (
-		tags   data.Tags
-		issues data.Issues
-		mrs    data.MRs
-	)
-
-	for {
-		select {
-		case <-ctx.Done():
-			return tags, issues, mrs, ctx.Err()
-		case err, ok := <-cerr:
-			if ok {
-				return nil, nil, nil, err
-			}
-		case t, ok := <-ctags:
-			if ok {
-				tags = append(tags, t)
-			} else { // tags are finished, nil the channel
-				ctags = nil
-			}
-		case i, ok := <-cissues:
-			if ok {
-				issues = append(issues, i)
-			} else { // issues are finished, nil the channel
-				cissues = nil
-			}
-		case m, ok := <-cmrs:
-			if ok {
-				mrs = append(mrs, m)
-			} else { // MRs are finished, nil the channel
-				cmrs = nil
-			}
-		}
-		// all channels finished, return data
-		if ctags == nil && cissues == nil && cmrs == nil {
-			return tags, issues, mrs, nil
+// parseComponentList parses the assignments like api=api,backend,
+// kind is used to describe the values in the error message
+func parseComponentList(kind string, list []string) (map[string][]string, error) {
+	ret := map[string][]string{}
+	for _, l := range list {
+		parts := strings.SplitN(l, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid component %v %v, expected component=x,y,z", kind, l)
 		}
+		component := strings.TrimSpace(parts[0])
+		ret[component] = append(ret[component], datasource.SplitList(parts[1])...)
 	}
+	return ret, nil
 }
 
-// getConnectorData returns all needed data from connector
-// if newRelease is specified, a new releases for
-// untagged activities is created
-func getConnectorData(
-	conn connectors.Connector,
-	tagsFilter *regexp.Regexp,
-	excludeLabels []string,
-	newRelease string,
-) (data.Tags, data.Issues, data.MRs, error) {
-
-	var (
-		tags   data.Tags
-		issues data.Issues
-		mrs    data.MRs
-	)
-
-	// one minute for data collection should be enougth for now
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
-	defer cancel()
-
-	// we use cerr to track the possible errors in all goroutines invoked here
-	cerr := make(chan error)
-
-	ctags, ctagscounter, cmaxtags := conn.Tags(ctx, cerr)
-	cissues, cissuescounter, cmaxissues := conn.Issues(ctx, cerr)
-	cmrs, cmrscounter, cmaxmrs := conn.MRs(ctx, cerr)
-
-	// invoke the progress printer
-	printProgress(
-		ctx, ProgressWriter,
-		ctagscounter, cmaxtags,
-		cissuescounter, cmaxissues,
-		cmrscounter, cmaxmrs)
-
-	//fan-in everything
-	tags, issues, mrs, err := collectData(
-		ctx,
-		ctags,
-		cissues,
-		cmrs,
-		cerr,
-	)
-	// release the err channel and only then process the possible errors
-	close(cerr)
-	if err != nil {
-		return nil, nil, nil, err
+// writeChangelog renders the changelog to the given file,
+// stdout is used if - is given
+func writeChangelog(filename string, gen *generator.Generator) (err error) {
+	if filename == "-" {
+		return gen.Render(Stdout)
 	}
 
-	// we should apply the filter to the tags
-	if tagsFilter != nil {
-		tags = data.FilterTags(tags, tagsFilter)
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
 	}
 
-	if newRelease != "" {
-		var relURL string
-		relURL, err = conn.GetNewTagURL(newRelease)
-		if err != nil {
-			return nil, nil, nil, err
+	defer func() {
+		if cerr := file.Close(); err == nil && cerr != nil {
+			err = cerr
 		}
+	}()
 
-		tags = append(tags, data.Tag{
-			Name: newRelease,
-			Date: time.Now(),
-			URL:  relURL,
-		})
-	}
-
-	// we should filter the labels
-	if len(excludeLabels) > 0 {
-		issues = data.FilterIssuesByLabel(issues, excludeLabels)
-		mrs = data.FilterMRsByLabel(mrs, excludeLabels)
-	}
-
-	return tags, issues, mrs, nil
+	return gen.Render(file)
 }
 
 // CLIFlags returns the possible CLI flags for this command
 func CLIFlags() []cli.Flag {
-	return []cli.Flag{
+	return append([]cli.Flag{
 		cli.StringFlag{
 			Name:  "file, f",
 			Usage: "File name of changelog, - is accepted for stdout",
 			Value: "CHANGELOG.md",
 		},
 		cli.StringFlag{
-			Name:  "new-release, r",
-			Usage: "Use the given release name and create a new release for all changes after the last tagged release", // nolint: lll
+			Name:  "component-file",
+			Usage: "File name of changelogs for monorepo components, {component} is replaced with the component name", // nolint: lll
+			Value: "{component}/CHANGELOG.md",
+		},
+		cli.StringSliceFlag{
+			Name:  "component-labels",
+			Usage: "Assign issues and MRs/PRs with labels to the monorepo component `component=x,y,z`, the component name is used as label by default", // nolint: lll
 		},
+		cli.StringSliceFlag{
+			Name:  "component-paths",
+			Usage: "Assign MRs/PRs, which changed files in the paths, to the monorepo component `component=x,y,z`", // nolint: lll
+		},
+	}, RenderCLIFlags()...)
+}
+
+// RenderCLIFlags returns the CLI flags, which control the rendering of releases.
+// They are shared with the commands rendering single releases
+func RenderCLIFlags() []cli.Flag {
+	return append([]cli.Flag{
 		cli.StringFlag{
-			Name:  "filter-tags, t",
-			Usage: "Only use tags, which match to the given regular expression",
-			Value: `^v\d+\.\d+\.\d+$`,
+			Name:  "exclude-authors",
+			Usage: "Exclude MRs/PRs of specified authors `x,y,z`, globs and /regex/ are supported",
 		},
 		cli.BoolFlag{
-			Name:  "no-filter-tags",
-			Usage: "Disable filtering of tags",
+			Name:  "exclude-bots",
+			Usage: "Exclude MRs/PRs of bot accounts like dependabot[bot]",
 		},
 		cli.StringFlag{
-			Name:  "exclude-labels",
-			Usage: "Exclude issues and MRs/PRs with specified labels `x,y,z`",
-			Value: "duplicate, question, invalid, wontfix, no changelog",
+			Name:  "collapse-authors",
+			Usage: "Summarize MRs/PRs of specified authors `x,y,z` as dependency updates",
+		},
+		cli.BoolFlag{
+			Name:  "collapse-bots",
+			Usage: "Summarize MRs/PRs of bot accounts like dependabot[bot] as dependency updates",
+		},
+		cli.BoolFlag{
+			Name:  "contributors",
+			Usage: "Render the contributors of each release and highlight the first-time contributors",
+		},
+		cli.BoolFlag{
+			Name:  "release-descriptions",
+			Usage: "Render the release descriptions and annotated tag messages",
+		},
+		cli.BoolFlag{
+			Name:  "commits",
+			Usage: "Render the direct commits, which are not part of any MR/PR",
+		},
+		cli.BoolFlag{
+			Name:  "compare-links",
+			Usage: "Render a link to the changes since the previous release",
+		},
+		cli.BoolFlag{
+			Name:  "milestones",
+			Usage: "Link the milestones with the same name as the release",
+		},
+		cli.BoolFlag{
+			Name:  "only-completed-issues",
+			Usage: "Include only issues closed as completed or closed by a merged MR/PR",
 		},
 		cli.StringFlag{
-			Name:  "endpoint",
-			Usage: "API endpoint type: " + strings.Join(connectors.RegisteredConnectors(), ", "),
-			Value: "github",
+			Name:  "linked-issues",
+			Usage: "Rendering of issues closed by MRs/PRs: list, nest (under the MR/PR) or hide",
+			Value: generator.LinkedIssuesList,
 		},
-	}
+	}, datasource.CLIFlags()...)
 }
 
 func init() { // nolint: gochecknoinits
-	flags := CLIFlags()
-
-	for _, conn := range connectors.RegisteredConnectors() {
-		connectorFlags, err := connectors.CLIFlags(conn)
-		if err != nil {
-			panic(err)
-		}
-
-		flags = append(flags, connectorFlags...)
-	}
+	flags := append(CLIFlags(), datasource.ConnectorCLIFlags()...)
 
 	commands.RegisterCommand(cli.Command{
 		Name:      "generate",
@@ -19,8 +19,13 @@ package generate_test
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"html/template"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -158,11 +163,13 @@ Merged pull requests
 
 func TestGenerate(t *testing.T) { // nolint: gocyclo
 	type cliParams struct {
-		newRelease    string
-		noFilterTags  bool
-		filterExpr    string
-		excludeLabels string
-		endpoint      string
+		newRelease     string
+		noFilterTags   bool
+		filterExpr     string
+		excludeLabels  string
+		excludeAuthors string
+		endpoint       string
+		linkedIssues   string
 	}
 
 	tests := []struct {
@@ -212,6 +219,13 @@ func TestGenerate(t *testing.T) { // nolint: gocyclo
 			},
 			wantOutput: genOutput(true, false, true, true),
 		},
+		{
+			name: "With broken author pattern",
+			cliParams: cliParams{
+				excludeAuthors: "/(abc/",
+			},
+			wantErr: errors.New("can't compile the author pattern /(abc/: error parsing regexp: missing closing ): `(?i)(abc`"), // nolint: lll
+		},
 		{
 			name:                 "Repository not found",
 			repositoryExistsFail: true,
@@ -224,6 +238,13 @@ func TestGenerate(t *testing.T) { // nolint: gocyclo
 			},
 			wantErr: errors.New("given endpoint isn't supported: wrongendpoint"),
 		},
+		{
+			name: "With wrong mode for linked issues",
+			cliParams: cliParams{
+				linkedIssues: "wrongmode",
+			},
+			wantErr: errors.New("unsupported mode for linked issues: wrongmode"),
+		},
 	}
 	for _, tt := range tests {
 		cliFlags := map[string]string{
@@ -245,6 +266,12 @@ func TestGenerate(t *testing.T) { // nolint: gocyclo
 		if tt.cliParams.excludeLabels != "" {
 			cliFlags["exclude-labels"] = tt.cliParams.excludeLabels
 		}
+		if tt.cliParams.excludeAuthors != "" {
+			cliFlags["exclude-authors"] = tt.cliParams.excludeAuthors
+		}
+		if tt.cliParams.linkedIssues != "" {
+			cliFlags["linked-issues"] = tt.cliParams.linkedIssues
+		}
 		ctx := tcli.TestContext(generate.CLIFlags(), cliFlags)
 
 		output := &bytes.Buffer{}
@@ -271,3 +298,89 @@ func TestGenerate(t *testing.T) { // nolint: gocyclo
 		})
 	}
 }
+
+func TestGenerateComponents(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chagen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	ctx := tcli.TestContext(generate.CLIFlags(), map[string]string{
+		"endpoint":         "testconnector",
+		"filter-tags":      `^(?P<component>v0\.[01])\.(?P<version>\d+)$`,
+		"component-file":   filepath.Join(dir, "{component}", "CHANGELOG.md"),
+		"component-labels": "v0.1=enhancement",
+	})
+
+	progress := &bytes.Buffer{}
+	generate.ProgressWriter = progress
+
+	testconnector.RetTestingTag = true
+	testconnector.RepositoryExistsFail = false
+
+	if err = generate.Generate(ctx); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	wantProgress := fmt.Sprintf(
+		"Changelog of component v0.0 written to %v\nChangelog of component v0.1 written to %v\n",
+		filepath.Join(dir, "v0.0", "CHANGELOG.md"),
+		filepath.Join(dir, "v0.1", "CHANGELOG.md"),
+	)
+	if !strings.HasSuffix(progress.String(), wantProgress) {
+		t.Errorf("Generate() progress = %v, want suffix %v", progress.String(), wantProgress)
+	}
+
+	changelog, err := ioutil.ReadFile(filepath.Join(dir, "v0.1", "CHANGELOG.md"))
+	if err != nil {
+		t.Fatalf("Generate() changelog of component is missing: %v", err)
+	}
+	for _, want := range []string{
+		"## [2](https://test.example.com/tags/v0.1.2)",
+		"## [0](https://test.example.com/tags/v0.1.0)",
+		"Test issue title 1 ",
+		"Test PR title 6 ",
+	} {
+		if !strings.Contains(string(changelog), want) {
+			t.Errorf("Generate() changelog = %v, should contain %v", string(changelog), want)
+		}
+	}
+	for _, unwanted := range []string{"## [9]", "Test PR title 10 "} {
+		if strings.Contains(string(changelog), unwanted) {
+			t.Errorf("Generate() changelog = %v, should not contain %v", string(changelog), unwanted)
+		}
+	}
+}
+
+func TestGenerateCompletedIssuesOfFilteredMRs(t *testing.T) {
+	ctx := tcli.TestContext(generate.CLIFlags(), map[string]string{
+		"file":                  "-",
+		"endpoint":              "testconnector",
+		"exclude-mr-labels":     "bugfix",
+		"only-completed-issues": "true",
+	})
+
+	output := &bytes.Buffer{}
+	generate.Stdout = output
+	generate.ProgressWriter = &bytes.Buffer{}
+
+	testconnector.RetTestingTag = true
+	testconnector.RepositoryExistsFail = false
+
+	if err := generate.Generate(ctx); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// the issue 1214 is closed by the excluded MR 2214 and should stay completed
+	for _, want := range []string{"Test issue title 1 ", "Test issue title 9 "} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("Generate() output = %v, should contain %v", output.String(), want)
+		}
+	}
+	for _, unwanted := range []string{"Test issue title 4 ", "Test PR title 1 "} {
+		if strings.Contains(output.String(), unwanted) {
+			t.Errorf("Generate() output = %v, should not contain %v", output.String(), unwanted)
+		}
+	}
+}
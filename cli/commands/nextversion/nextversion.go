@@ -0,0 +1,80 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package nextversion implements the next-version command
+package nextversion
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/artem-sidorenko/chagen/cli/commands"
+	"github.com/artem-sidorenko/chagen/datasource"
+	"github.com/artem-sidorenko/chagen/internal/output"
+
+	"github.com/urfave/cli"
+)
+
+// Stdout references the Stdout writer for next-version command
+var Stdout io.Writer = output.Stdout // nolint: gochecknoglobals
+// ProgressWriter references the writer for progress information
+var ProgressWriter io.Writer = output.Stderr // nolint: gochecknoglobals
+
+// NextVersion implements the CLI subcommand next-version,
+// the suggested version is written to stdout
+func NextVersion(ctx *cli.Context) error {
+	opts, err := datasource.NewOptions(ctx)
+	if err != nil {
+		return err
+	}
+	// the unreleased changes should not be moved to a new release
+	opts.NewRelease = ""
+
+	tags, issues, mrs, err := datasource.GetData(ctx, opts, ProgressWriter)
+	if err != nil {
+		return err
+	}
+
+	version, err := datasource.NextVersion(tags, issues, mrs, opts)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(Stdout, version)
+	return err
+}
+
+// CLIFlags returns the possible CLI flags for this command
+func CLIFlags() []cli.Flag {
+	return datasource.CLIFlags()
+}
+
+func init() { // nolint: gochecknoinits
+	flags := append(CLIFlags(), datasource.ConnectorCLIFlags()...)
+
+	commands.RegisterCommand(cli.Command{
+		Name:      "next-version",
+		Usage:     "Suggest the next version using the changes after the latest release",
+		ArgsUsage: " ", // we do not have any args (only flags), so avoid this help message
+		Flags:     flags,
+		Action: func(c *cli.Context) error {
+			if err := NextVersion(c); err != nil { // exit 1 and error message if we get any error reported
+				return cli.NewExitError(err, 1)
+			}
+			return nil
+		},
+	})
+}
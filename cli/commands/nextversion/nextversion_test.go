@@ -0,0 +1,99 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package nextversion_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/cli/commands/nextversion"
+	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+	"github.com/artem-sidorenko/chagen/internal/testing/testconnector"
+)
+
+func TestNextVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		cliFlags   map[string]string
+		wantErr    error
+		wantOutput string
+	}{
+		{
+			name:       "Minor bump via enhancement label",
+			wantOutput: "v0.2.0\n",
+		},
+		{
+			name:       "Patch bump without minor labels",
+			cliFlags:   map[string]string{"minor-labels": ""},
+			wantOutput: "v0.1.3\n",
+		},
+		{
+			name:       "Major bump via customized labels",
+			cliFlags:   map[string]string{"major-labels": "issue12"},
+			wantOutput: "v1.0.0\n",
+		},
+		{
+			name:     "No unreleased changes",
+			cliFlags: map[string]string{"include-labels": "unknown"},
+			wantErr:  errors.New("no unreleased changes after v0.1.2"),
+		},
+		{
+			name: "Tags of monorepo component",
+			cliFlags: map[string]string{
+				"filter-tags": `^(?P<component>v0\.[01])\.\d+$`,
+				"component":   "v0.0",
+			},
+			wantOutput: "v0.1.0\n",
+		},
+		{
+			name:     "Missing monorepo component",
+			cliFlags: map[string]string{"filter-tags": `^(?P<component>v0\.[01])\.\d+$`},
+			wantErr:  errors.New("option --component is required for the tags of monorepo components"),
+		},
+		{
+			name:     "Broken label pattern",
+			cliFlags: map[string]string{"major-labels": "/(abc/"},
+			wantErr:  errors.New("can't compile the label pattern /(abc/: error parsing regexp: missing closing ): `(?i)(abc`"), // nolint: lll
+		},
+	}
+	for _, tt := range tests {
+		cliFlags := map[string]string{"endpoint": "testconnector"}
+		for k, v := range tt.cliFlags {
+			cliFlags[k] = v
+		}
+		ctx := tcli.TestContext(nextversion.CLIFlags(), cliFlags)
+
+		output := &bytes.Buffer{}
+		nextversion.Stdout = output
+		nextversion.ProgressWriter = &bytes.Buffer{}
+
+		testconnector.RetTestingTag = false
+		testconnector.RepositoryExistsFail = false
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := nextversion.NextVersion(ctx)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("NextVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if out := output.String(); out != tt.wantOutput {
+				t.Errorf("NextVersion() output = %v, wantOutput %v", out, tt.wantOutput)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package publish implements the publish command
+package publish
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/artem-sidorenko/chagen/cli/commands"
+	"github.com/artem-sidorenko/chagen/cli/commands/generate"
+	"github.com/artem-sidorenko/chagen/datasource"
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+	"github.com/artem-sidorenko/chagen/internal/output"
+
+	"github.com/urfave/cli"
+)
+
+// ProgressWriter references the writer for progress information
+var ProgressWriter io.Writer = output.Stderr // nolint: gochecknoglobals
+
+// Publish implements the CLI subcommand publish,
+// the notes of given release are published to the hosted release of endpoint
+func Publish(ctx *cli.Context) error {
+	name := ctx.Args().First()
+	if name == "" {
+		return fmt.Errorf("release name is missing")
+	}
+
+	gen, conn, err := generate.NewGenerator(ctx, ProgressWriter)
+	if err != nil {
+		return err
+	}
+
+	publisher, ok := conn.(connectors.Publisher)
+	if !ok {
+		return fmt.Errorf("endpoint %v does not support publishing of releases", ctx.String("endpoint"))
+	}
+
+	// the description of release is its current body, it should not be nested
+	gen.ShowDescriptions = false
+	body := &bytes.Buffer{}
+	if err = gen.RenderRelease(body, name); err != nil {
+		return err
+	}
+
+	opts := connectors.PublishOptions{
+		Tag:           name,
+		Body:          body.String(),
+		OnlyIfChanged: ctx.Bool("only-if-changed"),
+	}
+	// the states of existing releases are kept, if the flags are not given
+	if ctx.IsSet("draft") {
+		opts.Draft = helpers.BoolPtr(ctx.Bool("draft"))
+	}
+	if ctx.IsSet("prerelease") {
+		opts.Prerelease = helpers.BoolPtr(ctx.Bool("prerelease"))
+	}
+
+	status, err := publisher.PublishRelease(opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ProgressWriter, "Release %v %v\n", name, status) // nolint: errcheck
+	return nil
+}
+
+// CLIFlags returns the possible CLI flags for this command
+func CLIFlags() []cli.Flag {
+	return append([]cli.Flag{
+		cli.BoolFlag{
+			Name:  "draft",
+			Usage: "Publish the release as draft",
+		},
+		cli.BoolFlag{
+			Name:  "prerelease",
+			Usage: "Publish the release as prerelease",
+		},
+		cli.BoolFlag{
+			Name:  "only-if-changed",
+			Usage: "Update an existing release only if its content differs",
+		},
+	}, generate.RenderCLIFlags()...)
+}
+
+func init() { // nolint: gochecknoinits
+	flags := append(CLIFlags(), datasource.ConnectorCLIFlags()...)
+
+	commands.RegisterCommand(cli.Command{
+		Name:      "publish",
+		Usage:     "Create or update the hosted release with the notes of a single release",
+		ArgsUsage: "<release>",
+		Flags:     flags,
+		Action: func(c *cli.Context) error {
+			if err := Publish(c); err != nil { // exit 1 and error message if we get any error reported
+				return cli.NewExitError(err, 1)
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,128 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package publish_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/cli/commands/publish"
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+	"github.com/artem-sidorenko/chagen/internal/testing/testconnector"
+
+	_ "github.com/artem-sidorenko/chagen/internal/testing/testconnector"
+)
+
+func TestPublish(t *testing.T) {
+	defer func() { testconnector.TagDescription = "" }()
+
+	tests := []struct {
+		name           string
+		args           []string
+		cliFlags       map[string]string
+		tagDescription string
+		wantErr        error
+		wantProgress   string
+		wantPublished  []connectors.PublishOptions
+	}{
+		{
+			name:     "New release",
+			args:     []string{"v0.0.2"},
+			cliFlags: map[string]string{"prerelease": "true", "only-if-changed": "true"},
+			// the description is the current body of the release and is not rendered
+			tagDescription: "Body of the existing release",
+			wantProgress:   "Release v0.0.2 created\n",
+			wantPublished: []connectors.PublishOptions{
+				{
+					Tag: "v0.0.2",
+					// nolint: lll
+					Body: `## [v0.0.2](https://test.example.com/tags/v0.0.2) (09.03.2003)
+
+Closed issues
+-------------
+- Test issue title 1 [\#1214](http://test.example.com/issues/1214)
+
+Merged pull requests
+--------------------
+- Test PR title 1 [\#2214](https://test.example.com/mrs/2214) ([test-user](https://test.example.com/authors/test-user))
+`,
+					Prerelease:    helpers.BoolPtr(true),
+					OnlyIfChanged: true,
+				},
+			},
+		},
+		{
+			name:         "Release without states",
+			args:         []string{"v0.0.1"},
+			wantProgress: "Release v0.0.1 created\n",
+			wantPublished: []connectors.PublishOptions{
+				{
+					Tag: "v0.0.1",
+					// nolint: lll
+					Body: `## [v0.0.1](https://test.example.com/tags/v0.0.1) (08.03.2003)
+`,
+				},
+			},
+		},
+		{
+			name:    "Missing release name",
+			wantErr: errors.New("release name is missing"),
+		},
+		{
+			name:    "Unknown release",
+			args:    []string{"v9.9.9"},
+			wantErr: errors.New("release v9.9.9 not found"),
+		},
+	}
+	for _, tt := range tests {
+		cliFlags := map[string]string{"endpoint": "testconnector"}
+		for k, v := range tt.cliFlags {
+			cliFlags[k] = v
+		}
+		ctx := tcli.TestContextWithArgs(
+			append(publish.CLIFlags(), connectors.CommonCLIFlags()...),
+			cliFlags,
+			tt.args,
+		)
+
+		progress := &bytes.Buffer{}
+		publish.ProgressWriter = progress
+
+		testconnector.RetTestingTag = false
+		testconnector.RepositoryExistsFail = false
+		testconnector.Published = nil
+		testconnector.TagDescription = tt.tagDescription
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := publish.Publish(ctx)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Publish() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(testconnector.Published, tt.wantPublished) {
+				t.Errorf("Publish() published = %+v, want %+v", testconnector.Published, tt.wantPublished)
+			}
+			if tt.wantProgress != "" && !strings.HasSuffix(progress.String(), tt.wantProgress) {
+				t.Errorf("Publish() progress = %v, want %v", progress.String(), tt.wantProgress)
+			}
+		})
+	}
+}
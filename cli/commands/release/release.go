@@ -0,0 +1,255 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package release implements the release command
+package release
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/artem-sidorenko/chagen/cli/commands"
+	"github.com/artem-sidorenko/chagen/cli/commands/generate"
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource"
+	"github.com/artem-sidorenko/chagen/internal/output"
+
+	"github.com/urfave/cli"
+)
+
+// Stdout references the Stdout writer for release command
+var Stdout io.Writer = output.Stdout // nolint: gochecknoglobals
+// ProgressWriter references the writer for progress information
+var ProgressWriter io.Writer = output.Stderr // nolint: gochecknoglobals
+
+// Git runs git with given arguments and returns its output,
+// it is replaced in the tests
+var Git = func(args ...string) (string, error) { // nolint: gochecknoglobals
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %v failed: %v: %v",
+			strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// Release implements the CLI subcommand release: the changelog is generated
+// with the new release, committed and an annotated tag with the release notes is created.
+// The tag message contains the notes without heading, they are marked
+// as generated to avoid their rendering as release description later
+func Release(ctx *cli.Context) error {
+	version := ctx.String("version")
+	if version == "" {
+		return fmt.Errorf("option --version is required")
+	}
+	filename := ctx.String("file")
+	if filename == "-" {
+		return fmt.Errorf("changelog file is required for releases, stdout is not supported")
+	}
+	prefix := ctx.String("tag-prefix")
+	if prefix != "" && strings.HasPrefix(version, prefix) {
+		return fmt.Errorf("version %v already contains the tag prefix %v, use --version %v",
+			version, prefix, strings.TrimPrefix(version, prefix))
+	}
+	tag := prefix + version
+	branch := ctx.String("branch")
+	// the remote is only needed and checked, if the release is pushed
+	remote := ""
+	if ctx.Bool("push") {
+		remote = ctx.String("remote")
+	}
+
+	if err := checkRepository(branch, tag, remote); err != nil {
+		return err
+	}
+
+	if err := ctx.Set("new-release", tag); err != nil {
+		return err
+	}
+
+	gen, _, err := generate.NewGenerator(ctx, ProgressWriter)
+	if err != nil {
+		return err
+	}
+
+	changelog := &bytes.Buffer{}
+	if err = gen.Render(changelog); err != nil {
+		return err
+	}
+	notes := &bytes.Buffer{}
+	if err = gen.RenderReleaseBody(notes, tag); err != nil {
+		return err
+	}
+	fmt.Fprintf(notes, "\n%v\n", data.GeneratedNotesTrailer) // nolint: errcheck
+
+	tagCmd := []string{"tag", "-a"}
+	if ctx.Bool("sign") {
+		tagCmd = append(tagCmd, "-s")
+	}
+	if key := ctx.String("sign-key"); key != "" {
+		tagCmd = append(tagCmd, "-u", key)
+	}
+	// the release notes contain markdown headers, they should not be stripped as comments
+	tagCmd = append(tagCmd, "--cleanup=whitespace", "-m", notes.String(), tag)
+
+	cmds := [][]string{
+		{"add", filename},
+		{"commit", "-m", "Release " + version},
+		tagCmd,
+	}
+	if remote != "" {
+		cmds = append(cmds,
+			[]string{"push", remote, branch},
+			[]string{"push", remote, "refs/tags/" + tag},
+		)
+	}
+
+	if ctx.Bool("dry-run") {
+		fmt.Fprintf(Stdout, "Would write the changelog to %v and run:\n", filename) // nolint: errcheck
+		for _, cmd := range cmds {
+			fmt.Fprintln(Stdout, formatCommand(cmd)) // nolint: errcheck
+		}
+		return nil
+	}
+
+	if err = ioutil.WriteFile(filename, changelog.Bytes(), 0644); err != nil { // nolint: gosec
+		return err
+	}
+	for _, cmd := range cmds {
+		if _, err = Git(cmd...); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(ProgressWriter, "Release %v created\n", tag) // nolint: errcheck
+	return nil
+}
+
+// checkRepository verifies that the working tree is clean,
+// the given branch is checked out and the tag does not exist yet.
+// The tags of given remote are checked too, if it isn't empty
+func checkRepository(branch, tag, remote string) error {
+	status, err := Git("status", "--porcelain")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(status) != "" {
+		return fmt.Errorf("working tree is not clean, commit or stash the changes first")
+	}
+
+	current, err := Git("symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return err
+	}
+	if current = strings.TrimSpace(current); current != branch {
+		return fmt.Errorf("releases should be created on the branch %v, current branch is %v",
+			branch, current)
+	}
+
+	tags, err := Git("tag", "--list", tag)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(tags) != "" {
+		return fmt.Errorf("tag %v already exists", tag)
+	}
+
+	if remote != "" {
+		if tags, err = Git("ls-remote", "--tags", remote, "refs/tags/"+tag); err != nil {
+			return err
+		}
+		if strings.TrimSpace(tags) != "" {
+			return fmt.Errorf("tag %v already exists on the remote %v", tag, remote)
+		}
+	}
+
+	return nil
+}
+
+// formatCommand returns the git command line, arguments with spaces are quoted
+func formatCommand(args []string) string {
+	ret := []string{"git"}
+	for _, arg := range args {
+		if strings.ContainsAny(arg, " \t\n\"'") {
+			arg = strconv.Quote(arg)
+		}
+		ret = append(ret, arg)
+	}
+	return strings.Join(ret, " ")
+}
+
+// CLIFlags returns the possible CLI flags for this command
+func CLIFlags() []cli.Flag {
+	return append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "version",
+			Usage: "Version of the new release without tag prefix, e.g. 1.2.3",
+		},
+		cli.StringFlag{
+			Name:  "tag-prefix",
+			Usage: "Prefix of the release tag",
+			Value: "v",
+		},
+		cli.StringFlag{
+			Name:  "branch",
+			Usage: "Branch, which should be checked out for the release",
+			Value: "master",
+		},
+		cli.BoolFlag{
+			Name:  "sign",
+			Usage: "Sign the release tag with the default GPG key",
+		},
+		cli.StringFlag{
+			Name:  "sign-key",
+			Usage: "Sign the release tag with the given GPG `key`",
+		},
+		cli.BoolFlag{
+			Name:  "push",
+			Usage: "Push the release commit and tag",
+		},
+		cli.StringFlag{
+			Name:  "remote",
+			Usage: "Remote for pushing of the release",
+			Value: "origin",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Print the git commands instead of running them",
+		},
+	}, generate.CLIFlags()...)
+}
+
+func init() { // nolint: gochecknoinits
+	flags := append(CLIFlags(), datasource.ConnectorCLIFlags()...)
+
+	commands.RegisterCommand(cli.Command{
+		Name:      "release",
+		Usage:     "Commit the changelog with a new release and create the release tag",
+		ArgsUsage: " ", // we do not have any args (only flags), so avoid this help message
+		Flags:     flags,
+		Action: func(c *cli.Context) error {
+			if err := Release(c); err != nil { // exit 1 and error message if we get any error reported
+				return cli.NewExitError(err, 1)
+			}
+			return nil
+		},
+	})
+}
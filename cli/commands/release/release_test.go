@@ -0,0 +1,230 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package release_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/cli/commands/release"
+	"github.com/artem-sidorenko/chagen/data"
+	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+	"github.com/artem-sidorenko/chagen/internal/testing/testconnector"
+)
+
+// fakeGit records the git commands and simulates the repository state
+type fakeGit struct {
+	status     string
+	branch     string
+	tags       string
+	remoteTags string
+	commands   [][]string
+}
+
+func (f *fakeGit) run(args ...string) (string, error) {
+	switch args[0] {
+	case "status":
+		return f.status, nil
+	case "symbolic-ref":
+		return f.branch + "\n", nil
+	case "tag":
+		if args[1] == "--list" {
+			return f.tags, nil
+		}
+	case "ls-remote":
+		return f.remoteTags, nil
+	}
+	f.commands = append(f.commands, args)
+	return "", nil
+}
+
+func TestRelease(t *testing.T) { // nolint: gocyclo
+	heading := fmt.Sprintf("## [v10.10.0](http://test.example.com/releases/v10.10.0) (%v)\n",
+		time.Now().Format(data.ReleaseDateFormat))
+	// the tag message contains the notes without heading and is marked as generated
+	// nolint: lll
+	body := `Closed issues
+-------------
+- Test issue title 13 [\#1234](http://test.example.com/issues/1234)
+- Test issue title 12 [\#1224](http://test.example.com/issues/1224)
+
+Merged pull requests
+--------------------
+- Test PR title 14 [\#2344](https://test.example.com/mrs/2344) ([te77st-user](https://test.example.com/authors/te77st-user))
+- Test PR title 13 [\#2334](https://test.example.com/mrs/2334) ([test-user](https://test.example.com/authors/test-user))
+`
+	notes := body + "\n" + data.GeneratedNotesTrailer + "\n"
+
+	dir, err := ioutil.TempDir("", "chagen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+	file := filepath.Join(dir, "CHANGELOG.md")
+
+	tests := []struct {
+		name          string
+		cliFlags      map[string]string
+		git           fakeGit
+		wantErr       error
+		wantCommands  [][]string
+		wantOutput    string
+		wantProgress  string
+		wantChangelog bool
+	}{
+		{
+			name:     "Release",
+			cliFlags: map[string]string{"version": "10.10.0"},
+			git:      fakeGit{branch: "master"},
+			wantCommands: [][]string{
+				{"add", file},
+				{"commit", "-m", "Release 10.10.0"},
+				{"tag", "-a", "--cleanup=whitespace", "-m", notes, "v10.10.0"},
+			},
+			wantProgress:  "Release v10.10.0 created\n",
+			wantChangelog: true,
+		},
+		{
+			name: "Signed release with push",
+			cliFlags: map[string]string{
+				"version":  "10.10.0",
+				"branch":   "main",
+				"sign-key": "8B4B87B9",
+				"push":     "true",
+			},
+			git: fakeGit{branch: "main"},
+			wantCommands: [][]string{
+				{"add", file},
+				{"commit", "-m", "Release 10.10.0"},
+				{"tag", "-a", "-u", "8B4B87B9", "--cleanup=whitespace", "-m", notes, "v10.10.0"},
+				{"push", "origin", "main"},
+				{"push", "origin", "refs/tags/v10.10.0"},
+			},
+			wantProgress:  "Release v10.10.0 created\n",
+			wantChangelog: true,
+		},
+		{
+			name:     "Dry run",
+			cliFlags: map[string]string{"version": "10.10.0", "sign": "true", "dry-run": "true"},
+			git:      fakeGit{branch: "master"},
+			wantOutput: fmt.Sprintf(`Would write the changelog to %v and run:
+git add %v
+git commit -m "Release 10.10.0"
+git tag -a -s --cleanup=whitespace -m %q v10.10.0
+`, file, file, notes),
+		},
+		{
+			name:    "Missing version",
+			git:     fakeGit{branch: "master"},
+			wantErr: errors.New("option --version is required"),
+		},
+		{
+			name:     "Dirty working tree",
+			cliFlags: map[string]string{"version": "10.10.0"},
+			git:      fakeGit{branch: "master", status: " M README.md\n"},
+			wantErr:  errors.New("working tree is not clean, commit or stash the changes first"),
+		},
+		{
+			name:     "Wrong branch",
+			cliFlags: map[string]string{"version": "10.10.0"},
+			git:      fakeGit{branch: "feature"},
+			wantErr: errors.New(
+				"releases should be created on the branch master, current branch is feature"),
+		},
+		{
+			name:     "Existing tag",
+			cliFlags: map[string]string{"version": "10.10.0"},
+			git:      fakeGit{branch: "master", tags: "v10.10.0\n"},
+			wantErr:  errors.New("tag v10.10.0 already exists"),
+		},
+		{
+			name:     "Existing tag on the remote",
+			cliFlags: map[string]string{"version": "10.10.0", "push": "true"},
+			git: fakeGit{
+				branch:     "master",
+				remoteTags: "d8351413f688c96c2c5d6fe58ebf5ac17f545bc0\trefs/tags/v10.10.0\n",
+			},
+			wantErr: errors.New("tag v10.10.0 already exists on the remote origin"),
+		},
+		{
+			name:     "Version with tag prefix",
+			cliFlags: map[string]string{"version": "v10.10.0"},
+			git:      fakeGit{branch: "master"},
+			wantErr: errors.New(
+				"version v10.10.0 already contains the tag prefix v, use --version 10.10.0"),
+		},
+	}
+	for _, tt := range tests {
+		cliFlags := map[string]string{
+			"file":     file,
+			"endpoint": "testconnector",
+		}
+		for k, v := range tt.cliFlags {
+			cliFlags[k] = v
+		}
+		ctx := tcli.TestContext(release.CLIFlags(), cliFlags)
+
+		output := &bytes.Buffer{}
+		release.Stdout = output
+		progress := &bytes.Buffer{}
+		release.ProgressWriter = progress
+
+		git := tt.git
+		release.Git = git.run
+
+		testconnector.RetTestingTag = true
+		testconnector.RepositoryExistsFail = false
+
+		t.Run(tt.name, func(t *testing.T) {
+			os.Remove(file) // nolint: errcheck, gosec
+
+			err := release.Release(ctx)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Release() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(git.commands, tt.wantCommands) {
+				t.Errorf("Release() commands = %q, want %q", git.commands, tt.wantCommands)
+			}
+			if out := output.String(); out != tt.wantOutput {
+				t.Errorf("Release() output = %v, wantOutput %v", out, tt.wantOutput)
+			}
+			if p := progress.String(); !strings.HasSuffix(p, tt.wantProgress) {
+				t.Errorf("Release() progress = %v, wantProgress %v", p, tt.wantProgress)
+			}
+
+			changelog, err := ioutil.ReadFile(file) // nolint: gosec
+			if tt.wantChangelog {
+				if err != nil {
+					t.Fatalf("Release() changelog not written: %v", err)
+				}
+				if want := heading + "\n" + body; !strings.Contains(string(changelog), want) {
+					t.Errorf("Release() changelog = %v, want the section %v", string(changelog), want)
+				}
+			} else if err == nil {
+				t.Errorf("Release() changelog should not be written")
+			}
+		})
+	}
+}
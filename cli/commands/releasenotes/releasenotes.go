@@ -0,0 +1,73 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package releasenotes implements the release-notes command
+package releasenotes
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/artem-sidorenko/chagen/cli/commands"
+	"github.com/artem-sidorenko/chagen/cli/commands/generate"
+	"github.com/artem-sidorenko/chagen/datasource"
+	"github.com/artem-sidorenko/chagen/internal/output"
+
+	"github.com/urfave/cli"
+)
+
+// Stdout references the Stdout writer for release-notes command
+var Stdout io.Writer = output.Stdout // nolint: gochecknoglobals
+// ProgressWriter references the writer for progress information
+var ProgressWriter io.Writer = output.Stderr // nolint: gochecknoglobals
+
+// ReleaseNotes implements the CLI subcommand release-notes,
+// only the section of given release is written to stdout
+func ReleaseNotes(ctx *cli.Context) error {
+	name := ctx.Args().First()
+	if name == "" {
+		return fmt.Errorf("release name is missing")
+	}
+
+	gen, _, err := generate.NewGenerator(ctx, ProgressWriter)
+	if err != nil {
+		return err
+	}
+
+	return gen.RenderRelease(Stdout, name)
+}
+
+// CLIFlags returns the possible CLI flags for this command
+func CLIFlags() []cli.Flag {
+	return generate.RenderCLIFlags()
+}
+
+func init() { // nolint: gochecknoinits
+	flags := append(CLIFlags(), datasource.ConnectorCLIFlags()...)
+
+	commands.RegisterCommand(cli.Command{
+		Name:      "release-notes",
+		Usage:     "Print the notes of a single release, e.g. for the release description",
+		ArgsUsage: "<release>",
+		Flags:     flags,
+		Action: func(c *cli.Context) error {
+			if err := ReleaseNotes(c); err != nil { // exit 1 and error message if we get any error reported
+				return cli.NewExitError(err, 1)
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,173 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package releasenotes_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/cli/commands/releasenotes"
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+	"github.com/artem-sidorenko/chagen/internal/testing/testconnector"
+
+	_ "github.com/artem-sidorenko/chagen/internal/testing/testconnector"
+)
+
+func TestReleaseNotes(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		cliFlags     map[string]string
+		wantErr      error
+		wantOutput   string
+		wantProgress string
+	}{
+		{
+			name: "Existing release",
+			args: []string{"v0.0.3"},
+			// nolint: lll
+			wantOutput: `## [v0.0.3](https://test.example.com/tags/v0.0.3) (10.03.2003)
+
+Closed issues
+-------------
+- Test issue title 2 [\#1227](http://test.example.com/issues/1227)
+
+Merged pull requests
+--------------------
+- Test PR title 2 [\#2224](https://test.example.com/mrs/2224) ([test-user2](https://test.example.com/authors/test-user2))
+`,
+		},
+		{
+			name:     "Automatic new release",
+			args:     []string{"v0.2.0"},
+			cliFlags: map[string]string{"new-release": "auto"},
+			// nolint: lll
+			wantOutput: fmt.Sprintf(`## [v0.2.0](http://test.example.com/releases/v0.2.0) (%v)
+
+Closed issues
+-------------
+- Test issue title 13 [\#1234](http://test.example.com/issues/1234)
+- Test issue title 12 [\#1224](http://test.example.com/issues/1224)
+
+Merged pull requests
+--------------------
+- Test PR title 14 [\#2344](https://test.example.com/mrs/2344) ([te77st-user](https://test.example.com/authors/te77st-user))
+- Test PR title 13 [\#2334](https://test.example.com/mrs/2334) ([test-user](https://test.example.com/authors/test-user))
+`, time.Now().Format(data.ReleaseDateFormat)),
+		},
+		{
+			name:     "With path filter",
+			args:     []string{"v0.0.2"},
+			cliFlags: map[string]string{"path": "docs/*"},
+			wantOutput: `## [v0.0.2](https://test.example.com/tags/v0.0.2) (09.03.2003)
+
+Closed issues
+-------------
+- Test issue title 1 [\#1214](http://test.example.com/issues/1214)
+`,
+		},
+		{
+			name:     "With direct commits",
+			args:     []string{"v0.1.2"},
+			cliFlags: map[string]string{"commits": "true"},
+			// nolint: lll
+			wantOutput: `## [v0.1.2](https://test.example.com/tags/v0.1.2) (20.03.2003)
+
+Direct commits
+--------------
+- Release v0.1.2 [d835141](https://test.example.com/commits/d8351413f688c96c2c5d6fe58ebf5ac17f545bc0) ([test-user](https://test.example.com/authors/test-user))
+- Fix typo in README [5e2c7d0](https://test.example.com/commits/5e2c7d0f9b1a4c3e8d6f2a1b0c9e8d7f6a5b4c3d) ([test-user](https://test.example.com/authors/test-user))
+`,
+		},
+		{
+			name:     "Commits of merged branches are skipped",
+			args:     []string{"v0.2.0"},
+			cliFlags: map[string]string{"commits": "true", "new-release": "v0.2.0", "include-labels": "unknown"},
+			// nolint: lll
+			wantOutput: fmt.Sprintf(`## [v0.2.0](http://test.example.com/releases/v0.2.0) (%v)
+
+Direct commits
+--------------
+- Update dependencies [f0e1d2c](https://test.example.com/commits/f0e1d2c3b4a5968778695a4b3c2d1e0f9a8b7c6d) ([test-user](https://test.example.com/authors/test-user))
+`, time.Now().Format(data.ReleaseDateFormat)),
+		},
+		{
+			name:     "With compare links and unsupported milestones",
+			args:     []string{"v0.1.2"},
+			cliFlags: map[string]string{"compare-links": "true", "milestones": "true", "include-labels": "unknown"},
+			wantOutput: `## [v0.1.2](https://test.example.com/tags/v0.1.2) (20.03.2003)
+
+[Full changelog](https://test.example.com/compare/v0.1.1...v0.1.2)
+`,
+			wantProgress: "Endpoint testconnector does not support milestones, skipping\n",
+		},
+		{
+			name:     "Direct commits with path filter",
+			args:     []string{"v0.1.2"},
+			cliFlags: map[string]string{"commits": "true", "path": "docs"},
+			wantErr:  errors.New("options --commits and --path can't be combined"),
+		},
+		{
+			name:    "Missing release name",
+			wantErr: errors.New("release name is missing"),
+		},
+		{
+			name:    "Unknown release",
+			args:    []string{"v9.9.9"},
+			wantErr: errors.New("release v9.9.9 not found"),
+		},
+	}
+	for _, tt := range tests {
+		cliFlags := map[string]string{"endpoint": "testconnector"}
+		for k, v := range tt.cliFlags {
+			cliFlags[k] = v
+		}
+		ctx := tcli.TestContextWithArgs(
+			append(releasenotes.CLIFlags(), connectors.CommonCLIFlags()...),
+			cliFlags,
+			tt.args,
+		)
+
+		output := &bytes.Buffer{}
+		releasenotes.Stdout = output
+		progress := &bytes.Buffer{}
+		releasenotes.ProgressWriter = progress
+
+		testconnector.RetTestingTag = false
+		testconnector.RepositoryExistsFail = false
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := releasenotes.ReleaseNotes(ctx)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("ReleaseNotes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if out := output.String(); out != tt.wantOutput {
+				t.Errorf("ReleaseNotes() output = %v, wantOutput %v", out, tt.wantOutput)
+			}
+			if !strings.Contains(progress.String(), tt.wantProgress) {
+				t.Errorf("ReleaseNotes() progress = %v, wantProgress %v", progress.String(), tt.wantProgress)
+			}
+		})
+	}
+}
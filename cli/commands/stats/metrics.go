@@ -0,0 +1,170 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// possible periods for the aggregation of releases
+const (
+	PeriodMonth   = "month"
+	PeriodQuarter = "quarter"
+	PeriodYear    = "year"
+)
+
+// ReleaseStats describes the metrics of a single release
+type ReleaseStats struct {
+	Release           string         `json:"release"`
+	Date              string         `json:"date"`
+	Issues            int            `json:"issues"`
+	MRs               int            `json:"mrs"`
+	Contributors      int            `json:"contributors"`
+	DaysSincePrevious *float64       `json:"days_since_previous,omitempty"`
+	Labels            map[string]int `json:"labels"`
+}
+
+// PeriodStats describes the metrics of all releases within a period
+type PeriodStats struct {
+	Period       string         `json:"period"`
+	Releases     int            `json:"releases"`
+	Issues       int            `json:"issues"`
+	MRs          int            `json:"mrs"`
+	Contributors int            `json:"contributors"`
+	Labels       map[string]int `json:"labels"`
+}
+
+// Metrics contains the metrics of releases and periods
+type Metrics struct {
+	Releases                  []ReleaseStats `json:"releases"`
+	Periods                   []PeriodStats  `json:"periods"`
+	MedianDaysBetweenReleases float64        `json:"median_days_between_releases"`
+}
+
+// NewMetrics calculates the metrics of given releases, which are aggregated by period.
+// Releases are expected to be sorted from newest to oldest
+func NewMetrics(r data.Releases, period string) (*Metrics, error) {
+	ret := &Metrics{
+		Releases: []ReleaseStats{},
+		Periods:  []PeriodStats{},
+	}
+
+	var intervals []float64
+	periodContributors := map[string]map[string]bool{}
+	periodIndex := map[string]int{}
+
+	for i, rel := range r {
+		rs := ReleaseStats{
+			Release:      rel.Release,
+			Date:         rel.Date,
+			Issues:       len(rel.Issues),
+			MRs:          len(rel.MRs) + len(rel.CollapsedMRs),
+			Contributors: len(rel.Contributors),
+			Labels:       labelDistribution(rel),
+		}
+		if i < len(r)-1 {
+			days := rel.Time.Sub(r[i+1].Time).Hours() / 24
+			rs.DaysSincePrevious = &days
+			intervals = append(intervals, days)
+		}
+		ret.Releases = append(ret.Releases, rs)
+
+		key, err := periodKey(rel.Time, period)
+		if err != nil {
+			return nil, err
+		}
+		idx, ok := periodIndex[key]
+		if !ok {
+			idx = len(ret.Periods)
+			periodIndex[key] = idx
+			periodContributors[key] = map[string]bool{}
+			ret.Periods = append(ret.Periods, PeriodStats{
+				Period: key,
+				Labels: map[string]int{},
+			})
+		}
+
+		ps := &ret.Periods[idx]
+		ps.Releases++
+		ps.Issues += rs.Issues
+		ps.MRs += rs.MRs
+		for label, count := range rs.Labels {
+			ps.Labels[label] += count
+		}
+		for _, c := range rel.Contributors {
+			periodContributors[key][c.Name] = true
+		}
+		ps.Contributors = len(periodContributors[key])
+	}
+
+	ret.MedianDaysBetweenReleases = median(intervals)
+
+	return ret, nil
+}
+
+// labelDistribution counts the labels of issues and MRs of the release
+func labelDistribution(r data.Release) map[string]int {
+	ret := map[string]int{}
+	for _, issue := range r.Issues {
+		for _, label := range issue.Labels {
+			ret[label]++
+		}
+	}
+	for _, mrs := range []data.MRs{r.MRs, r.CollapsedMRs} {
+		for _, mr := range mrs {
+			for _, label := range mr.Labels {
+				ret[label]++
+			}
+		}
+	}
+	return ret
+}
+
+// periodKey returns the name of period for the given date
+func periodKey(d time.Time, period string) (string, error) {
+	switch period {
+	case PeriodMonth:
+		return d.Format("2006-01"), nil
+	case PeriodQuarter:
+		return fmt.Sprintf("%v-Q%v", d.Year(), (int(d.Month())-1)/3+1), nil
+	case PeriodYear:
+		return d.Format("2006"), nil
+	default:
+		return "", fmt.Errorf("unsupported period: %v", period)
+	}
+}
+
+// median returns the median of given values or 0 if there are no values
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	v := make([]float64, len(values))
+	copy(v, values)
+	sort.Float64s(v)
+
+	m := len(v) / 2
+	if len(v)%2 == 0 {
+		return (v[m-1] + v[m]) / 2
+	}
+	return v[m]
+}
@@ -0,0 +1,194 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stats_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/cli/commands/stats"
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestNewMetrics(t *testing.T) {
+	releases := data.Releases{
+		{
+			Release: "v0.3.0",
+			Date:    "10.04.2019",
+			Time:    time.Date(2019, 4, 10, 12, 0, 0, 0, time.UTC),
+			Issues: data.Issues{
+				{ID: 1, Labels: []string{"bug"}},
+			},
+			MRs: data.MRs{
+				{ID: 10, Author: "user1", Labels: []string{"bug", "enhancement"}},
+			},
+			CollapsedMRs: data.MRs{
+				{ID: 11, Author: "dependabot[bot]", Labels: []string{"dependencies"}},
+			},
+			Contributors: data.Contributors{{Name: "user1"}},
+		},
+		{
+			Release: "v0.2.0",
+			Date:    "20.03.2019",
+			Time:    time.Date(2019, 3, 20, 0, 0, 0, 0, time.UTC),
+			MRs: data.MRs{
+				{ID: 8, Author: "user2"},
+				{ID: 7, Author: "user1"},
+			},
+			Contributors: data.Contributors{{Name: "user2"}, {Name: "user1"}},
+		},
+		{
+			Release: "v0.1.0",
+			Date:    "16.03.2019",
+			Time:    time.Date(2019, 3, 16, 0, 0, 0, 0, time.UTC),
+			Issues: data.Issues{
+				{ID: 2, Labels: []string{"bug"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		period  string
+		want    *stats.Metrics
+		wantErr error
+	}{
+		{
+			name:   "Monthly periods",
+			period: stats.PeriodMonth,
+			want: &stats.Metrics{
+				Releases: []stats.ReleaseStats{
+					{
+						Release:           "v0.3.0",
+						Date:              "10.04.2019",
+						Issues:            1,
+						MRs:               2,
+						Contributors:      1,
+						DaysSincePrevious: floatPtr(21.5),
+						Labels:            map[string]int{"bug": 2, "enhancement": 1, "dependencies": 1},
+					},
+					{
+						Release:           "v0.2.0",
+						Date:              "20.03.2019",
+						MRs:               2,
+						Contributors:      2,
+						DaysSincePrevious: floatPtr(4),
+						Labels:            map[string]int{},
+					},
+					{
+						Release: "v0.1.0",
+						Date:    "16.03.2019",
+						Issues:  1,
+						Labels:  map[string]int{"bug": 1},
+					},
+				},
+				Periods: []stats.PeriodStats{
+					{
+						Period:       "2019-04",
+						Releases:     1,
+						Issues:       1,
+						MRs:          2,
+						Contributors: 1,
+						Labels:       map[string]int{"bug": 2, "enhancement": 1, "dependencies": 1},
+					},
+					{
+						Period:       "2019-03",
+						Releases:     2,
+						Issues:       1,
+						MRs:          2,
+						Contributors: 2,
+						Labels:       map[string]int{"bug": 1},
+					},
+				},
+				MedianDaysBetweenReleases: 12.75,
+			},
+		},
+		{
+			name:   "Quarterly periods",
+			period: stats.PeriodQuarter,
+			want: &stats.Metrics{
+				Releases: []stats.ReleaseStats{
+					{
+						Release:           "v0.3.0",
+						Date:              "10.04.2019",
+						Issues:            1,
+						MRs:               2,
+						Contributors:      1,
+						DaysSincePrevious: floatPtr(21.5),
+						Labels:            map[string]int{"bug": 2, "enhancement": 1, "dependencies": 1},
+					},
+					{
+						Release:           "v0.2.0",
+						Date:              "20.03.2019",
+						MRs:               2,
+						Contributors:      2,
+						DaysSincePrevious: floatPtr(4),
+						Labels:            map[string]int{},
+					},
+					{
+						Release: "v0.1.0",
+						Date:    "16.03.2019",
+						Issues:  1,
+						Labels:  map[string]int{"bug": 1},
+					},
+				},
+				Periods: []stats.PeriodStats{
+					{
+						Period:       "2019-Q2",
+						Releases:     1,
+						Issues:       1,
+						MRs:          2,
+						Contributors: 1,
+						Labels:       map[string]int{"bug": 2, "enhancement": 1, "dependencies": 1},
+					},
+					{
+						Period:       "2019-Q1",
+						Releases:     2,
+						Issues:       1,
+						MRs:          2,
+						Contributors: 2,
+						Labels:       map[string]int{"bug": 1},
+					},
+				},
+				MedianDaysBetweenReleases: 12.75,
+			},
+		},
+		{
+			name:    "Wrong period",
+			period:  "week",
+			wantErr: errors.New("unsupported period: week"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stats.NewMetrics(releases, tt.period)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("NewMetrics() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewMetrics() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package stats implements the stats command
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/artem-sidorenko/chagen/cli/commands"
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource"
+	"github.com/artem-sidorenko/chagen/internal/output"
+
+	"github.com/urfave/cli"
+)
+
+// possible output formats
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+// Stdout references the Stdout writer for stats command
+var Stdout io.Writer = output.Stdout // nolint: gochecknoglobals
+// ProgressWriter references the writer for progress information
+var ProgressWriter io.Writer = output.Stderr // nolint: gochecknoglobals
+
+// Stats implements the CLI subcommand stats
+func Stats(ctx *cli.Context) error {
+	format := ctx.String("format")
+	if format != FormatTable && format != FormatJSON {
+		return fmt.Errorf("unsupported output format: %v", format)
+	}
+
+	period := ctx.String("period")
+	switch period {
+	case PeriodMonth, PeriodQuarter, PeriodYear:
+	default:
+		return fmt.Errorf("unsupported period: %v", period)
+	}
+
+	opts, err := datasource.NewOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	tags, issues, mrs, err := datasource.GetData(ctx, opts, ProgressWriter)
+	if err != nil {
+		return err
+	}
+
+	s, err := NewMetrics(data.NewReleases(tags, issues, mrs), period)
+	if err != nil {
+		return err
+	}
+
+	if format == FormatJSON {
+		enc := json.NewEncoder(Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(s)
+	}
+	return writeTable(Stdout, s)
+}
+
+// writeTable writes the stats as text tables
+func writeTable(wr io.Writer, s *Metrics) error {
+	tw := tabwriter.NewWriter(wr, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "RELEASE\tDATE\tISSUES\tMRS\tCONTRIBUTORS\tDAYS SINCE PREVIOUS\tLABELS") // nolint: errcheck
+	for _, r := range s.Releases {
+		days := "-"
+		if r.DaysSincePrevious != nil {
+			days = fmt.Sprintf("%.1f", *r.DaysSincePrevious)
+		}
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t%v\n", // nolint: errcheck
+			r.Release, r.Date, r.Issues, r.MRs, r.Contributors, days, formatLabels(r.Labels))
+	}
+
+	fmt.Fprintln(tw)                                                        // nolint: errcheck
+	fmt.Fprintln(tw, "PERIOD\tRELEASES\tISSUES\tMRS\tCONTRIBUTORS\tLABELS") // nolint: errcheck
+	for _, p := range s.Periods {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\n", // nolint: errcheck
+			p.Period, p.Releases, p.Issues, p.MRs, p.Contributors, formatLabels(p.Labels))
+	}
+
+	fmt.Fprintln(tw)                                                                          // nolint: errcheck
+	fmt.Fprintf(tw, "Median time between releases: %.1f days\n", s.MedianDaysBetweenReleases) // nolint: errcheck
+
+	return tw.Flush()
+}
+
+// formatLabels returns the labels with their counts, most used labels first
+func formatLabels(labels map[string]int) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if labels[names[i]] != labels[names[j]] {
+			return labels[names[i]] > labels[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	ret := make([]string, len(names))
+	for i, name := range names {
+		ret[i] = fmt.Sprintf("%v: %v", name, labels[name])
+	}
+	return strings.Join(ret, ", ")
+}
+
+// CLIFlags returns the possible CLI flags for this command
+func CLIFlags() []cli.Flag {
+	return append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "Output format: table or json",
+			Value: FormatTable,
+		},
+		cli.StringFlag{
+			Name:  "period",
+			Usage: "Aggregation period of releases: month, quarter or year",
+			Value: PeriodMonth,
+		},
+	}, datasource.CLIFlags()...)
+}
+
+func init() { // nolint: gochecknoinits
+	flags := append(CLIFlags(), datasource.ConnectorCLIFlags()...)
+
+	commands.RegisterCommand(cli.Command{
+		Name:      "stats",
+		Usage:     "Show the release statistics",
+		ArgsUsage: " ", // we do not have any args (only flags), so avoid this help message
+		Flags:     flags,
+		Action: func(c *cli.Context) error {
+			if err := Stats(c); err != nil { // exit 1 and error message if we get any error reported
+				return cli.NewExitError(err, 1)
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,109 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package stats_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/cli/commands/stats"
+	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+	"github.com/artem-sidorenko/chagen/internal/testing/testconnector"
+)
+
+func TestStats(t *testing.T) {
+	tests := []struct {
+		name         string
+		cliFlags     map[string]string
+		wantErr      error
+		wantContains []string
+	}{
+		{
+			name: "Table output",
+			wantContains: []string{
+				"RELEASE  DATE        ISSUES  MRS  CONTRIBUTORS  DAYS SINCE PREVIOUS  LABELS",
+				"v0.1.1   19.03.2003  0       1    1             1.2                  bugfix: 1",
+				"2003-03  12        ",
+				"Median time between releases: 1.2 days",
+			},
+		},
+		{
+			name:     "JSON output",
+			cliFlags: map[string]string{"format": "json"},
+			wantContains: []string{
+				`"release": "v0.1.1"`,
+				`"median_days_between_releases": 1.1574074074074074`,
+			},
+		},
+		{
+			name:     "Wrong format",
+			cliFlags: map[string]string{"format": "xml"},
+			wantErr:  errors.New("unsupported output format: xml"),
+		},
+		{
+			name:     "Wrong period",
+			cliFlags: map[string]string{"period": "week"},
+			wantErr:  errors.New("unsupported period: week"),
+		},
+		{
+			name:     "Wrong endpoint",
+			cliFlags: map[string]string{"endpoint": "wrongendpoint"},
+			wantErr:  errors.New("given endpoint isn't supported: wrongendpoint"),
+		},
+	}
+	for _, tt := range tests {
+		cliFlags := map[string]string{
+			"endpoint": "testconnector",
+		}
+		for k, v := range tt.cliFlags {
+			cliFlags[k] = v
+		}
+		ctx := tcli.TestContext(stats.CLIFlags(), cliFlags)
+
+		output := &bytes.Buffer{}
+		stats.Stdout = output
+		stats.ProgressWriter = &bytes.Buffer{}
+
+		testconnector.RetTestingTag = false
+		testconnector.RepositoryExistsFail = false
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := stats.Stats(ctx)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Stats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			out := output.String()
+			for _, s := range tt.wantContains {
+				if !strings.Contains(out, s) {
+					t.Errorf("Stats() output = %v, should contain %v", out, s)
+				}
+			}
+
+			if cliFlags["format"] == stats.FormatJSON && err == nil {
+				var s stats.Metrics
+				if err := json.Unmarshal(output.Bytes(), &s); err != nil {
+					t.Errorf("Stats() output is no valid JSON: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data
+
+import (
+	"regexp"
+	"strings"
+)
+
+// botSuffix is used by GitHub for the accounts of apps like dependabot[bot]
+const botSuffix = "[bot]"
+
+// AuthorMatcher matches the authors of MRs against globs or /regex/ patterns,
+// optionally the bot accounts are detected automatically
+type AuthorMatcher struct {
+	patterns []*regexp.Regexp
+	bots     bool
+}
+
+// NewAuthorMatcher returns a new AuthorMatcher for given patterns,
+// bots enables the automatic detection of bot accounts
+func NewAuthorMatcher(patterns []string, bots bool) (*AuthorMatcher, error) {
+	p, err := compilePatterns("author", patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthorMatcher{patterns: p, bots: bots}, nil
+}
+
+// Match returns true if the author matches one of the patterns or is a detected bot
+func (m *AuthorMatcher) Match(author string) bool {
+	if m.bots && IsBot(author) {
+		return true
+	}
+	return matchPatterns(m.patterns, author)
+}
+
+// IsBot returns true if the given author is a bot account
+func IsBot(author string) bool {
+	return strings.HasSuffix(strings.ToLower(author), botSuffix)
+}
+
+// FilterMRsByAuthor filters out the MRs with authors matched by AuthorMatcher
+func FilterMRsByAuthor(m MRs, am *AuthorMatcher) MRs {
+	var ret MRs
+	for _, mr := range m {
+		if !am.Match(mr.Author) {
+			ret = append(ret, mr)
+		}
+	}
+	return ret
+}
+
+// CollapseMRsByAuthor moves the MRs with authors matched by AuthorMatcher
+// to the CollapsedMRs of each release, so they can be summarized.
+// The contributors are updated, the collapsed authors aren't listed there
+func CollapseMRsByAuthor(r Releases, am *AuthorMatcher) {
+	for i := range r {
+		var mrs MRs
+		for _, mr := range r[i].MRs {
+			if am.Match(mr.Author) {
+				r[i].CollapsedMRs = append(r[i].CollapsedMRs, mr)
+			} else {
+				mrs = append(mrs, mr)
+			}
+		}
+		r[i].MRs = mrs
+	}
+
+	setContributors(r)
+}
@@ -0,0 +1,148 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+func TestAuthorMatcher_Match(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		bots     bool
+		author   string
+		want     bool
+	}{
+		{
+			name:   "No rules",
+			author: "dependabot[bot]",
+			want:   false,
+		},
+		{
+			name:   "Bot detection",
+			bots:   true,
+			author: "Dependabot[bot]",
+			want:   true,
+		},
+		{
+			name:   "Bot detection with human author",
+			bots:   true,
+			author: "artem-sidorenko",
+			want:   false,
+		},
+		{
+			name:     "Glob match",
+			patterns: []string{"renovate*"},
+			author:   "renovate-bot",
+			want:     true,
+		},
+		{
+			name:     "Regex match",
+			patterns: []string{"/^snyk-/"},
+			author:   "snyk-bot",
+			want:     true,
+		},
+		{
+			name:     "No match",
+			patterns: []string{"renovate*"},
+			author:   "test-user",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := data.NewAuthorMatcher(tt.patterns, tt.bots)
+			if err != nil {
+				t.Fatalf("NewAuthorMatcher() error = %v", err)
+			}
+			if got := m.Match(tt.author); got != tt.want {
+				t.Errorf("AuthorMatcher.Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAuthorMatcher(t *testing.T) {
+	_, err := data.NewAuthorMatcher([]string{"/(abc/"}, false)
+	wantErr := errors.New("can't compile the author pattern /(abc/: error parsing regexp: missing closing ): `(?i)(abc`") // nolint: lll
+	if !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("NewAuthorMatcher() error = %v, wantErr %v", err, wantErr)
+	}
+}
+
+func TestFilterMRsByAuthor(t *testing.T) {
+	m, _ := data.NewAuthorMatcher([]string{"renovate*"}, true)
+	mrs := data.MRs{
+		{ID: 1, Author: "test-user"},
+		{ID: 2, Author: "dependabot[bot]"},
+		{ID: 3, Author: "renovate-bot"},
+	}
+	want := data.MRs{{ID: 1, Author: "test-user"}}
+
+	if got := data.FilterMRsByAuthor(mrs, m); !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterMRsByAuthor() = %v, want %v", got, want)
+	}
+}
+
+func TestCollapseMRsByAuthor(t *testing.T) {
+	m, _ := data.NewAuthorMatcher([]string{"renovate"}, true)
+	releases := data.Releases{
+		{
+			Release: "v0.1.0",
+			MRs: data.MRs{
+				{ID: 1, Author: "test-user"},
+				{ID: 2, Author: "dependabot[bot]"},
+				{ID: 4, Author: "renovate"},
+			},
+			Contributors: data.Contributors{
+				{Name: "test-user", FirstTime: true},
+				{Name: "renovate", FirstTime: true},
+			},
+		},
+		{
+			Release: "v0.0.1",
+			MRs: data.MRs{
+				{ID: 3, Author: "dependabot[bot]"},
+			},
+		},
+	}
+	want := data.Releases{
+		{
+			Release: "v0.1.0",
+			MRs:     data.MRs{{ID: 1, Author: "test-user"}},
+			CollapsedMRs: data.MRs{
+				{ID: 2, Author: "dependabot[bot]"},
+				{ID: 4, Author: "renovate"},
+			},
+			Contributors: data.Contributors{{Name: "test-user", FirstTime: true}},
+		},
+		{
+			Release:      "v0.0.1",
+			CollapsedMRs: data.MRs{{ID: 3, Author: "dependabot[bot]"}},
+		},
+	}
+
+	data.CollapseMRsByAuthor(releases, m)
+	if !reflect.DeepEqual(releases, want) {
+		t.Errorf("CollapseMRsByAuthor() = %v, want %v", releases, want)
+	}
+}
@@ -0,0 +1,177 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Commit describes a git commit of the default branch
+type Commit struct {
+	SHA       string    `json:"sha"`
+	Message   string    `json:"message"`
+	URL       string    `json:"url"`
+	Author    string    `json:"author"`
+	AuthorURL string    `json:"author_url,omitempty"`
+	Date      time.Time `json:"date"`
+	Parents   []string  `json:"parents,omitempty"`
+}
+
+// Title returns the first line of the commit message
+func (c Commit) Title() string {
+	return strings.TrimSpace(strings.SplitN(c.Message, "\n", 2)[0])
+}
+
+// ShortSHA returns the abbreviated commit SHA
+func (c Commit) ShortSHA() string {
+	if len(c.SHA) > 7 {
+		return c.SHA[:7]
+	}
+	return c.SHA
+}
+
+// Commits is a slice with Commit elements
+type Commits []Commit
+
+// Len implements the Sort.Interface
+func (c *Commits) Len() int {
+	return len(*c)
+}
+
+// Less implements the Sort.Interface
+func (c *Commits) Less(i, j int) bool {
+	return (*c)[i].Date.After((*c)[j].Date)
+}
+
+// Swap implements the Sort.Interface
+func (c *Commits) Swap(i, j int) {
+	(*c)[i], (*c)[j] = (*c)[j], (*c)[i]
+}
+
+// FilterCommits filters and returns new slice of Commits, where Date is between given dates
+func FilterCommits(c Commits, fromDate, toDate time.Time) Commits {
+	var ret Commits
+	for _, commit := range c {
+		if commit.Date.After(fromDate) &&
+			(commit.Date.Before(toDate) || commit.Date.Equal(toDate)) {
+			ret = append(ret, commit)
+		}
+	}
+	return ret
+}
+
+// mrReference matches the references to the merged MRs in commit messages,
+// e.g. "Title (#12)" of squash merges on GitHub, "Merge pull request #12 from"
+// or "See merge request group/project!12"
+var mrReference = regexp.MustCompile( // nolint: gochecknoglobals
+	`(?im)(?:\(#(\d+)\)$|pull request #(\d+)|merge request \S*!(\d+))`)
+
+// mrReferences returns the IDs of MRs referenced in given commit message
+func mrReferences(message string) []int {
+	var ret []int
+	for _, m := range mrReference.FindAllStringSubmatch(message, -1) {
+		for _, id := range m[1:] {
+			if i, err := strconv.Atoi(id); err == nil {
+				ret = append(ret, i)
+			}
+		}
+	}
+	return ret
+}
+
+// DirectCommits returns the commits, which were made directly on the default branch.
+// Only the first parents of commits are followed, so commits of merged branches
+// are skipped. Merge commits, the commits created by merging of given MRs
+// (e.g. squash or rebase merges) and the commits referencing them in the message
+// are skipped too
+func DirectCommits(c Commits, mrs MRs) Commits {
+	bySHA := map[string]Commit{}
+	isParent := map[string]bool{}
+	for _, commit := range c {
+		bySHA[commit.SHA] = commit
+		for _, p := range commit.Parents {
+			isParent[p] = true
+		}
+	}
+
+	mergeCommits := map[string]bool{}
+	mrIDs := map[int]bool{}
+	for _, mr := range mrs {
+		if mr.MergeCommit != "" {
+			mergeCommits[mr.MergeCommit] = true
+		}
+		mrIDs[mr.ID] = true
+	}
+	ofMR := func(commit Commit) bool {
+		if mergeCommits[commit.SHA] {
+			return true
+		}
+		for _, id := range mrReferences(commit.Message) {
+			if mrIDs[id] {
+				return true
+			}
+		}
+		return false
+	}
+
+	var ret Commits
+	visited := map[string]bool{}
+	for _, head := range c {
+		// start only from the heads, which are no parents of other commits
+		if isParent[head.SHA] {
+			continue
+		}
+		for commit, ok := head, true; ok && !visited[commit.SHA]; {
+			visited[commit.SHA] = true
+			if len(commit.Parents) < 2 && !ofMR(commit) {
+				ret = append(ret, commit)
+			}
+			if len(commit.Parents) == 0 {
+				break
+			}
+			commit, ok = bySHA[commit.Parents[0]]
+		}
+	}
+	return ret
+}
+
+// AddCommits assigns the commits to the releases built by NewReleases
+// using the dates of given tags
+func AddCommits(r Releases, tags Tags, c Commits) {
+	tags = append(Tags(nil), tags...)
+	sort.Sort(&tags)
+	c = append(Commits(nil), c...)
+	for i := range c {
+		c[i].Date = c[i].Date.UTC()
+	}
+	sort.Sort(&c)
+
+	for i := range r {
+		if i >= len(tags) {
+			return
+		}
+		var lastReleaseDate time.Time
+		if i < len(tags)-1 {
+			lastReleaseDate = tags[i+1].Date
+		}
+		r[i].Commits = FilterCommits(c, lastReleaseDate, tags[i].Date)
+	}
+}
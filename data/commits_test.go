@@ -0,0 +1,122 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/internal/testing/helpers"
+)
+
+func TestCommit_Title(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"Fix typo", "Fix typo"},
+		{"Fix typo \n\nIt was wrong", "Fix typo"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			if got := (data.Commit{Message: tt.message}).Title(); got != tt.want {
+				t.Errorf("Title() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDirectCommits(t *testing.T) {
+	// history: initial <- fix <- merge(initial, branch) <- squashed <- rebased <- docs
+	commits := data.Commits{
+		{SHA: "docs", Message: "Update docs (#3)", Parents: []string{"rebased"}},
+		{
+			SHA:     "rebased",
+			Message: "Fix build\n\nSee merge request group/project!4",
+			Parents: []string{"squashed"},
+		},
+		{SHA: "squashed", Parents: []string{"merge"}},
+		{SHA: "merge", Parents: []string{"fix", "branch"}},
+		{SHA: "branch", Parents: []string{"initial"}},
+		{SHA: "fix", Parents: []string{"initial"}},
+		{SHA: "initial"},
+	}
+
+	tests := []struct {
+		name string
+		mrs  data.MRs
+		want []string
+	}{
+		{
+			name: "Without MRs",
+			want: []string{"docs", "rebased", "squashed", "fix", "initial"},
+		},
+		{
+			name: "Squashed MR",
+			mrs:  data.MRs{{ID: 1, MergeCommit: "squashed"}, {ID: 2, MergeCommit: "merge"}},
+			want: []string{"docs", "rebased", "fix", "initial"},
+		},
+		{
+			name: "MRs referenced in commit messages",
+			mrs:  data.MRs{{ID: 3}, {ID: 4}, {ID: 5}},
+			want: []string{"squashed", "fix", "initial"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range data.DirectCommits(commits, tt.mrs) {
+				got = append(got, c.SHA)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DirectCommits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddCommits(t *testing.T) {
+	tags := data.Tags{
+		{Name: "v0.1.0", Date: helpers.Time(1000)},
+		{Name: "v0.2.0", Date: helpers.Time(2000)},
+	}
+	commits := data.Commits{
+		{SHA: "a", Date: helpers.Time(500)},
+		{SHA: "b", Date: helpers.Time(1500)},
+		{SHA: "c", Date: helpers.Time(2000)},
+		{SHA: "d", Date: helpers.Time(2500)},
+	}
+
+	releases := data.NewReleases(tags, nil, nil)
+	data.AddCommits(releases, tags, commits)
+
+	want := map[string][]string{
+		"v0.2.0": {"c", "b"},
+		"v0.1.0": {"a"},
+	}
+	for _, r := range releases {
+		var got []string
+		for _, c := range r.Commits {
+			got = append(got, c.SHA)
+		}
+		if !reflect.DeepEqual(got, want[r.Release]) {
+			t.Errorf("AddCommits() release %v = %v, want %v", r.Release, got, want[r.Release])
+		}
+	}
+}
@@ -0,0 +1,169 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data
+
+import (
+	"regexp"
+	"sort"
+)
+
+// names of the capture groups in the tag filter, which are used for monorepos
+const (
+	ComponentGroup = "component"
+	VersionGroup   = "version"
+)
+
+// HasComponents returns true if the tag filter captures the component
+func HasComponents(re *regexp.Regexp) bool {
+	return re != nil && groupIndex(re, ComponentGroup) > 0
+}
+
+// groupIndex returns the index of the named capture group or -1 if it is missing
+func groupIndex(re *regexp.Regexp, name string) int {
+	for i, n := range re.SubexpNames() {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// TagsByComponent groups the tags by the component captured via the tag filter,
+// tags without captured component are skipped. If the tag filter captures
+// the version too, the tags are renamed to it, e.g. api/v1.2.3 becomes v1.2.3
+func TagsByComponent(ts Tags, re *regexp.Regexp) map[string]Tags {
+	ret := map[string]Tags{}
+	componentIdx := groupIndex(re, ComponentGroup)
+	versionIdx := groupIndex(re, VersionGroup)
+
+	for _, t := range ts {
+		m := re.FindStringSubmatch(t.Name)
+		if m == nil || componentIdx < 0 || m[componentIdx] == "" {
+			continue
+		}
+		if versionIdx > 0 && m[versionIdx] != "" {
+			t.Name = m[versionIdx]
+		}
+		ret[m[componentIdx]] = append(ret[m[componentIdx]], t)
+	}
+	return ret
+}
+
+// FilterTagsByComponent returns the tags of given component captured via the tag filter,
+// the tag names are kept, e.g. api/v1.2.3 stays api/v1.2.3
+func FilterTagsByComponent(ts Tags, re *regexp.Regexp, component string) Tags {
+	var ret Tags
+	componentIdx := groupIndex(re, ComponentGroup)
+
+	for _, t := range ts {
+		m := re.FindStringSubmatch(t.Name)
+		if m == nil || componentIdx < 0 || m[componentIdx] != component {
+			continue
+		}
+		ret = append(ret, t)
+	}
+	return ret
+}
+
+// Components returns the sorted names of components
+func Components(tags map[string]Tags) []string {
+	ret := make([]string, 0, len(tags))
+	for c := range tags {
+		ret = append(ret, c)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// ComponentMatcher assigns issues and MRs to components using their labels,
+// MRs are assigned using their changed files too
+type ComponentMatcher struct {
+	patterns map[string][]*regexp.Regexp
+	paths    map[string]*PathFilter
+}
+
+// NewComponentMatcher returns a new ComponentMatcher for given label patterns
+// and path globs of components. Components without label patterns are matched
+// by the label with the name of component
+func NewComponentMatcher(labels, paths map[string][]string) (*ComponentMatcher, error) {
+	m := &ComponentMatcher{
+		patterns: map[string][]*regexp.Regexp{},
+		paths:    map[string]*PathFilter{},
+	}
+	for component, patterns := range labels {
+		p, err := compilePatterns("component label", patterns)
+		if err != nil {
+			return nil, err
+		}
+		m.patterns[component] = p
+	}
+	for component, patterns := range paths {
+		f, err := NewPathFilter(patterns)
+		if err != nil {
+			return nil, err
+		}
+		m.paths[component] = f
+	}
+	return m, nil
+}
+
+// Match returns true if one of the labels belongs to the component
+func (m *ComponentMatcher) Match(component string, labels []string) bool {
+	patterns, ok := m.patterns[component]
+	if !ok {
+		var err error
+		if patterns, err = compilePatterns("component label", []string{component}); err != nil {
+			return false
+		}
+	}
+
+	for _, label := range labels {
+		if matchPatterns(patterns, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterIssuesByComponent returns the issues, which belong to the component
+func FilterIssuesByComponent(is Issues, m *ComponentMatcher, component string) Issues {
+	var ret Issues
+	for _, issue := range is {
+		if m.Match(component, issue.Labels) {
+			ret = append(ret, issue)
+		}
+	}
+	return ret
+}
+
+// MatchFiles returns true if one of the files belongs to the component
+func (m *ComponentMatcher) MatchFiles(component string, files []string) bool {
+	f, ok := m.paths[component]
+	return ok && f.Match(files)
+}
+
+// FilterMRsByComponent returns the MRs, which belong to the component
+// via labels or changed files
+func FilterMRsByComponent(mrs MRs, m *ComponentMatcher, component string) MRs {
+	var ret MRs
+	for _, mr := range mrs {
+		if m.Match(component, mr.Labels) || m.MatchFiles(component, mr.Files) {
+			ret = append(ret, mr)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,176 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data_test
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/internal/testing/helpers"
+)
+
+func TestTagsByComponent(t *testing.T) {
+	tags := data.Tags{
+		{Name: "api/v1.2.3", Date: helpers.Time(3000)},
+		{Name: "web/v0.9.0", Date: helpers.Time(2000)},
+		{Name: "api/v1.2.2", Date: helpers.Time(1000)},
+		{Name: "v1.0.0", Date: helpers.Time(500)},
+	}
+
+	tests := []struct {
+		name           string
+		re             string
+		wantComponents bool
+		want           map[string]data.Tags
+	}{
+		{
+			name:           "Component and version",
+			re:             `^(?P<component>[^/]+)/(?P<version>v\d+\.\d+\.\d+)$`,
+			wantComponents: true,
+			want: map[string]data.Tags{
+				"api": {
+					{Name: "v1.2.3", Date: helpers.Time(3000)},
+					{Name: "v1.2.2", Date: helpers.Time(1000)},
+				},
+				"web": {{Name: "v0.9.0", Date: helpers.Time(2000)}},
+			},
+		},
+		{
+			name:           "Component only",
+			re:             `^(?P<component>web)/v\d+\.\d+\.\d+$`,
+			wantComponents: true,
+			want: map[string]data.Tags{
+				"web": {{Name: "web/v0.9.0", Date: helpers.Time(2000)}},
+			},
+		},
+		{
+			name: "Without component",
+			re:   `^v\d+\.\d+\.\d+$`,
+			want: map[string]data.Tags{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re := regexp.MustCompile(tt.re)
+			if got := data.HasComponents(re); got != tt.wantComponents {
+				t.Errorf("HasComponents() = %v, want %v", got, tt.wantComponents)
+			}
+			if got := data.TagsByComponent(tags, re); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TagsByComponent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterTagsByComponent(t *testing.T) {
+	tags := data.Tags{
+		{Name: "api/v1.2.0", Date: helpers.Time(3000)},
+		{Name: "web/v3.0.0", Date: helpers.Time(2000)},
+		{Name: "api/v1.1.0", Date: helpers.Time(1000)},
+		{Name: "v1.0.0", Date: helpers.Time(500)},
+	}
+	re := regexp.MustCompile(`^(?P<component>[^/]+)/(?P<version>v\d+\.\d+\.\d+)$`)
+
+	want := data.Tags{
+		{Name: "api/v1.2.0", Date: helpers.Time(3000)},
+		{Name: "api/v1.1.0", Date: helpers.Time(1000)},
+	}
+	if got := data.FilterTagsByComponent(tags, re, "api"); !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterTagsByComponent() = %v, want %v", got, want)
+	}
+
+	// the next version should not be based on the tags of other components
+	mrs := data.MRs{{Name: "fix", MergedDate: helpers.Time(4000)}}
+	got, err := data.NextVersion(data.FilterTagsByComponent(tags, re, "api"), nil, mrs, nil)
+	if err != nil || got != "api/v1.2.1" {
+		t.Errorf("NextVersion() = %v, %v, want %v", got, err, "api/v1.2.1")
+	}
+}
+
+func TestComponentMatcher(t *testing.T) {
+	mrs := data.MRs{
+		{ID: 1, Labels: []string{"API"}},
+		{ID: 2, Labels: []string{"backend", "bug"}},
+		{ID: 3, Labels: []string{"frontend"}},
+		{ID: 4, Files: []string{"web/src/index.js"}},
+	}
+
+	tests := []struct {
+		name      string
+		labels    map[string][]string
+		paths     map[string][]string
+		component string
+		want      []int
+		wantErr   error
+	}{
+		{
+			name:      "Component name as default label",
+			component: "api",
+			want:      []int{1},
+		},
+		{
+			name:      "Configured labels",
+			labels:    map[string][]string{"api": {"api", "back*"}},
+			component: "api",
+			want:      []int{1, 2},
+		},
+		{
+			name:      "Configured labels and paths",
+			labels:    map[string][]string{"web": {"frontend"}},
+			paths:     map[string][]string{"web": {"web"}},
+			component: "web",
+			want:      []int{3, 4},
+		},
+		{
+			name:      "Unknown component",
+			labels:    map[string][]string{"web": {"frontend"}},
+			component: "cli",
+		},
+		{
+			name:    "Broken pattern",
+			labels:  map[string][]string{"web": {"/(abc/"}},
+			wantErr: errors.New("can't compile the component label pattern /(abc/: error parsing regexp: missing closing ): `(?i)(abc`"), // nolint: lll
+		},
+		{
+			name:    "Broken path pattern",
+			paths:   map[string][]string{"web": {"web/["}},
+			wantErr: errors.New("can't compile the path pattern web/[: syntax error in pattern"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := data.NewComponentMatcher(tt.labels, tt.paths)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("NewComponentMatcher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var got []int
+			for _, mr := range data.FilterMRsByComponent(mrs, m, tt.component) {
+				got = append(got, mr.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterMRsByComponent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
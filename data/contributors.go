@@ -0,0 +1,84 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data
+
+import "sort"
+
+// Contributor describes an author of merged MRs
+type Contributor struct {
+	Name      string
+	URL       string
+	FirstTime bool // the first merged MR of this author is in this release
+}
+
+// Contributors is a slice with Contributor elements
+type Contributors []Contributor
+
+// MarkReturningAuthors marks the MRs, whose authors merged other MRs before.
+// It should be used with the complete MR history before any filtering,
+// so the filtered out MRs are considered for the first contributions too
+func MarkReturningAuthors(mrs MRs) {
+	idx := make([]int, len(mrs))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return mrs[idx[i]].MergedDate.Before(mrs[idx[j]].MergedDate)
+	})
+
+	seen := map[string]bool{}
+	for _, i := range idx {
+		mrs[i].Returning = seen[mrs[i].Author]
+		seen[mrs[i].Author] = true
+	}
+}
+
+// setContributors fills the deduplicated contributors of every release.
+// Releases are expected to be sorted from newest to oldest, bots are skipped
+func setContributors(r Releases) {
+	seen := map[string]bool{}
+
+	// go from the oldest to the newest release to detect the first contributions
+	for i := len(r) - 1; i >= 0; i-- {
+		r[i].Contributors = nil
+		inRelease := map[string]int{}
+
+		for _, mr := range r[i].MRs {
+			if mr.Author == "" || IsBot(mr.Author) {
+				continue
+			}
+			// the first MR of an author might be filtered out,
+			// the remaining MRs are marked by MarkReturningAuthors then
+			firstTime := !seen[mr.Author] && !mr.Returning
+			if j, ok := inRelease[mr.Author]; ok {
+				r[i].Contributors[j].FirstTime = r[i].Contributors[j].FirstTime || firstTime
+				continue
+			}
+			inRelease[mr.Author] = len(r[i].Contributors)
+
+			r[i].Contributors = append(r[i].Contributors, Contributor{
+				Name:      mr.Author,
+				URL:       mr.AuthorURL,
+				FirstTime: firstTime,
+			})
+		}
+
+		for author := range inRelease {
+			seen[author] = true
+		}
+	}
+}
@@ -25,6 +25,15 @@ func sliceContains(slice []string, str string) bool {
 	return false
 }
 
+func intSliceContains(slice []int, i int) bool {
+	for _, s := range slice {
+		if i == s {
+			return true
+		}
+	}
+	return false
+}
+
 // UTCDate sets all time within data to the UTC
 func UTCDate(ts Tags, is Issues, mrs MRs) {
 	for i, t := range ts {
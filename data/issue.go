@@ -20,13 +20,27 @@ import (
 	"time"
 )
 
-// Issue describes an issue in the bug tracker
+// possible reasons for closing of issues
+const (
+	// CloseReasonUnknown is used if the bug tracker does not provide the reason
+	CloseReasonUnknown = ""
+	// CloseReasonCompleted is used for issues closed as completed
+	CloseReasonCompleted = "completed"
+	// CloseReasonNotPlanned is used for issues closed as not planned, e.g. won't fix
+	CloseReasonNotPlanned = "not_planned"
+)
+
+// Issue describes an issue in the bug tracker.
+// The issues of external trackers like Jira are identified by Key instead of ID
 type Issue struct {
-	ID         int
-	Name       string
-	ClosedDate time.Time
-	URL        string
-	Labels     []string
+	ID          int       `json:"id"`
+	Key         string    `json:"key,omitempty"` // key of issue in external tracker, e.g. ABC-123
+	Name        string    `json:"name"`
+	ClosedDate  time.Time `json:"closed_date"`
+	URL         string    `json:"url"`
+	Labels      []string  `json:"labels,omitempty"`
+	ClosedBy    []int     `json:"closed_by,omitempty"`    // IDs of MRs, which closed this issue
+	CloseReason string    `json:"close_reason,omitempty"` // reason of closing, if provided by the bug tracker
 }
 
 // Issues is a slice with Issue elements
@@ -59,19 +73,26 @@ func FilterIssues(is Issues, fromDate, toDate time.Time) Issues {
 	return ret
 }
 
-// FilterIssuesByLabel filters out the issues with given labels
-func FilterIssuesByLabel(is Issues, withoutLabels []string) Issues {
+// FilterCompletedIssues filters out the issues, which were neither closed
+// as completed nor closed by a merged MR
+func FilterCompletedIssues(is Issues) Issues {
 	var ret Issues
 	for _, issue := range is {
-		found := false
-		for _, label := range issue.Labels {
-			if sliceContains(withoutLabels, label) {
-				found = true
-				break
-			}
+		if issue.CloseReason == CloseReasonNotPlanned {
+			continue
 		}
+		if issue.CloseReason == CloseReasonCompleted || len(issue.ClosedBy) > 0 {
+			ret = append(ret, issue)
+		}
+	}
+	return ret
+}
 
-		if !found {
+// FilterIssuesByLabels filters the issues using the given LabelFilter
+func FilterIssuesByLabels(is Issues, f *LabelFilter) Issues {
+	var ret Issues
+	for _, issue := range is {
+		if f.Keep(issue.Labels) {
 			ret = append(ret, issue)
 		}
 	}
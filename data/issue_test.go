@@ -182,106 +182,20 @@ func TestIssues_Filter(t *testing.T) {
 	}
 }
 
-func TestFilterIssuesByLabel(t *testing.T) {
-	type args struct {
-		is            data.Issues
-		withoutLabels []string
+func TestFilterCompletedIssues(t *testing.T) {
+	is := data.Issues{
+		{ID: 1, CloseReason: data.CloseReasonCompleted},
+		{ID: 2, CloseReason: data.CloseReasonNotPlanned},
+		{ID: 3, CloseReason: data.CloseReasonNotPlanned, ClosedBy: []int{10}},
+		{ID: 4, ClosedBy: []int{10}},
+		{ID: 5},
 	}
-	tests := []struct {
-		name string
-		args args
-		want data.Issues
-	}{
-		{
-			name: "Issues without any matching exclude labels",
-			args: args{
-				is: data.Issues{
-					{
-						Name:       "Issue 1",
-						ClosedDate: helpers.Time(1047483647),
-						Labels:     []string{"bugfix"},
-					},
-					{
-						Name:       "Issue 2",
-						ClosedDate: helpers.Time(1247483647),
-					},
-					{
-						Name:       "Issue 3",
-						ClosedDate: helpers.Time(1347483647),
-						Labels:     []string{"enhancement"},
-					},
-				},
-				withoutLabels: []string{"no changelog", "wontfix"},
-			},
-			want: data.Issues{
-				{
-					Name:       "Issue 1",
-					ClosedDate: helpers.Time(1047483647),
-					Labels:     []string{"bugfix"},
-				},
-				{
-					Name:       "Issue 2",
-					ClosedDate: helpers.Time(1247483647),
-				},
-				{
-					Name:       "Issue 3",
-					ClosedDate: helpers.Time(1347483647),
-					Labels:     []string{"enhancement"},
-				},
-			},
-		},
-		{
-			name: "Issues with some matching exclude labels",
-			args: args{
-				is: data.Issues{
-					{
-						Name:       "Issue 1",
-						ClosedDate: helpers.Time(1047483647),
-						Labels:     []string{"bugfix", "enhancement"},
-					},
-					{
-						Name:       "Issue 2",
-						ClosedDate: helpers.Time(1247483647),
-						Labels:     []string{"no changelog"},
-					},
-					{
-						Name:       "Issue 3",
-						ClosedDate: helpers.Time(1347483647),
-						Labels:     []string{"enhancement"},
-					},
-					{
-						Name:       "Issue 4",
-						ClosedDate: helpers.Time(1347483647),
-						Labels:     []string{"enhancement", "no changelog"},
-					},
-					{
-						Name:       "Issue 5",
-						ClosedDate: helpers.Time(1347483647),
-						Labels:     []string{"bug", "wontfix"},
-					},
-				},
-				withoutLabels: []string{"no changelog", "wontfix"},
-			},
-			want: data.Issues{
-				{
-					Name:       "Issue 1",
-					ClosedDate: helpers.Time(1047483647),
-					Labels:     []string{"bugfix", "enhancement"},
-				},
-				{
-					Name:       "Issue 3",
-					ClosedDate: helpers.Time(1347483647),
-					Labels:     []string{"enhancement"},
-				},
-			},
-		},
+	want := data.Issues{
+		{ID: 1, CloseReason: data.CloseReasonCompleted},
+		{ID: 4, ClosedBy: []int{10}},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := data.FilterIssuesByLabel(tt.args.is,
-				tt.args.withoutLabels); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("FilterIssuesByLabel() = %v, want %v", got, tt.want)
-			}
-		})
+
+	if got := data.FilterCompletedIssues(is); !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterCompletedIssues() = %v, want %v", got, want)
 	}
 }
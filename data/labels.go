@@ -0,0 +1,99 @@
+/*
+   Copyright 2017 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// LabelFilter decides using the labels, which items should be kept.
+// Patterns are matched case-insensitive: patterns surrounded with
+// slashes like /^bug.*/ are regular expressions, all other are globs
+type LabelFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// NewLabelFilter returns a new LabelFilter, which keeps only items with
+// at least one of the include labels (if any given) and without any exclude labels
+func NewLabelFilter(include, exclude []string) (*LabelFilter, error) {
+	inc, err := compilePatterns("label", include)
+	if err != nil {
+		return nil, err
+	}
+	exc, err := compilePatterns("label", exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LabelFilter{include: inc, exclude: exc}, nil
+}
+
+// Keep returns true if an item with given labels passes the filter
+func (f *LabelFilter) Keep(labels []string) bool {
+	for _, label := range labels {
+		if matchPatterns(f.exclude, label) {
+			return false
+		}
+	}
+
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, label := range labels {
+		if matchPatterns(f.include, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// compilePatterns compiles the given regular expressions and globs,
+// kind is used to describe the patterns in the error message
+func compilePatterns(kind string, patterns []string) ([]*regexp.Regexp, error) {
+	var ret []*regexp.Regexp
+	for _, p := range patterns {
+		expr := p
+		if len(p) > 1 && strings.HasPrefix(p, "/") && strings.HasSuffix(p, "/") {
+			expr = p[1 : len(p)-1]
+		} else { // glob: * matches any characters, ? a single one
+			expr = regexp.QuoteMeta(p)
+			expr = strings.Replace(expr, `\*`, ".*", -1)
+			expr = strings.Replace(expr, `\?`, ".", -1)
+			expr = "^" + expr + "$"
+		}
+
+		re, err := regexp.Compile("(?i)" + expr)
+		if err != nil {
+			return nil, fmt.Errorf("can't compile the %v pattern %v: %v", kind, p, err)
+		}
+		ret = append(ret, re)
+	}
+	return ret, nil
+}
+
+// matchPatterns returns true if the string matches one of given patterns
+func matchPatterns(patterns []*regexp.Regexp, s string) bool {
+	for _, re := range patterns {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
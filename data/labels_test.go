@@ -0,0 +1,135 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+func TestLabelFilter_Keep(t *testing.T) {
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		labels  []string
+		want    bool
+	}{
+		{
+			name:   "No rules",
+			labels: []string{"bug"},
+			want:   true,
+		},
+		{
+			name:    "Exact exclude match in different case",
+			exclude: []string{"no changelog"},
+			labels:  []string{"bug", "No Changelog"},
+			want:    false,
+		},
+		{
+			name:    "Glob exclude match",
+			exclude: []string{"kind/*"},
+			labels:  []string{"kind/question"},
+			want:    false,
+		},
+		{
+			name:    "Glob does not match partially",
+			exclude: []string{"won?fix"},
+			labels:  []string{"wontfix later"},
+			want:    true,
+		},
+		{
+			name:    "Regex include match",
+			include: []string{"/^customer-/"},
+			labels:  []string{"Customer-Facing"},
+			want:    true,
+		},
+		{
+			name:    "No include match",
+			include: []string{"customer-facing"},
+			labels:  []string{"internal"},
+			want:    false,
+		},
+		{
+			name:    "No labels with include rules",
+			include: []string{"customer-facing"},
+			want:    false,
+		},
+		{
+			name:    "Exclude wins over include",
+			include: []string{"customer-facing"},
+			exclude: []string{"wontfix"},
+			labels:  []string{"customer-facing", "wontfix"},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := data.NewLabelFilter(tt.include, tt.exclude)
+			if err != nil {
+				t.Fatalf("NewLabelFilter() error = %v", err)
+			}
+			if got := f.Keep(tt.labels); got != tt.want {
+				t.Errorf("LabelFilter.Keep() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLabelFilter(t *testing.T) {
+	_, err := data.NewLabelFilter(nil, []string{"/(abc/"})
+	want := errors.New("can't compile the label pattern /(abc/: error parsing regexp: missing closing ): `(?i)(abc`") // nolint: lll
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("NewLabelFilter() error = %v, want %v", err, want)
+	}
+}
+
+func TestFilterIssuesByLabels(t *testing.T) {
+	f, _ := data.NewLabelFilter([]string{"customer-*"}, nil)
+	is := data.Issues{
+		{ID: 1, Labels: []string{"customer-facing"}},
+		{ID: 2, Labels: []string{"internal"}},
+		{ID: 3},
+	}
+	want := data.Issues{
+		{ID: 1, Labels: []string{"customer-facing"}},
+	}
+
+	if got := data.FilterIssuesByLabels(is, f); !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterIssuesByLabels() = %v, want %v", got, want)
+	}
+}
+
+func TestFilterMRsByLabels(t *testing.T) {
+	f, _ := data.NewLabelFilter(nil, []string{"/^no.changelog$/"})
+	m := data.MRs{
+		{ID: 1, Labels: []string{"No-Changelog"}},
+		{ID: 2, Labels: []string{"bugfix"}},
+		{ID: 3},
+	}
+	want := data.MRs{
+		{ID: 2, Labels: []string{"bugfix"}},
+		{ID: 3},
+	}
+
+	if got := data.FilterMRsByLabels(m, f); !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterMRsByLabels() = %v, want %v", got, want)
+	}
+}
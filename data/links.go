@@ -0,0 +1,117 @@
+/*
+   Copyright 2017 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// closingKeywordsRe matches the closing keywords with the referenced issues,
+// e.g. "Fixes #123" or "Closes #1, #2 and #3"
+var closingKeywordsRe = regexp.MustCompile( // nolint: gochecknoglobals
+	`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+(#\d+(?:(?:\s*,\s*|\s+and\s+)#\d+)*)`,
+)
+
+// issueRefRe matches a single issue reference
+var issueRefRe = regexp.MustCompile(`#(\d+)`) // nolint: gochecknoglobals
+
+// ParseClosingReferences returns the IDs of issues,
+// which are referenced with closing keywords in the given text
+func ParseClosingReferences(text string) []int {
+	var ret []int
+	for _, match := range closingKeywordsRe.FindAllStringSubmatch(text, -1) {
+		for _, ref := range issueRefRe.FindAllStringSubmatch(match[1], -1) {
+			id, err := strconv.Atoi(ref[1])
+			if err != nil || intSliceContains(ret, id) {
+				continue
+			}
+			ret = append(ret, id)
+		}
+	}
+	return ret
+}
+
+// issueKeyRe matches the keys of issues in external trackers like Jira, e.g. ABC-123
+var issueKeyRe = regexp.MustCompile(`\b[A-Z][A-Z0-9_]+-[1-9][0-9]*\b`) // nolint: gochecknoglobals
+
+// ParseIssueKeys returns the unique keys of external issues
+// like ABC-123, which are mentioned in the given texts
+func ParseIssueKeys(texts ...string) []string {
+	var ret []string
+	seen := map[string]bool{}
+	for _, text := range texts {
+		for _, key := range issueKeyRe.FindAllString(text, -1) {
+			if !seen[key] {
+				seen[key] = true
+				ret = append(ret, key)
+			}
+		}
+	}
+	return ret
+}
+
+// LinkIssues links the issues and MRs, which closed them, in both directions.
+// If connector did not provide any closed issues for a MR,
+// the closing references are parsed from its description.
+// The issues of external trackers are linked via the issue keys of MRs,
+// if connector did not provide them, the keys are parsed from the MR title
+func LinkIssues(is Issues, mrs MRs) {
+	for i, mr := range mrs {
+		if len(mr.Closes) == 0 {
+			mrs[i].Closes = ParseClosingReferences(mr.Description)
+		}
+		if len(mr.IssueKeys) == 0 {
+			mrs[i].IssueKeys = ParseIssueKeys(mr.Name)
+		}
+	}
+
+	for i, issue := range is {
+		for j, mr := range mrs {
+			if mr.closes(issue) && !intSliceContains(issue.ClosedBy, mr.ID) {
+				is[i].ClosedBy = append(is[i].ClosedBy, mr.ID)
+			}
+			if intSliceContains(issue.ClosedBy, mr.ID) && !mr.closes(issue) {
+				if issue.Key != "" {
+					mrs[j].IssueKeys = append(mrs[j].IssueKeys, issue.Key)
+				} else {
+					mrs[j].Closes = append(mrs[j].Closes, issue.ID)
+				}
+			}
+		}
+	}
+}
+
+// closes returns true if the MR closes the given issue,
+// the issues of external trackers are referenced via their keys
+func (mr MR) closes(issue Issue) bool {
+	if issue.Key != "" {
+		return sliceContains(mr.IssueKeys, issue.Key)
+	}
+	return intSliceContains(mr.Closes, issue.ID)
+}
+
+// closedIssues returns the issues from the given list, which are closed by the given MR
+func closedIssues(is Issues, mr MR) Issues {
+	var ret Issues
+	for _, issue := range is {
+		if mr.closes(issue) {
+			ret = append(ret, issue)
+		}
+	}
+	return ret
+}
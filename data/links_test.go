@@ -0,0 +1,162 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+func TestParseClosingReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []int
+	}{
+		{
+			name: "No references",
+			text: "Some improvements, see #123",
+			want: nil,
+		},
+		{
+			name: "Single reference",
+			text: "This PR fixes #123",
+			want: []int{123},
+		},
+		{
+			name: "Different keywords in multiline text",
+			text: "Closes #1\nResolved: #2\n\nFIXES #3, also closes #1",
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "List of references",
+			text: "Closes #10, #11 and #12",
+			want: []int{10, 11, 12},
+		},
+		{
+			name: "Keyword within other word",
+			text: "Prefixes #10",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := data.ParseClosingReferences(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseClosingReferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIssueKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  []string
+	}{
+		{
+			name:  "No keys",
+			texts: []string{"Fix crash", "feature/utf-8-support"},
+		},
+		{
+			name:  "Keys in title and branch",
+			texts: []string{"ABC-12: Fix crash, see also ABC-7", "bugfix/ABC-12-crash", "DATA_2-1"},
+			want:  []string{"ABC-12", "ABC-7", "DATA_2-1"},
+		},
+		{
+			name:  "Lower case and zero keys are ignored",
+			texts: []string{"abc-12 ABC-0 A-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := data.ParseIssueKeys(tt.texts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseIssueKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkIssues(t *testing.T) {
+	issues := data.Issues{
+		{ID: 1},
+		{ID: 2, ClosedBy: []int{20}},
+		{ID: 3},
+	}
+	mrs := data.MRs{
+		{ID: 10, Description: "Fixes #1 and #3"},
+		{ID: 20},
+		{ID: 30, Description: "Fixes #3", Closes: []int{1}},
+	}
+
+	wantIssues := data.Issues{
+		{ID: 1, ClosedBy: []int{10, 30}},
+		{ID: 2, ClosedBy: []int{20}},
+		{ID: 3, ClosedBy: []int{10}},
+	}
+	wantMRs := data.MRs{
+		{ID: 10, Description: "Fixes #1 and #3", Closes: []int{1, 3}},
+		{ID: 20, Closes: []int{2}},
+		{ID: 30, Description: "Fixes #3", Closes: []int{1}},
+	}
+
+	data.LinkIssues(issues, mrs)
+
+	if !reflect.DeepEqual(issues, wantIssues) {
+		t.Errorf("LinkIssues() issues = %+v, want %+v", issues, wantIssues)
+	}
+	if !reflect.DeepEqual(mrs, wantMRs) {
+		t.Errorf("LinkIssues() mrs = %+v, want %+v", mrs, wantMRs)
+	}
+}
+
+func TestLinkIssues_Keys(t *testing.T) {
+	issues := data.Issues{
+		{Key: "ABC-1"},
+		{Key: "ABC-2", ClosedBy: []int{20}},
+		{Key: "ABC-3"},
+		{ID: 3},
+	}
+	mrs := data.MRs{
+		{ID: 10, Name: "ABC-1: fix the crash"},
+		{ID: 20},
+		{ID: 30, Name: "Update docs", IssueKeys: []string{"ABC-3"}},
+	}
+
+	wantIssues := data.Issues{
+		{Key: "ABC-1", ClosedBy: []int{10}},
+		{Key: "ABC-2", ClosedBy: []int{20}},
+		{Key: "ABC-3", ClosedBy: []int{30}},
+		{ID: 3},
+	}
+	wantMRs := data.MRs{
+		{ID: 10, Name: "ABC-1: fix the crash", IssueKeys: []string{"ABC-1"}},
+		{ID: 20, IssueKeys: []string{"ABC-2"}},
+		{ID: 30, Name: "Update docs", IssueKeys: []string{"ABC-3"}},
+	}
+
+	data.LinkIssues(issues, mrs)
+
+	if !reflect.DeepEqual(issues, wantIssues) {
+		t.Errorf("LinkIssues() issues = %+v, want %+v", issues, wantIssues)
+	}
+	if !reflect.DeepEqual(mrs, wantMRs) {
+		t.Errorf("LinkIssues() mrs = %+v, want %+v", mrs, wantMRs)
+	}
+}
@@ -0,0 +1,44 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data
+
+import (
+	"strings"
+)
+
+// Milestone describes a milestone of repository
+type Milestone struct {
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Milestones is a slice with Milestone elements
+type Milestones []Milestone
+
+// AddMilestones assigns the milestones to the releases with the same name,
+// the prefix v is ignored, so the milestone 1.2.0 belongs to the release v1.2.0
+func AddMilestones(r Releases, m Milestones) {
+	for i := range r {
+		for j := range m {
+			if strings.TrimPrefix(r[i].Release, "v") == strings.TrimPrefix(m[j].Title, "v") {
+				r[i].Milestone = &m[j]
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+func TestAddMilestones(t *testing.T) {
+	releases := data.Releases{{Release: "v1.2.0"}, {Release: "v1.1.0"}, {Release: "v1.0.0"}}
+	milestones := data.Milestones{
+		{Title: "1.2.0", URL: "https://example.com/milestones/3"},
+		{Title: "v1.0.0", URL: "https://example.com/milestones/1"},
+		{Title: "Backlog", URL: "https://example.com/milestones/2"},
+	}
+
+	data.AddMilestones(releases, milestones)
+
+	want := []*data.Milestone{&milestones[0], nil, &milestones[1]}
+	for i, r := range releases {
+		if !reflect.DeepEqual(r.Milestone, want[i]) {
+			t.Errorf("AddMilestones() release %v = %v, want %v", r.Release, r.Milestone, want[i])
+		}
+	}
+}
@@ -22,13 +22,20 @@ import (
 
 // MR describes a Pull or Merge Request
 type MR struct {
-	ID         int
-	Name       string
-	URL        string
-	Author     string
-	AuthorURL  string
-	MergedDate time.Time
-	Labels     []string
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	URL          string    `json:"url"`
+	Author       string    `json:"author"`
+	AuthorURL    string    `json:"author_url"`
+	MergedDate   time.Time `json:"merged_date"`
+	Labels       []string  `json:"labels,omitempty"`
+	Description  string    `json:"description,omitempty"`
+	Closes       []int     `json:"closes,omitempty"`       // IDs of issues, which are closed by this MR
+	Files        []string  `json:"files,omitempty"`        // changed files, provided only if needed for filtering
+	MergeCommit  string    `json:"merge_commit,omitempty"` // SHA of the commit created by the merge
+	IssueKeys    []string  `json:"issue_keys,omitempty"`   // keys of linked issues in external trackers, e.g. Jira
+	ClosedIssues Issues    `json:"-"`                      // closed issues of the same release, filled by NewReleases
+	Returning    bool      `json:"-"`                      // the author merged MRs before, see MarkReturningAuthors
 }
 
 // MRs is a slice with MR elements
@@ -61,19 +68,11 @@ func FilterMRs(m MRs, fromDate, toDate time.Time) MRs {
 	return ret
 }
 
-// FilterMRsByLabel filters out the MRs with given labels
-func FilterMRsByLabel(m MRs, withoutLabels []string) MRs {
+// FilterMRsByLabels filters the MRs using the given LabelFilter
+func FilterMRsByLabels(m MRs, f *LabelFilter) MRs {
 	var ret MRs
 	for _, mr := range m {
-		found := false
-		for _, label := range mr.Labels {
-			if sliceContains(withoutLabels, label) {
-				found = true
-				break
-			}
-		}
-
-		if !found {
+		if f.Keep(mr.Labels) {
 			ret = append(ret, mr)
 		}
 	}
@@ -0,0 +1,104 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// PathFilter matches the changed files against path globs.
+// A glob matches the file itself and all files in the matching directories,
+// e.g. libs/* matches libs/foo/bar.go. The path elements are matched like
+// in path.Match, ** matches any number of directories, e.g. **/testdata.
+// The globs are cleaned: ./libs/foo/ is the same as libs/foo, . matches all files
+type PathFilter struct {
+	patterns [][]string // path elements of the globs
+}
+
+// NewPathFilter returns a new PathFilter for given globs
+func NewPathFilter(patterns []string) (*PathFilter, error) {
+	var ret [][]string
+	for _, p := range patterns {
+		elems := splitPath(p)
+		for _, e := range elems {
+			if _, err := path.Match(e, ""); err != nil {
+				return nil, fmt.Errorf("can't compile the path pattern %v: %v",
+					strings.Join(elems, "/"), err)
+			}
+		}
+		ret = append(ret, elems)
+	}
+	return &PathFilter{patterns: ret}, nil
+}
+
+// Match returns true if one of the files matches one of the globs
+func (f *PathFilter) Match(files []string) bool {
+	for _, file := range files {
+		elems := splitPath(file)
+		for _, pattern := range f.patterns {
+			if matchPath(pattern, elems) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitPath returns the elements of cleaned path, the root and . have no elements
+func splitPath(p string) []string {
+	p = strings.Trim(path.Clean("/"+p), "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// matchPath returns true if the elements of glob match the elements
+// of the path or of one of its parent directories
+func matchPath(pattern, elems []string) bool {
+	if len(pattern) == 0 {
+		return true
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(elems); i++ {
+			if matchPath(pattern[1:], elems[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(elems) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], elems[0]); !ok { // nolint: gosec
+		return false
+	}
+	return matchPath(pattern[1:], elems[1:])
+}
+
+// FilterMRsByPaths returns the MRs, which changed files matching the PathFilter
+func FilterMRsByPaths(m MRs, f *PathFilter) MRs {
+	var ret MRs
+	for _, mr := range m {
+		if f.Match(mr.Files) {
+			ret = append(ret, mr)
+		}
+	}
+	return ret
+}
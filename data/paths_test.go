@@ -0,0 +1,125 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+func TestPathFilter_Match(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		files    []string
+		want     bool
+	}{
+		{
+			name:     "Exact file",
+			patterns: []string{"README.md"},
+			files:    []string{"docs/index.md", "README.md"},
+			want:     true,
+		},
+		{
+			name:     "Directory",
+			patterns: []string{"libs/foo/"},
+			files:    []string{"libs/foo/bar/baz.go"},
+			want:     true,
+		},
+		{
+			name:     "Glob directory",
+			patterns: []string{"libs/*"},
+			files:    []string{"libs/foo/bar.go"},
+			want:     true,
+		},
+		{
+			name:     "Glob file extension",
+			patterns: []string{"docs/*.md"},
+			files:    []string{"docs/usage.md"},
+			want:     true,
+		},
+		{
+			name:     "Similar prefix does not match",
+			patterns: []string{"libs/foo"},
+			files:    []string{"libs/foobar/main.go"},
+			want:     false,
+		},
+		{
+			name:     "Directory with dot prefix",
+			patterns: []string{"./libs/foo/"},
+			files:    []string{"libs/foo/main.go"},
+			want:     true,
+		},
+		{
+			name:     "Repository root",
+			patterns: []string{"."},
+			files:    []string{"main.go"},
+			want:     true,
+		},
+		{
+			name:     "Leading double star",
+			patterns: []string{"**/testdata"},
+			files:    []string{"libs/foo/testdata/data.json"},
+			want:     true,
+		},
+		{
+			name:     "Double star matches no directories",
+			patterns: []string{"libs/**/main.go"},
+			files:    []string{"libs/main.go"},
+			want:     true,
+		},
+		{
+			name:     "Double star in the middle",
+			patterns: []string{"libs/**/*.md"},
+			files:    []string{"libs/foo/docs/usage.md"},
+			want:     true,
+		},
+		{
+			name:     "Double star does not match other directories",
+			patterns: []string{"libs/**/*.md"},
+			files:    []string{"docs/usage.md"},
+			want:     false,
+		},
+		{
+			name:     "No files",
+			patterns: []string{"libs"},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := data.NewPathFilter(tt.patterns)
+			if err != nil {
+				t.Fatalf("NewPathFilter() error = %v", err)
+			}
+			if got := f.Match(tt.files); got != tt.want {
+				t.Errorf("PathFilter.Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPathFilter(t *testing.T) {
+	_, err := data.NewPathFilter([]string{"libs/["})
+	want := errors.New("can't compile the path pattern libs/[: syntax error in pattern")
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("NewPathFilter() error = %v, wantErr %v", err, want)
+	}
+}
@@ -18,18 +18,34 @@ package data
 
 import (
 	"sort"
+	"strings"
 	"time"
 )
 
-const releaseDateFormat = "02.01.2006"
+// ReleaseDateFormat is the format of Release.Date
+const ReleaseDateFormat = "02.01.2006"
+
+// GeneratedNotesTrailer marks the release notes generated by chagen, e.g. in the
+// messages of release tags. They are not used as release descriptions to avoid duplicates
+const GeneratedNotesTrailer = "Generated-by: chagen"
 
 // Release desribes a release with it data
 type Release struct {
-	Release    string
-	ReleaseURL string
-	Date       string
-	Issues     Issues
-	MRs        MRs
+	Release      string
+	ReleaseURL   string
+	Date         string    // formatted with ReleaseDateFormat
+	Time         time.Time // date and time of the release, e.g. for calculations
+	Title        string
+	Description  string
+	Prerelease   bool
+	Draft        bool
+	Issues       Issues
+	MRs          MRs
+	CollapsedMRs MRs        // MRs, which are summarized, e.g. dependency updates
+	Commits      Commits    // direct commits without MR, filled by AddCommits
+	CompareURL   string     // link to the changes since previous release, filled by AddCompareURLs
+	Milestone    *Milestone // milestone of the same name, filled by AddMilestones
+	Contributors Contributors
 }
 
 // Releases is a slice with Release elements
@@ -43,6 +59,7 @@ func NewReleases(tags Tags, issues Issues, mrs MRs) Releases {
 
 	// we should work always with UTC time to avoid surprises
 	UTCDate(tags, issues, mrs)
+	LinkIssues(issues, mrs)
 
 	// we should have a proper sorted data to avoid surprises
 	sort.Sort(&tags)
@@ -59,14 +76,71 @@ func NewReleases(tags Tags, issues Issues, mrs MRs) Releases {
 			lastReleaseDate = time.Time{}
 		}
 
+		relIssues := FilterIssues(issues, lastReleaseDate, tag.Date)
+		relMRs := FilterMRs(mrs, lastReleaseDate, tag.Date)
+		for i := range relMRs {
+			relMRs[i].ClosedIssues = closedIssues(relIssues, relMRs[i])
+		}
+
 		ret = append(ret, Release{
-			Release:    tag.Name,
-			ReleaseURL: tag.URL,
-			Date:       tag.Date.Format(releaseDateFormat),
-			Issues:     FilterIssues(issues, lastReleaseDate, tag.Date),
-			MRs:        FilterMRs(mrs, lastReleaseDate, tag.Date),
+			Release:     tag.Name,
+			ReleaseURL:  tag.URL,
+			Date:        tag.Date.Format(ReleaseDateFormat),
+			Time:        tag.Date,
+			Title:       tag.Title,
+			Description: releaseDescription(tag),
+			Prerelease:  tag.Prerelease,
+			Draft:       tag.Draft,
+			Issues:      relIssues,
+			MRs:         relMRs,
 		})
 	}
 
+	setContributors(ret)
+
+	return ret
+}
+
+// releaseDescription returns the description of given tag,
+// the release notes generated by chagen are skipped
+func releaseDescription(tag Tag) string {
+	description := strings.TrimSpace(tag.Description)
+	if strings.HasSuffix(description, GeneratedNotesTrailer) {
+		return ""
+	}
+	return description
+}
+
+// UnlinkedIssues returns the issues of the release,
+// which are not closed by any MR of the same release
+func (r Release) UnlinkedIssues() Issues {
+	var ret Issues
+	for _, issue := range r.Issues {
+		linked := false
+		for _, mr := range r.MRs {
+			if mr.closes(issue) {
+				linked = true
+				break
+			}
+		}
+
+		if !linked {
+			ret = append(ret, issue)
+		}
+	}
 	return ret
 }
+
+// AddCompareURLs sets the URLs with the changes of releases
+// compared to the previous release using given function.
+// The releases have to be sorted like returned by NewReleases
+func AddCompareURLs(r Releases, compareURL func(from, to string) (string, error)) error {
+	for i := 0; i < len(r)-1; i++ {
+		u, err := compareURL(r[i+1].Release, r[i].Release)
+		if err != nil {
+			return err
+		}
+		r[i].CompareURL = u
+	}
+	return nil
+}
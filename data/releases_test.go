@@ -17,6 +17,7 @@
 package data_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -36,6 +37,7 @@ func TestNewReleases(t *testing.T) {
 				data.Release{
 					Release:    "v0.1.2",
 					Date:       "20.03.2003",
+					Time:       helpers.Time(1048183647),
 					ReleaseURL: "https://test.example.com/tags/v0.1.2",
 					MRs: data.MRs{
 						data.MR{
@@ -48,10 +50,18 @@ func TestNewReleases(t *testing.T) {
 							Labels:     []string{"no changelog"},
 						},
 					},
+					Contributors: data.Contributors{
+						{
+							Name:      "test-user8",
+							URL:       "https://test.example.com/authors/test-user8",
+							FirstTime: true,
+						},
+					},
 				},
 				data.Release{
 					Release:    "v0.1.1",
 					Date:       "19.03.2003",
+					Time:       helpers.Time(1048083647),
 					ReleaseURL: "https://test.example.com/tags/v0.1.1",
 					Issues: data.Issues{
 						data.Issue{
@@ -73,10 +83,17 @@ func TestNewReleases(t *testing.T) {
 							Labels:     []string{"bugfix"},
 						},
 					},
+					Contributors: data.Contributors{
+						{
+							Name: "test-user",
+							URL:  "https://test.example.com/authors/test-user",
+						},
+					},
 				},
 				data.Release{
 					Release:    "v0.1.0",
 					Date:       "18.03.2003",
+					Time:       helpers.Time(1047983647),
 					ReleaseURL: "https://test.example.com/tags/v0.1.0",
 					Issues: data.Issues{
 						data.Issue{
@@ -97,10 +114,17 @@ func TestNewReleases(t *testing.T) {
 							Labels:     []string{"bugfix"},
 						},
 					},
+					Contributors: data.Contributors{
+						{
+							Name: "test-user",
+							URL:  "https://test.example.com/authors/test-user",
+						},
+					},
 				},
 				data.Release{
 					Release:    "v0.0.9",
 					Date:       "17.03.2003",
+					Time:       helpers.Time(1047883647),
 					ReleaseURL: "https://test.example.com/tags/v0.0.9",
 					MRs: data.MRs{
 						data.MR{
@@ -113,10 +137,17 @@ func TestNewReleases(t *testing.T) {
 							Labels:     []string{"invalid"},
 						},
 					},
+					Contributors: data.Contributors{
+						{
+							Name: "test-user",
+							URL:  "https://test.example.com/authors/test-user",
+						},
+					},
 				},
 				data.Release{
 					Release:    "v0.0.8",
 					Date:       "16.03.2003",
+					Time:       helpers.Time(1047783647),
 					ReleaseURL: "https://test.example.com/tags/v0.0.8",
 					Issues: data.Issues{
 						data.Issue{
@@ -138,10 +169,18 @@ func TestNewReleases(t *testing.T) {
 							Labels:     []string{"bugfix"},
 						},
 					},
+					Contributors: data.Contributors{
+						{
+							Name:      "test5-user",
+							URL:       "https://test.example.com/authors/test5-user",
+							FirstTime: true,
+						},
+					},
 				},
 				data.Release{
 					Release:    "v0.0.7",
 					Date:       "14.03.2003",
+					Time:       helpers.Time(1047683647),
 					ReleaseURL: "https://test.example.com/tags/v0.0.7",
 					Issues: data.Issues{
 						data.Issue{
@@ -163,10 +202,17 @@ func TestNewReleases(t *testing.T) {
 							Labels:     []string{"enhancement"},
 						},
 					},
+					Contributors: data.Contributors{
+						{
+							Name: "test-user",
+							URL:  "https://test.example.com/authors/test-user",
+						},
+					},
 				},
 				data.Release{
 					Release:    "v0.0.6",
 					Date:       "13.03.2003",
+					Time:       helpers.Time(1047583647),
 					ReleaseURL: "https://test.example.com/tags/v0.0.6",
 					MRs: data.MRs{
 						data.MR{
@@ -179,10 +225,17 @@ func TestNewReleases(t *testing.T) {
 							Labels:     []string{"bugfix"},
 						},
 					},
+					Contributors: data.Contributors{
+						{
+							Name: "test-user",
+							URL:  "https://test.example.com/authors/test-user",
+						},
+					},
 				},
 				data.Release{
 					Release:    "v0.0.5",
 					Date:       "12.03.2003",
+					Time:       helpers.Time(1047483647),
 					ReleaseURL: "https://test.example.com/tags/v0.0.5",
 					Issues: data.Issues{
 						data.Issue{
@@ -196,6 +249,7 @@ func TestNewReleases(t *testing.T) {
 				data.Release{
 					Release:    "v0.0.4",
 					Date:       "11.03.2003",
+					Time:       helpers.Time(1047383647),
 					ReleaseURL: "https://test.example.com/tags/v0.0.4",
 					MRs: data.MRs{
 						data.MR{
@@ -208,10 +262,17 @@ func TestNewReleases(t *testing.T) {
 							Labels:     []string{"enhancement", "bugfix"},
 						},
 					},
+					Contributors: data.Contributors{
+						{
+							Name: "test-user",
+							URL:  "https://test.example.com/authors/test-user",
+						},
+					},
 				},
 				data.Release{
 					Release:    "v0.0.3",
 					Date:       "10.03.2003",
+					Time:       helpers.Time(1047283647),
 					ReleaseURL: "https://test.example.com/tags/v0.0.3",
 					Issues: data.Issues{
 						data.Issue{
@@ -232,10 +293,18 @@ func TestNewReleases(t *testing.T) {
 							AuthorURL:  "https://test.example.com/authors/test-user2",
 						},
 					},
+					Contributors: data.Contributors{
+						{
+							Name:      "test-user2",
+							URL:       "https://test.example.com/authors/test-user2",
+							FirstTime: true,
+						},
+					},
 				},
 				data.Release{
 					Release:    "v0.0.2",
 					Date:       "09.03.2003",
+					Time:       helpers.Time(1047183647),
 					ReleaseURL: "https://test.example.com/tags/v0.0.2",
 					Issues: data.Issues{
 						data.Issue{
@@ -257,10 +326,18 @@ func TestNewReleases(t *testing.T) {
 							Labels:     []string{"bugfix"},
 						},
 					},
+					Contributors: data.Contributors{
+						{
+							Name:      "test-user",
+							URL:       "https://test.example.com/authors/test-user",
+							FirstTime: true,
+						},
+					},
 				},
 				data.Release{
 					Release:    "v0.0.1",
 					Date:       "08.03.2003",
+					Time:       helpers.Time(1047083647),
 					ReleaseURL: "https://test.example.com/tags/v0.0.1",
 				},
 			},
@@ -285,3 +362,170 @@ func TestNewReleases(t *testing.T) {
 		})
 	}
 }
+
+func TestNewReleasesWithReleaseDetails(t *testing.T) {
+	tags := data.Tags{
+		{
+			Name:        "v0.2.0",
+			Date:        helpers.Time(1048294647),
+			URL:         "https://test.example.com/releases/v0.2.0",
+			Title:       "Preview of v0.2",
+			Description: "Try the new features",
+			Prerelease:  true,
+		},
+		{
+			Name: "v0.1.0",
+			Date: helpers.Time(1047983647),
+			URL:  "https://test.example.com/tags/v0.1.0",
+			// the generated release notes are skipped
+			Description: "Closed issues\n-------------\n\n" + data.GeneratedNotesTrailer + "\n",
+		},
+	}
+	want := data.Releases{
+		{
+			Release:     "v0.2.0",
+			ReleaseURL:  "https://test.example.com/releases/v0.2.0",
+			Date:        "22.03.2003",
+			Time:        helpers.Time(1048294647),
+			Title:       "Preview of v0.2",
+			Description: "Try the new features",
+			Prerelease:  true,
+		},
+		{
+			Release:    "v0.1.0",
+			ReleaseURL: "https://test.example.com/tags/v0.1.0",
+			Date:       "18.03.2003",
+			Time:       helpers.Time(1047983647),
+		},
+	}
+
+	got := data.NewReleases(tags, nil, nil)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewReleases() = %#v, want %#v", got, want)
+	}
+}
+
+func TestNewReleasesLinkedIssues(t *testing.T) {
+	tags := data.Tags{
+		{Name: "v0.2.0", Date: helpers.Time(1048294647)},
+		{Name: "v0.1.0", Date: helpers.Time(1047983647)},
+	}
+	issues := data.Issues{
+		{ID: 1, ClosedDate: helpers.Time(1048194647)},
+		{ID: 2, ClosedDate: helpers.Time(1048194647)},
+		{ID: 3, ClosedDate: helpers.Time(1047883647)},
+	}
+	mrs := data.MRs{
+		// issue 3 belongs to the previous release and can't be nested
+		{ID: 10, MergedDate: helpers.Time(1048194648), Closes: []int{1, 3}},
+	}
+
+	got := data.NewReleases(tags, issues, mrs)
+
+	wantClosed := data.Issues{
+		{ID: 1, ClosedDate: helpers.Time(1048194647), ClosedBy: []int{10}},
+	}
+	if !reflect.DeepEqual(got[0].MRs[0].ClosedIssues, wantClosed) {
+		t.Errorf("NewReleases() ClosedIssues = %+v, want %+v", got[0].MRs[0].ClosedIssues, wantClosed)
+	}
+
+	wantUnlinked := data.Issues{
+		{ID: 2, ClosedDate: helpers.Time(1048194647)},
+	}
+	if !reflect.DeepEqual(got[0].UnlinkedIssues(), wantUnlinked) {
+		t.Errorf("Release.UnlinkedIssues() = %+v, want %+v", got[0].UnlinkedIssues(), wantUnlinked)
+	}
+}
+
+func TestNewReleasesContributors(t *testing.T) {
+	tags := data.Tags{
+		{Name: "v0.2.0", Date: helpers.Time(1048294647)},
+		{Name: "v0.1.0", Date: helpers.Time(1047983647)},
+	}
+	mrs := data.MRs{
+		{ID: 1, MergedDate: helpers.Time(1048194647), Author: "old", AuthorURL: "https://example.com/old"},
+		{ID: 2, MergedDate: helpers.Time(1048194648), Author: "new", AuthorURL: "https://example.com/new"},
+		{ID: 3, MergedDate: helpers.Time(1048194649), Author: "new", AuthorURL: "https://example.com/new"},
+		{ID: 4, MergedDate: helpers.Time(1048194650), Author: "dependabot[bot]"},
+		{ID: 5, MergedDate: helpers.Time(1047883647), Author: "old", AuthorURL: "https://example.com/old"},
+	}
+
+	got := data.NewReleases(tags, nil, mrs)
+
+	want := []data.Contributors{
+		{
+			{Name: "new", URL: "https://example.com/new", FirstTime: true},
+			{Name: "old", URL: "https://example.com/old"},
+		},
+		{
+			{Name: "old", URL: "https://example.com/old", FirstTime: true},
+		},
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i].Contributors, want[i]) {
+			t.Errorf("NewReleases() %v Contributors = %+v, want %+v", got[i].Release, got[i].Contributors, want[i])
+		}
+	}
+}
+
+func TestNewReleasesContributorsOfFilteredMRs(t *testing.T) {
+	tags := data.Tags{
+		{Name: "v0.2.0", Date: helpers.Time(1048294647)},
+		{Name: "v0.1.0", Date: helpers.Time(1047983647)},
+	}
+	mrs := data.MRs{
+		{ID: 1, MergedDate: helpers.Time(1048194647), Author: "old", AuthorURL: "https://example.com/old"},
+		{ID: 2, MergedDate: helpers.Time(1048194648), Author: "new", AuthorURL: "https://example.com/new"},
+		{ID: 3, MergedDate: helpers.Time(1048194649), Author: "new", AuthorURL: "https://example.com/new"},
+		{ID: 5, MergedDate: helpers.Time(1047883647), Author: "old", AuthorURL: "https://example.com/old"},
+	}
+	data.MarkReturningAuthors(mrs)
+
+	// the first MR of old is filtered out, e.g. by its labels
+	got := data.NewReleases(tags, nil, mrs[:3])
+
+	want := data.Contributors{
+		{Name: "new", URL: "https://example.com/new", FirstTime: true},
+		{Name: "old", URL: "https://example.com/old"},
+	}
+	if !reflect.DeepEqual(got[0].Contributors, want) {
+		t.Errorf("NewReleases() Contributors = %+v, want %+v", got[0].Contributors, want)
+	}
+}
+
+func TestAddCompareURLs(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "URLs are added",
+			want: []string{"v0.1.0...v0.2.0", "v0.0.1...v0.1.0", ""},
+		},
+		{
+			name:    "Errors are passed",
+			err:     errors.New("no compare"),
+			want:    []string{"", "", ""},
+			wantErr: errors.New("no compare"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			releases := data.Releases{{Release: "v0.2.0"}, {Release: "v0.1.0"}, {Release: "v0.0.1"}}
+
+			err := data.AddCompareURLs(releases, func(from, to string) (string, error) {
+				return from + "..." + to, tt.err
+			})
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("AddCompareURLs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for i, r := range releases {
+				if r.CompareURL != tt.want[i] {
+					t.Errorf("AddCompareURLs() release %v = %v, want %v", r.Release, r.CompareURL, tt.want[i])
+				}
+			}
+		})
+	}
+}
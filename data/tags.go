@@ -21,12 +21,16 @@ import (
 	"time"
 )
 
-// Tag describes a git tag
+// Tag describes a git tag or a hosted release
 type Tag struct {
-	Name   string
-	Commit string
-	Date   time.Time
-	URL    string
+	Name        string    `json:"name"`
+	Commit      string    `json:"commit,omitempty"`
+	Date        time.Time `json:"date"`
+	URL         string    `json:"url"`
+	Title       string    `json:"title,omitempty"` // name of the hosted release, if any
+	Description string    `json:"description,omitempty"`
+	Prerelease  bool      `json:"prerelease,omitempty"`
+	Draft       bool      `json:"draft,omitempty"`
 }
 
 // Tags is a slice with Tag elements
@@ -0,0 +1,204 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// possible levels of version bumps, higher levels win
+const (
+	BumpPatch = iota
+	BumpMinor
+	BumpMajor
+)
+
+// semverRe matches semantic versions with an optional prefix like v1.2.3
+var semverRe = regexp.MustCompile(`^(\D*)(\d+)\.(\d+)\.(\d+)$`) // nolint: gochecknoglobals
+
+// conventionalRe matches the Conventional Commit prefixes like feat(cli)!:
+var conventionalRe = regexp.MustCompile(`^(\w+)(\([^)]*\))?(!)?:`) // nolint: gochecknoglobals
+
+// Version describes a semantic version, the prefix like v is kept
+type Version struct {
+	Prefix string
+	Major  int
+	Minor  int
+	Patch  int
+}
+
+// ParseVersion parses the given semantic version,
+// false is returned if the version is not a semantic one
+func ParseVersion(s string) (Version, bool) {
+	m := semverRe.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, false
+	}
+
+	var v Version
+	var err error
+	v.Prefix = m[1]
+	if v.Major, err = strconv.Atoi(m[2]); err != nil {
+		return Version{}, false
+	}
+	if v.Minor, err = strconv.Atoi(m[3]); err != nil {
+		return Version{}, false
+	}
+	if v.Patch, err = strconv.Atoi(m[4]); err != nil {
+		return Version{}, false
+	}
+	return v, true
+}
+
+// Less returns true if the version is lower than the given one
+func (v Version) Less(o Version) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	return v.Patch < o.Patch
+}
+
+// Bump returns the next version for the given bump level
+func (v Version) Bump(level int) Version {
+	switch level {
+	case BumpMajor:
+		return Version{Prefix: v.Prefix, Major: v.Major + 1}
+	case BumpMinor:
+		return Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor + 1}
+	default:
+		return Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+}
+
+// String implements the fmt.Stringer
+func (v Version) String() string {
+	return fmt.Sprintf("%v%v.%v.%v", v.Prefix, v.Major, v.Minor, v.Patch)
+}
+
+// BumpRules decides about the bump level of changes using labels
+// and optionally the Conventional Commit prefixes of MR titles
+type BumpRules struct {
+	major        []*regexp.Regexp
+	minor        []*regexp.Regexp
+	conventional bool
+}
+
+// NewBumpRules returns new BumpRules, changes with major or minor labels cause
+// the according bump, all other changes are patches. conventional enables
+// the evaluation of Conventional Commit prefixes like feat: or fix!:
+func NewBumpRules(major, minor []string, conventional bool) (*BumpRules, error) {
+	ma, err := compilePatterns("label", major)
+	if err != nil {
+		return nil, err
+	}
+	mi, err := compilePatterns("label", minor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BumpRules{major: ma, minor: mi, conventional: conventional}, nil
+}
+
+// labelsLevel returns the bump level caused by the labels
+func (r *BumpRules) labelsLevel(labels []string) int {
+	level := BumpPatch
+	for _, label := range labels {
+		if matchPatterns(r.major, label) {
+			return BumpMajor
+		}
+		if matchPatterns(r.minor, label) {
+			level = BumpMinor
+		}
+	}
+	return level
+}
+
+// issueLevel returns the bump level of the issue
+func (r *BumpRules) issueLevel(i Issue) int {
+	if r == nil {
+		return BumpPatch
+	}
+	return r.labelsLevel(i.Labels)
+}
+
+// mrLevel returns the bump level of the MR
+func (r *BumpRules) mrLevel(mr MR) int {
+	if r == nil {
+		return BumpPatch
+	}
+
+	level := r.labelsLevel(mr.Labels)
+	if !r.conventional {
+		return level
+	}
+
+	m := conventionalRe.FindStringSubmatch(mr.Name)
+	switch {
+	case (m != nil && m[3] == "!") || strings.Contains(mr.Description, "BREAKING CHANGE"):
+		return BumpMajor
+	case m != nil && strings.ToLower(m[1]) == "feat" && level < BumpMinor:
+		return BumpMinor
+	}
+	return level
+}
+
+// NextVersion returns the next version for the changes after
+// the latest semantic version tag. If there is no such tag, 0.0.0 is assumed
+func NextVersion(tags Tags, issues Issues, mrs MRs, rules *BumpRules) (string, error) {
+	latest := Version{Prefix: "v"}
+	var latestDate time.Time
+	found := false
+	for _, tag := range tags {
+		v, ok := ParseVersion(tag.Name)
+		if ok && (!found || latest.Less(v)) {
+			latest, latestDate, found = v, tag.Date, true
+		}
+	}
+
+	level := -1
+	for _, i := range issues {
+		if i.ClosedDate.After(latestDate) {
+			level = maxInt(level, rules.issueLevel(i))
+		}
+	}
+	for _, mr := range mrs {
+		if mr.MergedDate.After(latestDate) {
+			level = maxInt(level, rules.mrLevel(mr))
+		}
+	}
+
+	if level < 0 {
+		return "", fmt.Errorf("no unreleased changes after %v", latest)
+	}
+
+	return latest.Bump(level).String(), nil
+}
+
+// maxInt returns the larger value
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
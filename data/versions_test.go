@@ -0,0 +1,147 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package data_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/internal/testing/helpers"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   data.Version
+		wantOk bool
+	}{
+		{"v1.2.3", data.Version{Prefix: "v", Major: 1, Minor: 2, Patch: 3}, true},
+		{"10.0.12", data.Version{Major: 10, Patch: 12}, true},
+		{"release-0.1.0", data.Version{Prefix: "release-", Minor: 1}, true},
+		{"v1.2", data.Version{}, false},
+		{"v1.2.3-rc1", data.Version{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := data.ParseVersion(tt.name)
+			if !reflect.DeepEqual(got, tt.want) || ok != tt.wantOk {
+				t.Errorf("ParseVersion() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestNextVersion(t *testing.T) { // nolint: gocyclo
+	tags := data.Tags{
+		{Name: "v0.9.0", Date: helpers.Time(1000)},
+		{Name: "v1.2.3", Date: helpers.Time(2000)},
+		{Name: "v1.10.0-rc1", Date: helpers.Time(3000)},
+	}
+
+	tests := []struct {
+		name         string
+		tags         data.Tags
+		issues       data.Issues
+		mrs          data.MRs
+		conventional bool
+		want         string
+		wantErr      error
+	}{
+		{
+			name:   "Patch",
+			tags:   tags,
+			issues: data.Issues{{Name: "old", ClosedDate: helpers.Time(1500), Labels: []string{"breaking"}}},
+			mrs:    data.MRs{{Name: "fix", MergedDate: helpers.Time(2500), Labels: []string{"bug"}}},
+			want:   "v1.2.4",
+		},
+		{
+			name:   "Minor via issue label",
+			tags:   tags,
+			issues: data.Issues{{Name: "new", ClosedDate: helpers.Time(2500), Labels: []string{"Enhancement"}}},
+			want:   "v1.3.0",
+		},
+		{
+			name: "Major via MR label",
+			tags: tags,
+			mrs: data.MRs{
+				{Name: "feature", MergedDate: helpers.Time(2500), Labels: []string{"enhancement"}},
+				{Name: "api change", MergedDate: helpers.Time(2600), Labels: []string{"breaking"}},
+			},
+			want: "v2.0.0",
+		},
+		{
+			name: "Conventional prefixes are ignored by default",
+			tags: tags,
+			mrs:  data.MRs{{Name: "feat!: new api", MergedDate: helpers.Time(2500)}},
+			want: "v1.2.4",
+		},
+		{
+			name:         "Conventional feature",
+			tags:         tags,
+			mrs:          data.MRs{{Name: "feat(cli): new flag", MergedDate: helpers.Time(2500)}},
+			conventional: true,
+			want:         "v1.3.0",
+		},
+		{
+			name:         "Conventional breaking change",
+			tags:         tags,
+			mrs:          data.MRs{{Name: "feat(cli)!: new api", MergedDate: helpers.Time(2500)}},
+			conventional: true,
+			want:         "v2.0.0",
+		},
+		{
+			name: "Conventional breaking change in description",
+			tags: tags,
+			mrs: data.MRs{{
+				Name:        "fix: handling of flags",
+				Description: "BREAKING CHANGE: flag -x is removed",
+				MergedDate:  helpers.Time(2500),
+			}},
+			conventional: true,
+			want:         "v2.0.0",
+		},
+		{
+			name: "Without tags",
+			mrs:  data.MRs{{Name: "first", MergedDate: helpers.Time(2500)}},
+			want: "v0.0.1",
+		},
+		{
+			name:    "Without changes",
+			tags:    tags,
+			mrs:     data.MRs{{Name: "old", MergedDate: helpers.Time(1500)}},
+			wantErr: errors.New("no unreleased changes after v1.2.3"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := data.NewBumpRules([]string{"breaking"}, []string{"enhancement"}, tt.conventional)
+			if err != nil {
+				t.Fatalf("NewBumpRules() error = %v", err)
+			}
+
+			got, err := data.NextVersion(tt.tags, tt.issues, tt.mrs, rules)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("NextVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NextVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// apiVersion is the used version of REST API, it is supported
+// by Azure DevOps Services and Azure DevOps Server 2020 or newer
+const apiVersion = "6.0"
+
+// continuationTokenHeader contains the token of next page, if there are further pages
+const continuationTokenHeader = "x-ms-continuationtoken"
+
+// the API structures, only the needed fields are described
+
+type apiList struct {
+	Count int             `json:"count"`
+	Value json.RawMessage `json:"value"`
+}
+
+type apiRef struct {
+	Name           string `json:"name"`
+	ObjectID       string `json:"objectId"`
+	PeeledObjectID string `json:"peeledObjectId"` // commit of annotated tag
+}
+
+type apiCommit struct {
+	CommitID  string `json:"commitId"`
+	Committer struct {
+		Date time.Time `json:"date"`
+	} `json:"committer"`
+}
+
+type apiIdentity struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+type apiLabel struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
+
+type apiPullRequest struct {
+	PullRequestID   int         `json:"pullRequestId"`
+	Title           string      `json:"title"`
+	Description     string      `json:"description"`
+	CreatedBy       apiIdentity `json:"createdBy"`
+	ClosedDate      time.Time   `json:"closedDate"`
+	SourceRefName   string      `json:"sourceRefName"`
+	Labels          []apiLabel  `json:"labels"`
+	LastMergeCommit *struct {
+		CommitID string `json:"commitId"`
+	} `json:"lastMergeCommit"`
+}
+
+type apiResourceRef struct {
+	ID string `json:"id"`
+}
+
+type apiWorkItem struct {
+	ID     int `json:"id"`
+	Fields struct {
+		Title        string     `json:"System.Title"`
+		State        string     `json:"System.State"`
+		WorkItemType string     `json:"System.WorkItemType"`
+		Tags         string     `json:"System.Tags"`
+		ChangedDate  time.Time  `json:"System.ChangedDate"`
+		ClosedDate   *time.Time `json:"Microsoft.VSTS.Common.ClosedDate"`
+	} `json:"fields"`
+}
+
+type apiIteration struct {
+	ID int `json:"id"`
+}
+
+type apiIterationChanges struct {
+	ChangeEntries []struct {
+		Item struct {
+			Path     string `json:"path"`
+			IsFolder bool   `json:"isFolder"`
+		} `json:"item"`
+	} `json:"changeEntries"`
+	NextSkip int `json:"nextSkip"`
+}
+
+// projectPath returns the API path of given project resource
+func (c *Connector) projectPath(resource string) string {
+	return fmt.Sprintf("/%s/%s/_apis%s",
+		url.PathEscape(c.Organization), url.PathEscape(c.Project), resource)
+}
+
+// repoPath returns the API path of given repository resource
+func (c *Connector) repoPath(resource string) string {
+	return c.projectPath(fmt.Sprintf("/git/repositories/%s%s", url.PathEscape(c.Repo), resource))
+}
+
+// get requests the given API path and decodes the JSON response to v.
+// The response is returned for the inspection of status code and headers
+func (c *Connector) get(
+	ctx context.Context,
+	path string,
+	query url.Values,
+	v interface{},
+) (*http.Response, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("api-version", apiVersion)
+
+	req, err := http.NewRequest(http.MethodGet, c.BaseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		// the personal access tokens are sent without user name
+		req.SetBasicAuth("", c.Token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, fmt.Errorf("GET %v: %v", path, resp.Status)
+	}
+	return resp, json.NewDecoder(resp.Body).Decode(v)
+}
+
+// list requests the given API list and decodes its values to v.
+// The continuation token of next page is returned, if the API provides it
+func (c *Connector) list(
+	ctx context.Context,
+	path string,
+	query url.Values,
+	v interface{},
+) (string, error) {
+	var l apiList
+	resp, err := c.get(ctx, path, query, &l)
+	if err != nil {
+		return "", err
+	}
+	if err := json.Unmarshal(l.Value, v); err != nil {
+		return "", err
+	}
+	return resp.Header.Get(continuationTokenHeader), nil
+}
@@ -0,0 +1,134 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package azure implements the connector for Azure DevOps Services and Server repositories
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+
+	"github.com/urfave/cli"
+)
+
+// AccessTokenEnvVar contains the name of environment variable
+// which sets the personal access token (PAT)
+const AccessTokenEnvVar = "CHAGEN_AZURE_TOKEN" // nolint: gosec
+
+// ServicesURL is the URL of Azure DevOps Services
+const ServicesURL = "https://dev.azure.com"
+
+// Connector implements the Azure DevOps connector
+type Connector struct {
+	context      context.Context
+	client       *http.Client
+	BaseURL      string
+	Token        string
+	Organization string
+	Project      string
+	Repo         string
+	ProjectURL   string
+	FetchFiles   bool
+}
+
+// RepositoryExists checks if referenced repository is present
+func (c *Connector) RepositoryExists() (bool, error) {
+	var repo struct{}
+	resp, err := c.get(c.context, c.repoPath(""), nil, &repo)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound { // not found isn't an error
+			return false, nil
+		}
+		return false, formatErrorCode("RepositoryExists", err)
+	}
+	return true, nil
+}
+
+// New returns a new initialized Connector or error if any
+func New(ctx *cli.Context) (connectors.Connector, error) {
+	baseURL := strings.TrimSuffix(ctx.String("azure-url"), "/")
+	if baseURL == "" {
+		return nil, errors.New("option --azure-url is required")
+	}
+	organization := ctx.String("azure-organization")
+	if organization == "" {
+		return nil, errors.New("option --azure-organization is required")
+	}
+	project := ctx.String("azure-project")
+	if project == "" {
+		return nil, errors.New("option --azure-project is required")
+	}
+	repo := ctx.String("azure-repo")
+	if repo == "" {
+		return nil, errors.New("option --azure-repo is required")
+	}
+	tagDateSource, err := connectors.GetTagDateSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the refs API provides only the tagged commits
+	if tagDateSource != connectors.TagDateDefault && tagDateSource != connectors.TagDateCommit {
+		return nil, fmt.Errorf("tag date source %v is not supported by Azure DevOps", tagDateSource)
+	}
+
+	return &Connector{
+		context:      context.Background(),
+		client:       http.DefaultClient,
+		BaseURL:      baseURL,
+		Token:        os.Getenv(AccessTokenEnvVar),
+		Organization: organization,
+		Project:      project,
+		Repo:         repo,
+		ProjectURL: fmt.Sprintf("%s/%s/%s/_git/%s", baseURL,
+			url.PathEscape(organization), url.PathEscape(project), url.PathEscape(repo)),
+		FetchFiles: connectors.FetchMRFiles(ctx),
+	}, nil
+}
+
+// CLIFlags returns the possible CLI flags for this connector
+func CLIFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "azure-url",
+			Usage: "Base URL of Azure DevOps Server or Azure DevOps Services",
+			Value: ServicesURL,
+		},
+		cli.StringFlag{
+			Name:  "azure-organization",
+			Usage: "Organization (Azure DevOps Services) or collection (Azure DevOps Server)",
+		},
+		cli.StringFlag{
+			Name:  "azure-project",
+			Usage: "Project where repository belongs to",
+		},
+		cli.StringFlag{
+			Name:  "azure-repo",
+			Usage: "Name of repository",
+		},
+	}
+}
+
+func init() { // nolint: gochecknoinits
+	connectors.RegisterConnector("azure", "Azure DevOps", New, CLIFlags)
+	connectors.RegisterConnectorDetails("azure", []string{AccessTokenEnvVar}, &Connector{})
+}
@@ -0,0 +1,241 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package azure_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/azure"
+	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+)
+
+const testToken = "testtoken"
+
+// the API prefixes of test project
+const (
+	gitPrefix = "/testorg/testproject/_apis/git/repositories/testrepo"
+	witPrefix = "/testorg/testproject/_apis/wit"
+)
+
+// recorded returns the path of recorded API response
+func recorded(name string) string {
+	return filepath.Join("testdata", name+".json")
+}
+
+// newTestServer returns the fake of REST API, which replays the recorded responses
+// of testrepo from testdata. The file name is derived from the API and path, the lists
+// are recorded per $skip or per continuation token, e.g. the refs are served from
+// git_refs_page1.json and git_refs_page2.json. The continuation token of the next page
+// is set, if the next page is recorded
+func newTestServer() *httptest.Server { // nolint: gocyclo
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, pass, ok := r.BasicAuth(); !ok || pass != testToken {
+			http.Error(w, `{"message":"access denied"}`, http.StatusUnauthorized)
+			return
+		}
+		query := r.URL.Query()
+		if query.Get("api-version") == "" {
+			http.Error(w, `{"message":"api-version is required"}`, http.StatusBadRequest)
+			return
+		}
+
+		var name, resource string
+		switch {
+		case strings.HasPrefix(r.URL.Path, gitPrefix):
+			name, resource = "git", strings.TrimPrefix(r.URL.Path, gitPrefix)
+		case strings.HasPrefix(r.URL.Path, witPrefix):
+			name, resource = "wit", strings.TrimPrefix(r.URL.Path, witPrefix)
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		if resource = strings.Trim(resource, "/"); resource == "" {
+			resource = "repo"
+		}
+		name += "_" + strings.Replace(resource, "/", "_", -1)
+
+		switch {
+		case query.Get("$skip") != "":
+			name += "_skip" + query.Get("$skip")
+		case query.Get("$top") != "":
+			page := 1
+			if token := query.Get("continuationToken"); token != "" {
+				if _, err := fmt.Sscanf(token, "page%d", &page); err != nil {
+					http.Error(w, `{"message":"wrong continuation token"}`, http.StatusBadRequest)
+					return
+				}
+			}
+			if _, err := os.Stat(recorded(fmt.Sprintf("%s_page%d", name, page+1))); err == nil {
+				w.Header().Set("x-ms-continuationtoken", fmt.Sprintf("page%d", page+1))
+			}
+			name = fmt.Sprintf("%s_page%d", name, page)
+		}
+
+		content, err := ioutil.ReadFile(recorded(name))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content) // nolint: errcheck, gosec
+	}))
+}
+
+// newTestConnector returns the connector configured for the test server
+func newTestConnector(url, repo string, flags map[string]string) (*azure.Connector, error) {
+	os.Setenv(azure.AccessTokenEnvVar, testToken) // nolint: errcheck, gosec
+	defer os.Unsetenv(azure.AccessTokenEnvVar)    // nolint: errcheck
+
+	cliFlags := map[string]string{
+		"azure-url":          url,
+		"azure-organization": "testorg",
+		"azure-project":      "testproject",
+		"azure-repo":         repo,
+	}
+	for k, v := range flags {
+		cliFlags[k] = v
+	}
+	conn, err := azure.New(tcli.TestContext(
+		append(azure.CLIFlags(), connectors.CommonCLIFlags()...),
+		cliFlags,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return conn.(*azure.Connector), nil
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		want    *azure.Connector
+		wantErr error
+	}{
+		{
+			name:  "Azure DevOps Server",
+			flags: map[string]string{"azure-url": "https://tfs.example.com/tfs/"},
+			want: &azure.Connector{
+				BaseURL:      "https://tfs.example.com/tfs",
+				Token:        testToken,
+				Organization: "testorg",
+				Project:      "testproject",
+				Repo:         "testrepo",
+				ProjectURL:   "https://tfs.example.com/tfs/testorg/testproject/_git/testrepo",
+			},
+		},
+		{
+			name:    "Missing URL",
+			flags:   map[string]string{"azure-url": ""},
+			wantErr: errors.New("option --azure-url is required"),
+		},
+		{
+			name:    "Missing organization",
+			flags:   map[string]string{"azure-organization": ""},
+			wantErr: errors.New("option --azure-organization is required"),
+		},
+		{
+			name:    "Missing project",
+			flags:   map[string]string{"azure-project": ""},
+			wantErr: errors.New("option --azure-project is required"),
+		},
+		{
+			name:    "Missing repo",
+			flags:   map[string]string{"azure-repo": ""},
+			wantErr: errors.New("option --azure-repo is required"),
+		},
+		{
+			name:    "Unsupported tag date source",
+			flags:   map[string]string{"tag-date-source": "tag"},
+			wantErr: errors.New("tag date source tag is not supported by Azure DevOps"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newTestConnector(azure.ServicesURL, "testrepo", tt.flags)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if c.BaseURL != tt.want.BaseURL || c.Token != tt.want.Token ||
+				c.Organization != tt.want.Organization || c.Project != tt.want.Project ||
+				c.Repo != tt.want.Repo || c.ProjectURL != tt.want.ProjectURL {
+				t.Errorf("New() = %+v, want %+v", c, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnector_RepositoryExists(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		repo    string
+		token   string
+		want    bool
+		wantErr error
+	}{
+		{
+			name: "Existing repository",
+			repo: "testrepo",
+			want: true,
+		},
+		{
+			name: "Missing repository",
+			repo: "missingrepo",
+		},
+		{
+			name:    "Wrong token",
+			repo:    "testrepo",
+			token:   "wrongtoken",
+			wantErr: errors.New("Azure DevOps query 'RepositoryExists' failed: GET /testorg/testproject/_apis/git/repositories/testrepo: 401 Unauthorized"), // nolint: lll
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newTestConnector(srv.URL, tt.repo, nil)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if tt.token != "" {
+				c.Token = tt.token
+			}
+
+			got, err := c.RepositoryExists()
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("RepositoryExists() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RepositoryExists() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
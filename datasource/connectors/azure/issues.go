@@ -0,0 +1,198 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// WorkItemsPerRequest defines how many work items are fetched per request,
+// the API allows up to 200
+var WorkItemsPerRequest = 200 // nolint: gochecknoglobals
+
+// closeReasons maps the states of closed work items to the close reasons,
+// work items in other states are still open
+var closeReasons = map[string]string{ // nolint: gochecknoglobals
+	"Closed":   data.CloseReasonCompleted,
+	"Done":     data.CloseReasonCompleted,
+	"Resolved": data.CloseReasonCompleted,
+	"Removed":  data.CloseReasonNotPlanned,
+}
+
+// workItemFields are the fields of work items, which are needed for the issues
+const workItemFields = "System.Title,System.State,System.WorkItemType,System.Tags," +
+	"System.ChangedDate,Microsoft.VSTS.Common.ClosedDate"
+
+// Issues returns the closed work items, which are linked
+// to the completed pull requests, via channels.
+// Returns possible errors via given cerr channel
+// cissues returns issues
+// cissuescounter returns the channel, which ticks when an issue is proceeded
+// cmaxissues returns the max available amount of issues
+func (c *Connector) Issues(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	cissues <-chan data.Issue,
+	cissuescounter <-chan bool,
+	cmaxissues <-chan int,
+) {
+	issues := make(chan data.Issue)
+	maxissues := make(chan int, 1)
+	issuescounter := make(chan bool, 100)
+
+	go func() {
+		defer close(issues)
+		defer close(issuescounter)
+		defer close(maxissues)
+
+		ids, err := c.linkedWorkItemIDs(ctx)
+		if err != nil {
+			helpers.NonBlockingErrSend(ctx, cerr, formatErrorCode("Issues", err))
+			return
+		}
+		helpers.NonBlockingMaxSend(ctx, maxissues, len(ids))
+
+		for len(ids) > 0 {
+			n := WorkItemsPerRequest
+			if n > len(ids) {
+				n = len(ids)
+			}
+			items, err := c.workItems(ctx, ids[:n])
+			if err != nil {
+				helpers.NonBlockingErrSend(ctx, cerr, formatErrorCode("Issues", err))
+				return
+			}
+			ids = ids[n:]
+
+			for _, wi := range items {
+				issuescounter <- true
+				// deleted work items are omitted by the API
+				if wi == nil {
+					continue
+				}
+				issue, closed := c.issue(*wi)
+				if !closed {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case issues <- issue:
+				}
+			}
+		}
+	}()
+
+	return issues, issuescounter, maxissues
+}
+
+// linkedWorkItemIDs returns the sorted IDs of all work items,
+// which are linked to the completed PRs
+func (c *Connector) linkedWorkItemIDs(ctx context.Context) ([]int, error) {
+	seen := map[int]bool{}
+	var ret []int
+	cursor := ""
+	for {
+		p, prs, err := c.listPRs(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range prs {
+			ids, err := c.workItemIDs(ctx, pr.PullRequestID)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range ids {
+				if !seen[id] {
+					seen[id] = true
+					ret = append(ret, id)
+				}
+			}
+		}
+		if p.Next == "" {
+			sort.Ints(ret)
+			return ret, nil
+		}
+		cursor = p.Next
+	}
+}
+
+// workItems returns the work items with given IDs,
+// nil is returned for the deleted work items
+func (c *Connector) workItems(ctx context.Context, ids []int) ([]*apiWorkItem, error) {
+	var sids []string
+	for _, id := range ids {
+		sids = append(sids, strconv.Itoa(id))
+	}
+	query := url.Values{
+		"ids":         {strings.Join(sids, ",")},
+		"fields":      {workItemFields},
+		"errorPolicy": {"omit"},
+	}
+
+	var items []*apiWorkItem
+	if _, err := c.list(ctx, c.projectPath("/wit/workitems"), query, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// issue converts the work item to our data structure, the work item type
+// and tags are used as labels. Returns false if the work item is not closed
+func (c *Connector) issue(wi apiWorkItem) (data.Issue, bool) {
+	reason, closed := closeReasons[wi.Fields.State]
+	if !closed {
+		return data.Issue{}, false
+	}
+
+	ret := data.Issue{
+		ID:          wi.ID,
+		Name:        wi.Fields.Title,
+		URL:         c.workItemURL(wi.ID),
+		ClosedDate:  wi.Fields.ChangedDate.UTC(),
+		CloseReason: reason,
+	}
+	// the closed date is not available in all process templates
+	if wi.Fields.ClosedDate != nil {
+		ret.ClosedDate = wi.Fields.ClosedDate.UTC()
+	}
+	if wi.Fields.WorkItemType != "" {
+		ret.Labels = append(ret.Labels, wi.Fields.WorkItemType)
+	}
+	for _, t := range strings.Split(wi.Fields.Tags, ";") {
+		if t = strings.TrimSpace(t); t != "" {
+			ret.Labels = append(ret.Labels, t)
+		}
+	}
+	return ret, true
+}
+
+// workItemURL returns the web URL of given work item
+func (c *Connector) workItemURL(id int) string {
+	return fmt.Sprintf("%s/%s/%s/_workitems/edit/%d", c.BaseURL,
+		url.PathEscape(c.Organization), url.PathEscape(c.Project), id)
+}
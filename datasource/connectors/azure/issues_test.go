@@ -0,0 +1,87 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package azure_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/azure"
+	"github.com/artem-sidorenko/chagen/internal/testing/helpers"
+)
+
+func TestConnector_Issues(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	azure.PRsPerPage = 2
+	c, err := newTestConnector(srv.URL, "testrepo", nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	cerr := make(chan error, 1)
+
+	cgot, cissuescounter, cmaxissues := c.Issues(context.Background(), cerr)
+
+	var got data.Issues
+	for i := range cgot {
+		got = append(got, i)
+	}
+	processed := 0
+	for range cissuescounter {
+		processed++
+	}
+	gotmaxissues := helpers.GetChannelValuesInt(cmaxissues)
+
+	select {
+	case err := <-cerr:
+		t.Fatalf("Connector.Issues() error = %v", err)
+	default:
+	}
+
+	// the active work item 13 and deleted work item 14 are skipped
+	want := data.Issues{
+		{
+			ID:          12,
+			Name:        "Crash on empty config",
+			URL:         srv.URL + "/testorg/testproject/_workitems/edit/12",
+			ClosedDate:  time.Date(2019, 3, 18, 7, 31, 0, 0, time.UTC),
+			Labels:      []string{"Bug", "config", "crash"},
+			CloseReason: data.CloseReasonCompleted,
+		},
+		{
+			ID:          15,
+			Name:        "Support XML config",
+			URL:         srv.URL + "/testorg/testproject/_workitems/edit/15",
+			ClosedDate:  time.Date(2019, 3, 15, 11, 0, 0, 0, time.UTC),
+			Labels:      []string{"Task"},
+			CloseReason: data.CloseReasonNotPlanned,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Connector.Issues() = %+v, want %+v", got, want)
+	}
+	if processed != 4 {
+		t.Errorf("Connector.Issues() processed = %v, want %v", processed, 4)
+	}
+	if !reflect.DeepEqual(gotmaxissues, []int{4}) {
+		t.Errorf("Connector.Issues() maxissues = %v, want %v", gotmaxissues, []int{4})
+	}
+}
@@ -0,0 +1,218 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// PRsPerPage defined how many PRs are fetched per page
+var PRsPerPage = 50 // nolint: gochecknoglobals
+
+// PRFilesPerPage defined how many changed files of a PR are fetched per page
+var PRFilesPerPage = 100 // nolint: gochecknoglobals
+
+// MRs returns the completed pull requests via channels.
+// Returns possible errors via given cerr channel
+// cmrs returns PRs
+// cmrscounter returns the channel, which ticks when a PR is proceeded
+// cmaxmrs returns the max available amount of PRs
+func (c *Connector) MRs(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	cmrs <-chan data.MR,
+	cmrscounter <-chan bool,
+	cmaxmrs <-chan int,
+) {
+	mrs := make(chan data.MR)
+	maxmrs := make(chan int, 1)
+	mrscounter := make(chan bool, 100)
+
+	go func() {
+		defer close(mrs)
+		defer close(mrscounter)
+		defer close(maxmrs)
+
+		helpers.FetchCursorPages(ctx, cerr, maxmrs,
+			func(ctx context.Context, cursor string) (helpers.CursorPage, error) {
+				p, prs, err := c.listPRs(ctx, cursor)
+				if err != nil {
+					return p, formatErrorCode("MRs", err)
+				}
+
+				for _, pr := range prs {
+					mr, err := c.mr(ctx, pr)
+					if err != nil {
+						return p, formatErrorCode("MRs", err)
+					}
+					select {
+					case <-ctx.Done():
+						return p, ctx.Err()
+					case mrs <- mr:
+						mrscounter <- true
+					}
+				}
+				return p, nil
+			})
+	}()
+
+	return mrs, mrscounter, maxmrs
+}
+
+// listPRs returns the page of completed PRs at given cursor. The PR list
+// is paged via $skip, the cursor is the amount of PRs to skip
+func (c *Connector) listPRs(
+	ctx context.Context,
+	cursor string,
+) (helpers.CursorPage, []apiPullRequest, error) {
+	skip := 0
+	if cursor != "" {
+		var err error
+		if skip, err = strconv.Atoi(cursor); err != nil {
+			return helpers.CursorPage{}, nil, err
+		}
+	}
+	query := url.Values{
+		"searchCriteria.status": {"completed"},
+		"$top":                  {strconv.Itoa(PRsPerPage)},
+		"$skip":                 {strconv.Itoa(skip)},
+	}
+
+	var prs []apiPullRequest
+	if _, err := c.list(ctx, c.repoPath("/pullrequests"), query, &prs); err != nil {
+		return helpers.CursorPage{}, nil, err
+	}
+
+	p := helpers.CursorPage{Items: len(prs)}
+	// a full page means, there might be further PRs
+	if len(prs) == PRsPerPage {
+		p.Next = strconv.Itoa(skip + len(prs))
+	}
+	return p, prs, nil
+}
+
+// mr converts the Azure DevOps pull request to our data structure,
+// the linked work items are used as closed issues
+func (c *Connector) mr(ctx context.Context, pr apiPullRequest) (data.MR, error) {
+	closes, err := c.workItemIDs(ctx, pr.PullRequestID)
+	if err != nil {
+		return data.MR{}, err
+	}
+
+	ret := data.MR{
+		ID:   pr.PullRequestID,
+		Name: pr.Title,
+		URL:  fmt.Sprintf("%s/pullrequest/%d", c.ProjectURL, pr.PullRequestID),
+		// Azure DevOps has no profile pages, the completed PRs of author are linked instead
+		Author: pr.CreatedBy.DisplayName,
+		AuthorURL: fmt.Sprintf("%s/pullrequests?_a=completed&createdBy=%s",
+			c.ProjectURL, url.QueryEscape(pr.CreatedBy.ID)),
+		MergedDate:  pr.ClosedDate.UTC(),
+		Description: pr.Description,
+		Closes:      closes,
+		IssueKeys: data.ParseIssueKeys(pr.Title, pr.Description,
+			strings.TrimPrefix(pr.SourceRefName, "refs/heads/")),
+	}
+	for _, l := range pr.Labels {
+		if l.Active {
+			ret.Labels = append(ret.Labels, l.Name)
+		}
+	}
+	if pr.LastMergeCommit != nil {
+		ret.MergeCommit = pr.LastMergeCommit.CommitID
+	}
+
+	if c.FetchFiles {
+		files, err := c.prFiles(ctx, pr.PullRequestID)
+		if err != nil {
+			return data.MR{}, err
+		}
+		ret.Files = files
+	}
+	return ret, nil
+}
+
+// workItemIDs returns the sorted IDs of work items linked to the given PR
+func (c *Connector) workItemIDs(ctx context.Context, id int) ([]int, error) {
+	var refs []apiResourceRef
+	path := c.repoPath(fmt.Sprintf("/pullRequests/%d/workitems", id))
+	if _, err := c.list(ctx, path, nil, &refs); err != nil {
+		return nil, err
+	}
+
+	var ret []int
+	for _, r := range refs {
+		wid, err := strconv.Atoi(r.ID)
+		if err != nil {
+			return nil, fmt.Errorf("wrong ID of work item: %v", r.ID)
+		}
+		ret = append(ret, wid)
+	}
+	sort.Ints(ret)
+	return ret, nil
+}
+
+// prFiles returns the files changed by the last iteration of given PR,
+// the changes of last iteration cover all previous ones
+func (c *Connector) prFiles(ctx context.Context, id int) ([]string, error) {
+	var iterations []apiIteration
+	path := c.repoPath(fmt.Sprintf("/pullRequests/%d/iterations", id))
+	if _, err := c.list(ctx, path, nil, &iterations); err != nil {
+		return nil, err
+	}
+	last := 0
+	for _, it := range iterations {
+		if it.ID > last {
+			last = it.ID
+		}
+	}
+	if last == 0 {
+		return nil, nil
+	}
+
+	var ret []string
+	path = c.repoPath(fmt.Sprintf("/pullRequests/%d/iterations/%d/changes", id, last))
+	for skip := 0; ; {
+		query := url.Values{
+			"$top":  {strconv.Itoa(PRFilesPerPage)},
+			"$skip": {strconv.Itoa(skip)},
+		}
+		var changes apiIterationChanges
+		if _, err := c.get(ctx, path, query, &changes); err != nil {
+			return nil, err
+		}
+		for _, ch := range changes.ChangeEntries {
+			if !ch.Item.IsFolder {
+				ret = append(ret, strings.TrimPrefix(ch.Item.Path, "/"))
+			}
+		}
+		if changes.NextSkip <= skip {
+			return ret, nil
+		}
+		skip = changes.NextSkip
+	}
+}
@@ -0,0 +1,125 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package azure_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/azure"
+	"github.com/artem-sidorenko/chagen/internal/testing/helpers"
+)
+
+// wantMRs returns the expected PRs of test server
+func wantMRs(url string, files bool) data.MRs {
+	project := url + "/testorg/testproject/_git/testrepo"
+	mrs := data.MRs{
+		{
+			ID:          21,
+			Name:        "Fix crash on empty config",
+			URL:         project + "/pullrequest/21",
+			Author:      "Test Contributor",
+			AuthorURL:   project + "/pullrequests?_a=completed&createdBy=5f1d5a3c-7b2e-4c8d-9e1f-2a3b4c5d6e7f",
+			MergedDate:  time.Date(2019, 3, 18, 7, 30, 0, 0, time.UTC),
+			Labels:      []string{"bugfix"},
+			Description: "Handles the empty config, see PROJ-4",
+			Closes:      []int{12},
+			MergeCommit: "9c1e3a5b7d9f1b3d5f7a9c1e3b5d7f9a1c3e5b7d",
+			IssueKeys:   []string{"PROJ-4"},
+		},
+		{
+			ID:          20,
+			Name:        "Add login page",
+			URL:         project + "/pullrequest/20",
+			Author:      "Jane Doe",
+			AuthorURL:   project + "/pullrequests?_a=completed&createdBy=0a9b8c7d-6e5f-4a3b-2c1d-0e9f8a7b6c5d",
+			MergedDate:  time.Date(2019, 3, 16, 12, 0, 0, 0, time.UTC),
+			Closes:      []int{12, 13},
+			MergeCommit: "2b4d6f8a0c1e3b5d7f9a1c2e4b6d8f0a3c5e7b9d",
+			IssueKeys:   []string{"PROJ-42"},
+		},
+		{
+			ID:         19,
+			Name:       "Update documentation",
+			URL:        project + "/pullrequest/19",
+			Author:     "Jane Doe",
+			AuthorURL:  project + "/pullrequests?_a=completed&createdBy=0a9b8c7d-6e5f-4a3b-2c1d-0e9f8a7b6c5d",
+			MergedDate: time.Date(2019, 3, 15, 10, 0, 0, 0, time.UTC),
+			Closes:     []int{14, 15},
+		},
+	}
+	if files {
+		mrs[0].Files = []string{"config/config.go", "config/config_test.go"}
+		mrs[1].Files = []string{"web/login.go"}
+	}
+	return mrs
+}
+
+func TestConnector_MRs(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name  string
+		flags map[string]string
+		want  data.MRs
+	}{
+		{
+			name: "Completed PRs with work items",
+			want: wantMRs(srv.URL, false),
+		},
+		{
+			name:  "Completed PRs with changed files",
+			flags: map[string]string{"path": "config"},
+			want:  wantMRs(srv.URL, true),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			azure.PRsPerPage = 2
+			c, err := newTestConnector(srv.URL, "testrepo", tt.flags)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			cerr := make(chan error, 1)
+
+			cgot, _, cmaxmrs := c.MRs(context.Background(), cerr)
+
+			var got data.MRs
+			for m := range cgot {
+				got = append(got, m)
+			}
+			gotmaxmrs := helpers.GetChannelValuesInt(cmaxmrs)
+
+			select {
+			case err := <-cerr:
+				t.Fatalf("Connector.MRs() error = %v", err)
+			default:
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Connector.MRs() = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotmaxmrs, []int{3}) {
+				t.Errorf("Connector.MRs() maxmrs = %v, want %v", gotmaxmrs, []int{3})
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// TagsPerPage defined how many tags are fetched per page
+var TagsPerPage = 50 // nolint: gochecknoglobals
+
+// Tags returns the tags via channels.
+// Returns possible errors via given cerr channel
+// ctags returns tags
+// ctagscounter returns the channel, which ticks when a tag is proceeded
+// cmaxtags returns the max available amount of tags
+func (c *Connector) Tags(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	ctags <-chan data.Tag,
+	ctagscounter <-chan bool,
+	cmaxtags <-chan int,
+) {
+	tags := make(chan data.Tag)
+	maxtags := make(chan int, 1)
+	tagscounter := make(chan bool, 100)
+
+	go func() {
+		defer close(tags)
+		defer close(tagscounter)
+		defer close(maxtags)
+
+		helpers.FetchCursorPages(ctx, cerr, maxtags,
+			func(ctx context.Context, cursor string) (helpers.CursorPage, error) {
+				query := url.Values{
+					"filter":   {"tags/"},
+					"peelTags": {"true"},
+					"$top":     {strconv.Itoa(TagsPerPage)},
+				}
+				if cursor != "" {
+					query.Set("continuationToken", cursor)
+				}
+
+				var refs []apiRef
+				next, err := c.list(ctx, c.repoPath("/refs"), query, &refs)
+				if err != nil {
+					return helpers.CursorPage{}, formatErrorCode("Tags", err)
+				}
+
+				for _, r := range refs {
+					tag, err := c.tag(ctx, r)
+					if err != nil {
+						return helpers.CursorPage{}, formatErrorCode("Tags", err)
+					}
+					select {
+					case <-ctx.Done():
+						return helpers.CursorPage{}, ctx.Err()
+					case tags <- tag:
+						tagscounter <- true
+					}
+				}
+				return helpers.CursorPage{Items: len(refs), Next: next}, nil
+			})
+	}()
+
+	return tags, tagscounter, maxtags
+}
+
+// tag converts the ref to our data structure, the refs do not
+// provide any dates, so the tagged commit is fetched too
+func (c *Connector) tag(ctx context.Context, r apiRef) (data.Tag, error) {
+	sha := r.ObjectID
+	if r.PeeledObjectID != "" {
+		sha = r.PeeledObjectID
+	}
+
+	var commit apiCommit
+	if _, err := c.get(ctx, c.repoPath("/commits/"+url.PathEscape(sha)), nil, &commit); err != nil {
+		return data.Tag{}, err
+	}
+
+	name := strings.TrimPrefix(r.Name, "refs/tags/")
+	return data.Tag{
+		Name:   name,
+		Commit: sha,
+		Date:   commit.Committer.Date.UTC(),
+		URL:    c.tagURL(name),
+	}, nil
+}
@@ -0,0 +1,41 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package azure_test
+
+import (
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/azure"
+)
+
+func TestConnector_URLs(t *testing.T) {
+	c, err := newTestConnector(azure.ServicesURL, "testrepo", nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	project := "https://dev.azure.com/testorg/testproject/_git/testrepo"
+
+	if got, _ := c.GetNewTagURL("v1.0.0"); got != project+"?version=GTv1.0.0" {
+		t.Errorf("GetNewTagURL() = %v", got)
+	}
+
+	got, _ := c.CompareURL("v0.1.0", "v1.0.0")
+	if got != project+"/branchCompare?baseVersion=GTv0.1.0&targetVersion=GTv1.0.0" {
+		t.Errorf("CompareURL() = %v", got)
+	}
+}
@@ -0,0 +1,267 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+)
+
+// the API structures, only the needed fields are described.
+// The cloud* structures belong to Bitbucket Cloud API 2.0,
+// the server* ones to Bitbucket Server REST API 1.0
+
+type apiRepository struct {
+	HasIssues bool `json:"has_issues"` // provided only by Bitbucket Cloud
+}
+
+// apiPage covers the paging information of both APIs
+type apiPage struct {
+	Values json.RawMessage `json:"values"`
+	// total amount in Bitbucket Cloud, page size in Bitbucket Server
+	Size          int    `json:"size"`
+	Next          string `json:"next"`
+	IsLastPage    bool   `json:"isLastPage"`
+	NextPageStart int    `json:"nextPageStart"`
+}
+
+type cloudLinks struct {
+	HTML struct {
+		Href string `json:"href"`
+	} `json:"html"`
+}
+
+type cloudUser struct {
+	Nickname    string     `json:"nickname"`
+	DisplayName string     `json:"display_name"`
+	Links       cloudLinks `json:"links"`
+}
+
+type cloudTag struct {
+	Name   string `json:"name"`
+	Target struct {
+		Hash string    `json:"hash"`
+		Date time.Time `json:"date"`
+	} `json:"target"`
+}
+
+type cloudPullRequest struct {
+	ID          int       `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Author      cloudUser `json:"author"`
+	UpdatedOn   time.Time `json:"updated_on"`
+	MergeCommit *struct {
+		Hash string `json:"hash"`
+	} `json:"merge_commit"`
+	Source struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+	} `json:"source"`
+	Links cloudLinks `json:"links"`
+}
+
+type cloudIssue struct {
+	ID        int        `json:"id"`
+	Title     string     `json:"title"`
+	State     string     `json:"state"`
+	Kind      string     `json:"kind"`
+	UpdatedOn time.Time  `json:"updated_on"`
+	Links     cloudLinks `json:"links"`
+}
+
+type cloudDiffStat struct {
+	Old *struct {
+		Path string `json:"path"`
+	} `json:"old"`
+	New *struct {
+		Path string `json:"path"`
+	} `json:"new"`
+}
+
+type serverLinks struct {
+	Self []struct {
+		Href string `json:"href"`
+	} `json:"self"`
+}
+
+type serverUser struct {
+	Name  string      `json:"name"`
+	Slug  string      `json:"slug"`
+	Links serverLinks `json:"links"`
+}
+
+type serverTag struct {
+	DisplayID    string `json:"displayId"`
+	LatestCommit string `json:"latestCommit"`
+}
+
+type cloudCommit struct {
+	Date time.Time `json:"date"`
+}
+
+type serverCommit struct {
+	CommitterTimestamp int64 `json:"committerTimestamp"`
+}
+
+type serverPullRequest struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ClosedDate  int64  `json:"closedDate"`
+	Author      struct {
+		User serverUser `json:"user"`
+	} `json:"author"`
+	FromRef struct {
+		DisplayID string `json:"displayId"`
+	} `json:"fromRef"`
+	Properties struct {
+		MergeCommit struct {
+			ID string `json:"id"`
+		} `json:"mergeCommit"`
+	} `json:"properties"`
+	Links serverLinks `json:"links"`
+}
+
+type serverChange struct {
+	Path struct {
+		ToString string `json:"toString"`
+	} `json:"path"`
+}
+
+// href returns the first link of given links
+func (l serverLinks) href() string {
+	if len(l.Self) == 0 {
+		return ""
+	}
+	return l.Self[0].Href
+}
+
+// repoPath returns the API path of given repository resource
+func (c *Connector) repoPath(resource string) string {
+	owner, repo := url.PathEscape(c.Owner), url.PathEscape(c.Repo)
+	if c.Server {
+		return fmt.Sprintf("/projects/%s/repos/%s%s", owner, repo, resource)
+	}
+	return fmt.Sprintf("/repositories/%s/%s%s", owner, repo, resource)
+}
+
+// get requests the given API URL and decodes the JSON response to v.
+// The response is returned for the inspection of status code
+func (c *Connector) get(ctx context.Context, u string, v interface{}) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	if c.User != "" {
+		req.SetBasicAuth(c.User, c.Token)
+	} else if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, fmt.Errorf("GET %v: %v", strings.TrimPrefix(req.URL.Path, c.apiPath()), resp.Status)
+	}
+	return resp, json.NewDecoder(resp.Body).Decode(v)
+}
+
+// apiPath returns the path part of API URL
+func (c *Connector) apiPath() string {
+	u, err := url.Parse(c.APIURL)
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
+
+// list requests the page of API list at given cursor and decodes its values to v.
+// Bitbucket Cloud provides the URL of next page as cursor,
+// Bitbucket Server the start index of next page
+func (c *Connector) list(
+	ctx context.Context,
+	path string,
+	cursor string,
+	perPage int,
+	query url.Values,
+	v interface{},
+) (helpers.CursorPage, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+
+	u := c.APIURL + path
+	switch {
+	case c.Server:
+		query.Set("limit", strconv.Itoa(perPage))
+		if cursor != "" {
+			query.Set("start", cursor)
+		}
+		u += "?" + query.Encode()
+	case cursor != "":
+		// the credentials should not be sent anywhere else
+		if !strings.HasPrefix(cursor, c.APIURL+"/") {
+			return helpers.CursorPage{}, fmt.Errorf("unexpected URL of next page: %v", cursor)
+		}
+		u = cursor // the URL of next page contains all query parameters
+	default:
+		query.Set("pagelen", strconv.Itoa(perPage))
+		u += "?" + query.Encode()
+	}
+
+	var page apiPage
+	if _, err := c.get(ctx, u, &page); err != nil {
+		return helpers.CursorPage{}, err
+	}
+	if err := json.Unmarshal(page.Values, v); err != nil {
+		return helpers.CursorPage{}, err
+	}
+
+	if c.Server {
+		if page.IsLastPage {
+			return helpers.CursorPage{}, nil
+		}
+		return helpers.CursorPage{Next: strconv.Itoa(page.NextPageStart)}, nil
+	}
+	return helpers.CursorPage{Next: page.Next, Total: page.Size}, nil
+}
+
+// copyValues returns a copy of given query values,
+// so they can be modified for every page
+func copyValues(v url.Values) url.Values {
+	ret := url.Values{}
+	for k, vs := range v {
+		ret[k] = append([]string(nil), vs...)
+	}
+	return ret
+}
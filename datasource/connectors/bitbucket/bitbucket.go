@@ -0,0 +1,149 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package bitbucket implements the connector for Bitbucket Cloud
+// and Bitbucket Server/Data Center
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+
+	"github.com/urfave/cli"
+)
+
+// AccessTokenEnvVar contains the name of environment variable
+// which sets the authentication access token
+const AccessTokenEnvVar = "CHAGEN_BITBUCKET_TOKEN" // nolint: gosec
+
+// UserEnvVar contains the name of environment variable which sets the user name.
+// If it is set, the access token is used as app password via basic authentication
+const UserEnvVar = "CHAGEN_BITBUCKET_USER"
+
+const (
+	// CloudURL is the URL of Bitbucket Cloud, all other
+	// URLs are handled as Bitbucket Server/Data Center
+	CloudURL = "https://bitbucket.org"
+	// cloudAPIURL is the URL of Bitbucket Cloud API 2.0
+	cloudAPIURL = "https://api.bitbucket.org/2.0"
+	// serverAPIPath is the path of Bitbucket Server REST API 1.0
+	serverAPIPath = "/rest/api/1.0"
+)
+
+// Connector implements the Bitbucket connector
+type Connector struct {
+	context    context.Context
+	client     *http.Client
+	BaseURL    string
+	APIURL     string
+	Server     bool
+	User       string
+	Token      string
+	Owner      string
+	Repo       string
+	ProjectURL string
+	FetchFiles bool
+}
+
+// RepositoryExists checks if referenced repository is present
+func (c *Connector) RepositoryExists() (bool, error) {
+	var repo apiRepository
+	resp, err := c.get(c.context, c.APIURL+c.repoPath(""), &repo)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound { // not found isn't an error
+			return false, nil
+		}
+		return false, formatErrorCode("RepositoryExists", err)
+	}
+	return true, nil
+}
+
+// New returns a new initialized Connector or error if any
+func New(ctx *cli.Context) (connectors.Connector, error) {
+	baseURL := strings.TrimSuffix(ctx.String("bitbucket-url"), "/")
+	if baseURL == "" {
+		return nil, errors.New("option --bitbucket-url is required")
+	}
+	owner := ctx.String("bitbucket-owner")
+	if owner == "" {
+		return nil, errors.New("option --bitbucket-owner is required")
+	}
+	repo := ctx.String("bitbucket-repo")
+	if repo == "" {
+		return nil, errors.New("option --bitbucket-repo is required")
+	}
+	tagDateSource, err := connectors.GetTagDateSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Bitbucket APIs provide only the commit dates of tags
+	if tagDateSource != connectors.TagDateDefault && tagDateSource != connectors.TagDateCommit {
+		return nil, fmt.Errorf("tag date source %v is not supported by Bitbucket", tagDateSource)
+	}
+
+	c := &Connector{
+		context:    context.Background(),
+		client:     http.DefaultClient,
+		BaseURL:    baseURL,
+		User:       os.Getenv(UserEnvVar),
+		Token:      os.Getenv(AccessTokenEnvVar),
+		Owner:      owner,
+		Repo:       repo,
+		FetchFiles: connectors.FetchMRFiles(ctx),
+	}
+	if baseURL == CloudURL {
+		c.APIURL = cloudAPIURL
+		c.ProjectURL = fmt.Sprintf("%s/%s/%s", baseURL, owner, repo)
+	} else {
+		c.Server = true
+		c.APIURL = baseURL + serverAPIPath
+		c.ProjectURL = fmt.Sprintf("%s/projects/%s/repos/%s",
+			baseURL, url.PathEscape(owner), url.PathEscape(repo))
+	}
+	return c, nil
+}
+
+// CLIFlags returns the possible CLI flags for this connector
+func CLIFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "bitbucket-url",
+			Usage: "Base URL of Bitbucket Server/Data Center instance or Bitbucket Cloud",
+			Value: CloudURL,
+		},
+		cli.StringFlag{
+			Name:  "bitbucket-owner",
+			Usage: "Workspace (Cloud) or project key (Server) where repository belongs to",
+		},
+		cli.StringFlag{
+			Name:  "bitbucket-repo",
+			Usage: "Name of repository",
+		},
+	}
+}
+
+func init() { // nolint: gochecknoinits
+	connectors.RegisterConnector("bitbucket", "Bitbucket", New, CLIFlags)
+	connectors.RegisterConnectorDetails("bitbucket",
+		[]string{AccessTokenEnvVar, UserEnvVar}, &Connector{})
+}
@@ -0,0 +1,279 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package bitbucket_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/bitbucket"
+	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+)
+
+const testToken = "testtoken"
+
+// the API prefixes of test repository
+const (
+	cloudPrefix  = "/2.0/repositories/testowner/testrepo"
+	serverPrefix = "/rest/api/1.0/projects/TEST/repos/testrepo"
+)
+
+// recorded returns the path of recorded API response
+func recorded(name string) string {
+	return filepath.Join("testdata", name+".json")
+}
+
+// newTestServer returns the server, which replays the recorded API responses of
+// testrepo from testdata for both APIs. The file name is derived from the API and path,
+// the lists are recorded per page (Bitbucket Cloud) or start index (Bitbucket Server),
+// e.g. /2.0/repositories/testowner/testrepo/refs/tags?page=2 is served from
+// cloud_refs_tags_page2.json. The next link of Bitbucket Cloud is set,
+// if further pages are recorded
+func newTestServer() *httptest.Server { // nolint: gocyclo
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			http.Error(w, `{"error":{"message":"token is required"}}`, http.StatusUnauthorized)
+			return
+		}
+
+		var name, resource string
+		switch {
+		case strings.HasPrefix(r.URL.Path, cloudPrefix):
+			name, resource = "cloud", strings.TrimPrefix(r.URL.Path, cloudPrefix)
+		case strings.HasPrefix(r.URL.Path, serverPrefix):
+			name, resource = "server", strings.TrimPrefix(r.URL.Path, serverPrefix)
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		if resource = strings.Trim(resource, "/"); resource == "" {
+			resource = "repo"
+		}
+		name += "_" + strings.Replace(resource, "/", "_", -1)
+
+		query := r.URL.Query()
+		page := 0
+		switch {
+		case query.Get("limit") != "":
+			start := query.Get("start")
+			if start == "" {
+				start = "0"
+			}
+			name += "_start" + start
+		case query.Get("pagelen") != "" || query.Get("page") != "":
+			page = 1
+			if p, err := strconv.Atoi(query.Get("page")); err == nil {
+				page = p
+			}
+			name = fmt.Sprintf("%s_page%d", name, page)
+		}
+
+		content, err := ioutil.ReadFile(recorded(name))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		nextPage := strings.TrimSuffix(name, strconv.Itoa(page)) + strconv.Itoa(page+1)
+		if _, err := os.Stat(recorded(nextPage)); page > 0 && err == nil {
+			var p map[string]interface{}
+			if err := json.Unmarshal(content, &p); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			p["next"] = fmt.Sprintf("http://%s%s?page=%d", r.Host, r.URL.Path, page+1)
+			content, _ = json.Marshal(p) // nolint: errcheck
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content) // nolint: errcheck, gosec
+	}))
+}
+
+// newTestConnector returns the connector configured for the test server,
+// Bitbucket Server is used if server is set, Bitbucket Cloud otherwise
+func newTestConnector(
+	srv *httptest.Server,
+	server bool,
+	repo string,
+	flags map[string]string,
+) (*bitbucket.Connector, error) {
+	os.Setenv(bitbucket.AccessTokenEnvVar, testToken) // nolint: errcheck, gosec
+	defer os.Unsetenv(bitbucket.AccessTokenEnvVar)    // nolint: errcheck
+
+	cliFlags := map[string]string{
+		"bitbucket-owner": "testowner",
+		"bitbucket-repo":  repo,
+	}
+	if server {
+		cliFlags["bitbucket-url"] = srv.URL
+		cliFlags["bitbucket-owner"] = "TEST"
+	}
+	for k, v := range flags {
+		cliFlags[k] = v
+	}
+	conn, err := bitbucket.New(tcli.TestContext(
+		append(bitbucket.CLIFlags(), connectors.CommonCLIFlags()...),
+		cliFlags,
+	))
+	if err != nil {
+		return nil, err
+	}
+	c := conn.(*bitbucket.Connector)
+	if !server && srv != nil {
+		c.APIURL = srv.URL + "/2.0"
+	}
+	return c, nil
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		want    *bitbucket.Connector
+		wantErr error
+	}{
+		{
+			name: "Bitbucket Cloud",
+			want: &bitbucket.Connector{
+				BaseURL:    "https://bitbucket.org",
+				APIURL:     "https://api.bitbucket.org/2.0",
+				Token:      testToken,
+				Owner:      "testowner",
+				Repo:       "testrepo",
+				ProjectURL: "https://bitbucket.org/testowner/testrepo",
+			},
+		},
+		{
+			name: "Bitbucket Server",
+			flags: map[string]string{
+				"bitbucket-url":   "https://bitbucket.example.com/",
+				"bitbucket-owner": "TEST",
+			},
+			want: &bitbucket.Connector{
+				BaseURL:    "https://bitbucket.example.com",
+				APIURL:     "https://bitbucket.example.com/rest/api/1.0",
+				Server:     true,
+				Token:      testToken,
+				Owner:      "TEST",
+				Repo:       "testrepo",
+				ProjectURL: "https://bitbucket.example.com/projects/TEST/repos/testrepo",
+			},
+		},
+		{
+			name:    "Missing URL",
+			flags:   map[string]string{"bitbucket-url": ""},
+			wantErr: errors.New("option --bitbucket-url is required"),
+		},
+		{
+			name:    "Missing owner",
+			flags:   map[string]string{"bitbucket-owner": ""},
+			wantErr: errors.New("option --bitbucket-owner is required"),
+		},
+		{
+			name:    "Missing repo",
+			flags:   map[string]string{"bitbucket-repo": ""},
+			wantErr: errors.New("option --bitbucket-repo is required"),
+		},
+		{
+			name:    "Unsupported tag date source",
+			flags:   map[string]string{"tag-date-source": "tag"},
+			wantErr: errors.New("tag date source tag is not supported by Bitbucket"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newTestConnector(nil, false, "testrepo", tt.flags)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if c.BaseURL != tt.want.BaseURL || c.APIURL != tt.want.APIURL ||
+				c.Server != tt.want.Server || c.Token != tt.want.Token ||
+				c.Owner != tt.want.Owner || c.Repo != tt.want.Repo ||
+				c.ProjectURL != tt.want.ProjectURL {
+				t.Errorf("New() = %+v, want %+v", c, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnector_RepositoryExists(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		server  bool
+		repo    string
+		token   string
+		want    bool
+		wantErr error
+	}{
+		{
+			name: "Existing Bitbucket Cloud repository",
+			repo: "testrepo",
+			want: true,
+		},
+		{
+			name:   "Existing Bitbucket Server repository",
+			server: true,
+			repo:   "testrepo",
+			want:   true,
+		},
+		{
+			name: "Missing repository",
+			repo: "missingrepo",
+		},
+		{
+			name:    "Wrong token",
+			repo:    "testrepo",
+			token:   "wrongtoken",
+			wantErr: errors.New("Bitbucket query 'RepositoryExists' failed: GET /repositories/testowner/testrepo: 401 Unauthorized"), // nolint: lll
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newTestConnector(srv, tt.server, tt.repo, nil)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if tt.token != "" {
+				c.Token = tt.token
+			}
+
+			got, err := c.RepositoryExists()
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("RepositoryExists() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RepositoryExists() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// IssuesPerPage defined how many issues are fetched per page
+var IssuesPerPage = 50 // nolint: gochecknoglobals
+
+// closeReasons maps the states of closed Bitbucket Cloud issues to the close reasons
+var closeReasons = map[string]string{ // nolint: gochecknoglobals
+	"resolved":  data.CloseReasonCompleted,
+	"closed":    data.CloseReasonCompleted,
+	"invalid":   data.CloseReasonNotPlanned,
+	"duplicate": data.CloseReasonNotPlanned,
+	"wontfix":   data.CloseReasonNotPlanned,
+}
+
+// closedIssuesQuery filters the closed Bitbucket Cloud issues
+const closedIssuesQuery = `state="resolved" OR state="closed" OR ` +
+	`state="invalid" OR state="duplicate" OR state="wontfix"`
+
+// Issues returns the closed issues via channels.
+// Only Bitbucket Cloud has an issue tracker, if it is not available,
+// no issues are returned. Bitbucket Server is usually used with Jira,
+// the keys of Jira issues are provided by MRs
+// Returns possible errors via given cerr channel
+// cissues returns issues
+// cissuescounter returns the channel, which ticks when an issue is proceeded
+// cmaxissues returns the max available amount of issues
+func (c *Connector) Issues(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	cissues <-chan data.Issue,
+	cissuescounter <-chan bool,
+	cmaxissues <-chan int,
+) {
+	issues := make(chan data.Issue)
+	maxissues := make(chan int, 1)
+	issuescounter := make(chan bool, 100)
+
+	go func() {
+		defer close(issues)
+		defer close(issuescounter)
+		defer close(maxissues)
+
+		if c.Server {
+			helpers.NonBlockingMaxSend(ctx, maxissues, 0)
+			return
+		}
+		var repo apiRepository
+		if _, err := c.get(ctx, c.APIURL+c.repoPath(""), &repo); err != nil {
+			helpers.NonBlockingErrSend(ctx, cerr, formatErrorCode("Issues", err))
+			return
+		}
+		if !repo.HasIssues {
+			helpers.NonBlockingMaxSend(ctx, maxissues, 0)
+			return
+		}
+
+		query := url.Values{"q": {closedIssuesQuery}}
+		helpers.FetchCursorPages(ctx, cerr, maxissues,
+			func(ctx context.Context, cursor string) (helpers.CursorPage, error) {
+				var rissues []cloudIssue
+				p, err := c.list(ctx, c.repoPath("/issues"), cursor, IssuesPerPage, copyValues(query), &rissues)
+				if err != nil {
+					return p, formatErrorCode("Issues", err)
+				}
+
+				for _, i := range rissues {
+					select {
+					case <-ctx.Done():
+						return p, ctx.Err()
+					case issues <- issue(i):
+						issuescounter <- true
+					}
+				}
+				p.Items = len(rissues)
+				return p, nil
+			})
+	}()
+
+	return issues, issuescounter, maxissues
+}
+
+// issue converts the Bitbucket Cloud issue to our data structure.
+// Bitbucket has no labels, the kind of issue (e.g. bug) is used instead.
+// The API does not provide the closing date, closed issues
+// are updated the last time by closing
+func issue(i cloudIssue) data.Issue {
+	ret := data.Issue{
+		ID:          i.ID,
+		Name:        i.Title,
+		URL:         i.Links.HTML.Href,
+		ClosedDate:  i.UpdatedOn.UTC(),
+		CloseReason: closeReasons[i.State],
+	}
+	if i.Kind != "" {
+		ret.Labels = []string{i.Kind}
+	}
+	return ret
+}
@@ -0,0 +1,97 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package bitbucket_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/internal/testing/helpers"
+)
+
+func TestConnector_Issues(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name          string
+		server        bool
+		want          data.Issues
+		wantMaxissues []int
+	}{
+		{
+			name: "Bitbucket Cloud issue tracker",
+			want: data.Issues{
+				{
+					ID:          3,
+					Name:        "Crash on empty config",
+					URL:         "https://bitbucket.org/testowner/testrepo/issues/3/crash-on-empty-config",
+					ClosedDate:  time.Date(2019, 3, 18, 7, 31, 0, 0, time.UTC),
+					Labels:      []string{"bug"},
+					CloseReason: data.CloseReasonCompleted,
+				},
+				{
+					ID:          2,
+					Name:        "Support XML config",
+					URL:         "https://bitbucket.org/testowner/testrepo/issues/2/support-xml-config",
+					ClosedDate:  time.Date(2019, 3, 15, 11, 0, 0, 0, time.UTC),
+					Labels:      []string{"enhancement"},
+					CloseReason: data.CloseReasonNotPlanned,
+				},
+			},
+			wantMaxissues: []int{2},
+		},
+		{
+			name:          "Bitbucket Server without issues",
+			server:        true,
+			wantMaxissues: []int{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newTestConnector(srv, tt.server, "testrepo", nil)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			cerr := make(chan error, 1)
+
+			cgot, _, cmaxissues := c.Issues(context.Background(), cerr)
+
+			var got data.Issues
+			for i := range cgot {
+				got = append(got, i)
+			}
+			gotmaxissues := helpers.GetChannelValuesInt(cmaxissues)
+
+			select {
+			case err := <-cerr:
+				t.Fatalf("Connector.Issues() error = %v", err)
+			default:
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Connector.Issues() = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotmaxissues, tt.wantMaxissues) {
+				t.Errorf("Connector.Issues() maxissues = %v, want %v", gotmaxissues, tt.wantMaxissues)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// PRsPerPage defined how many PRs are fetched per page
+var PRsPerPage = 50 // nolint: gochecknoglobals
+
+// PRFilesPerPage defined how many changed files of a PR are fetched per page
+var PRFilesPerPage = 100 // nolint: gochecknoglobals
+
+// MRs returns the merged pull requests via channels.
+// Returns possible errors via given cerr channel
+// cmrs returns PRs
+// cmrscounter returns the channel, which ticks when a PR is proceeded
+// cmaxmrs returns the max available amount of PRs
+func (c *Connector) MRs(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	cmrs <-chan data.MR,
+	cmrscounter <-chan bool,
+	cmaxmrs <-chan int,
+) {
+	mrs := make(chan data.MR)
+	maxmrs := make(chan int, 1)
+	mrscounter := make(chan bool, 100)
+
+	go func() {
+		defer close(mrs)
+		defer close(mrscounter)
+		defer close(maxmrs)
+
+		helpers.FetchCursorPages(ctx, cerr, maxmrs,
+			func(ctx context.Context, cursor string) (helpers.CursorPage, error) {
+				var (
+					p    helpers.CursorPage
+					rmrs []data.MR
+					err  error
+				)
+				if c.Server {
+					p, rmrs, err = c.serverPRs(ctx, cursor)
+				} else {
+					p, rmrs, err = c.cloudPRs(ctx, cursor)
+				}
+				if err != nil {
+					return p, formatErrorCode("MRs", err)
+				}
+
+				for _, mr := range rmrs {
+					if c.FetchFiles {
+						if mr.Files, err = c.prFiles(ctx, mr.ID); err != nil {
+							return p, formatErrorCode("PRFiles", err)
+						}
+					}
+					select {
+					case <-ctx.Done():
+						return p, ctx.Err()
+					case mrs <- mr:
+						mrscounter <- true
+					}
+				}
+				p.Items = len(rmrs)
+				return p, nil
+			})
+	}()
+
+	return mrs, mrscounter, maxmrs
+}
+
+// cloudPRs returns the page of merged Bitbucket Cloud PRs at given cursor
+func (c *Connector) cloudPRs(
+	ctx context.Context,
+	cursor string,
+) (helpers.CursorPage, []data.MR, error) {
+	var rprs []cloudPullRequest
+	query := url.Values{"state": {"MERGED"}}
+	p, err := c.list(ctx, c.repoPath("/pullrequests"), cursor, PRsPerPage, query, &rprs)
+	if err != nil {
+		return p, nil, err
+	}
+
+	var ret []data.MR
+	for _, pr := range rprs {
+		mr := data.MR{
+			ID:          pr.ID,
+			Name:        pr.Title,
+			URL:         pr.Links.HTML.Href,
+			Author:      pr.Author.Nickname,
+			AuthorURL:   pr.Author.Links.HTML.Href,
+			Description: pr.Description,
+			Closes:      data.ParseClosingReferences(pr.Description),
+			IssueKeys:   data.ParseIssueKeys(pr.Title, pr.Description, pr.Source.Branch.Name),
+			// the API does not provide the merge date, PRs without
+			// merge commit fall back to the date of the last update
+			MergedDate: pr.UpdatedOn.UTC(),
+		}
+		if mr.Author == "" {
+			mr.Author = pr.Author.DisplayName
+		}
+		if pr.MergeCommit != nil {
+			var commit cloudCommit
+			path := c.repoPath("/commit/" + url.PathEscape(pr.MergeCommit.Hash))
+			if _, err := c.get(ctx, c.APIURL+path, &commit); err != nil {
+				return p, nil, err
+			}
+			mr.MergeCommit = pr.MergeCommit.Hash
+			mr.MergedDate = commit.Date.UTC()
+		}
+		ret = append(ret, mr)
+	}
+	return p, ret, nil
+}
+
+// serverPRs returns the page of merged Bitbucket Server PRs at given cursor
+func (c *Connector) serverPRs(
+	ctx context.Context,
+	cursor string,
+) (helpers.CursorPage, []data.MR, error) {
+	var rprs []serverPullRequest
+	query := url.Values{"state": {"MERGED"}}
+	p, err := c.list(ctx, c.repoPath("/pull-requests"), cursor, PRsPerPage, query, &rprs)
+	if err != nil {
+		return p, nil, err
+	}
+
+	var ret []data.MR
+	for _, pr := range rprs {
+		ret = append(ret, data.MR{
+			ID:          pr.ID,
+			Name:        pr.Title,
+			URL:         pr.Links.href(),
+			Author:      pr.Author.User.Slug,
+			AuthorURL:   pr.Author.User.Links.href(),
+			Description: pr.Description,
+			IssueKeys:   data.ParseIssueKeys(pr.Title, pr.Description, pr.FromRef.DisplayID),
+			MergedDate:  millisToTime(pr.ClosedDate),
+			MergeCommit: pr.Properties.MergeCommit.ID,
+		})
+	}
+	return p, ret, nil
+}
+
+// prFiles returns the files changed by given PR
+func (c *Connector) prFiles(ctx context.Context, id int) ([]string, error) {
+	resource := fmt.Sprintf("/pullrequests/%d/diffstat", id)
+	if c.Server {
+		resource = fmt.Sprintf("/pull-requests/%d/changes", id)
+	}
+
+	var ret []string
+	cursor := ""
+	for {
+		var (
+			p   helpers.CursorPage
+			err error
+		)
+		if c.Server {
+			var changes []serverChange
+			p, err = c.list(ctx, c.repoPath(resource), cursor, PRFilesPerPage, nil, &changes)
+			for _, ch := range changes {
+				ret = append(ret, ch.Path.ToString)
+			}
+		} else {
+			var stats []cloudDiffStat
+			p, err = c.list(ctx, c.repoPath(resource), cursor, PRFilesPerPage, nil, &stats)
+			for _, s := range stats {
+				// deleted files have only the old path
+				if s.New != nil {
+					ret = append(ret, s.New.Path)
+				} else if s.Old != nil {
+					ret = append(ret, s.Old.Path)
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if p.Next == "" {
+			return ret, nil
+		}
+		cursor = p.Next
+	}
+}
@@ -0,0 +1,146 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package bitbucket_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+func TestConnector_MRs(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		server bool
+		flags  map[string]string
+		want   data.MRs
+	}{
+		{
+			name: "Bitbucket Cloud",
+			want: data.MRs{
+				{
+					ID:          5,
+					Name:        "ABC-12: Fix crash on empty config",
+					URL:         "https://bitbucket.org/testowner/testrepo/pull-requests/5",
+					Author:      "contributor",
+					AuthorURL:   "https://bitbucket.org/%7B3f2a1b4c-5d6e-7f80-9a1b-2c3d4e5f6a7b%7D/",
+					MergedDate:  time.Date(2019, 3, 18, 7, 30, 0, 0, time.UTC),
+					Description: "Fixes #3",
+					Closes:      []int{3},
+					MergeCommit: "9c1e3a5b7d9f1b3d5f7a9c1e3b5d7f9a1c3e5b7d",
+					IssueKeys:   []string{"ABC-12"},
+				},
+				{
+					ID:         4,
+					Name:       "Add documentation",
+					URL:        "https://bitbucket.org/testowner/testrepo/pull-requests/4",
+					Author:     "Jane Doe",
+					AuthorURL:  "https://bitbucket.org/%7B8e7d6c5b-4a39-2817-0f6e-5d4c3b2a1908%7D/",
+					MergedDate: time.Date(2019, 3, 16, 12, 0, 0, 0, time.UTC),
+					IssueKeys:  []string{"ABC-7"},
+				},
+			},
+		},
+		{
+			name:  "Bitbucket Cloud with changed files",
+			flags: map[string]string{"path": "config"},
+			want: data.MRs{
+				{
+					ID:          5,
+					Name:        "ABC-12: Fix crash on empty config",
+					URL:         "https://bitbucket.org/testowner/testrepo/pull-requests/5",
+					Author:      "contributor",
+					AuthorURL:   "https://bitbucket.org/%7B3f2a1b4c-5d6e-7f80-9a1b-2c3d4e5f6a7b%7D/",
+					MergedDate:  time.Date(2019, 3, 18, 7, 30, 0, 0, time.UTC),
+					Description: "Fixes #3",
+					Closes:      []int{3},
+					Files:       []string{"config/config.go", "config/legacy.go"},
+					MergeCommit: "9c1e3a5b7d9f1b3d5f7a9c1e3b5d7f9a1c3e5b7d",
+					IssueKeys:   []string{"ABC-12"},
+				},
+				{
+					ID:         4,
+					Name:       "Add documentation",
+					URL:        "https://bitbucket.org/testowner/testrepo/pull-requests/4",
+					Author:     "Jane Doe",
+					AuthorURL:  "https://bitbucket.org/%7B8e7d6c5b-4a39-2817-0f6e-5d4c3b2a1908%7D/",
+					MergedDate: time.Date(2019, 3, 16, 12, 0, 0, 0, time.UTC),
+					Files:      []string{"docs/README.md"},
+					IssueKeys:  []string{"ABC-7"},
+				},
+			},
+		},
+		{
+			name:   "Bitbucket Server with changed files",
+			server: true,
+			flags:  map[string]string{"path": "web"},
+			want: data.MRs{
+				{
+					ID:          7,
+					Name:        "Add login page",
+					URL:         "https://bitbucket.example.com/projects/TEST/repos/testrepo/pull-requests/7",
+					Author:      "jdoe",
+					AuthorURL:   "https://bitbucket.example.com/users/jdoe",
+					MergedDate:  time.Date(2019, 3, 18, 9, 30, 0, 0, time.UTC),
+					Description: "Implements PROJ-42",
+					Files:       []string{"web/login.go"},
+					MergeCommit: "9c1e3a5b7d9f1b3d5f7a9c1e3b5d7f9a1c3e5b7d",
+					IssueKeys:   []string{"PROJ-42"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newTestConnector(srv, tt.server, "testrepo", tt.flags)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			cerr := make(chan error, 1)
+
+			cgot, cmrscounter, _ := c.MRs(context.Background(), cerr)
+
+			var got data.MRs
+			for m := range cgot {
+				got = append(got, m)
+			}
+			processed := 0
+			for range cmrscounter {
+				processed++
+			}
+
+			select {
+			case err := <-cerr:
+				t.Fatalf("Connector.MRs() error = %v", err)
+			default:
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Connector.MRs() = %+v, want %+v", got, tt.want)
+			}
+			if processed != len(tt.want) {
+				t.Errorf("Connector.MRs() processed = %v, want %v", processed, len(tt.want))
+			}
+		})
+	}
+}
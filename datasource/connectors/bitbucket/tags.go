@@ -0,0 +1,135 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// TagsPerPage defined how many tags are fetched per page
+var TagsPerPage = 50 // nolint: gochecknoglobals
+
+// Tags returns the tags via channels.
+// Returns possible errors via given cerr channel
+// ctags returns tags
+// ctagscounter returns the channel, which ticks when a tag is proceeded
+// cmaxtags returns the max available amount of tags
+func (c *Connector) Tags(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	ctags <-chan data.Tag,
+	ctagscounter <-chan bool,
+	cmaxtags <-chan int,
+) {
+	tags := make(chan data.Tag)
+	maxtags := make(chan int, 1)
+	tagscounter := make(chan bool, 100)
+
+	go func() {
+		defer close(tags)
+		defer close(tagscounter)
+		defer close(maxtags)
+
+		helpers.FetchCursorPages(ctx, cerr, maxtags,
+			func(ctx context.Context, cursor string) (helpers.CursorPage, error) {
+				var (
+					p     helpers.CursorPage
+					rtags []data.Tag
+					err   error
+				)
+				if c.Server {
+					p, rtags, err = c.serverTags(ctx, cursor)
+				} else {
+					p, rtags, err = c.cloudTags(ctx, cursor)
+				}
+				if err != nil {
+					return p, formatErrorCode("Tags", err)
+				}
+
+				for _, t := range rtags {
+					select {
+					case <-ctx.Done():
+						return p, ctx.Err()
+					case tags <- t:
+						tagscounter <- true
+					}
+				}
+				p.Items = len(rtags)
+				return p, nil
+			})
+	}()
+
+	return tags, tagscounter, maxtags
+}
+
+// cloudTags returns the page of Bitbucket Cloud tags at given cursor
+func (c *Connector) cloudTags(
+	ctx context.Context,
+	cursor string,
+) (helpers.CursorPage, []data.Tag, error) {
+	var rtags []cloudTag
+	p, err := c.list(ctx, c.repoPath("/refs/tags"), cursor, TagsPerPage, nil, &rtags)
+	if err != nil {
+		return p, nil, err
+	}
+
+	var ret []data.Tag
+	for _, t := range rtags {
+		ret = append(ret, data.Tag{
+			Name:   t.Name,
+			Commit: t.Target.Hash,
+			Date:   t.Target.Date.UTC(),
+			URL:    c.tagURL(t.Name),
+		})
+	}
+	return p, ret, nil
+}
+
+// serverTags returns the page of Bitbucket Server tags at given cursor.
+// The tags do not provide any dates, so the tagged commits are fetched too
+func (c *Connector) serverTags(
+	ctx context.Context,
+	cursor string,
+) (helpers.CursorPage, []data.Tag, error) {
+	var rtags []serverTag
+	p, err := c.list(ctx, c.repoPath("/tags"), cursor, TagsPerPage, nil, &rtags)
+	if err != nil {
+		return p, nil, err
+	}
+
+	var ret []data.Tag
+	for _, t := range rtags {
+		var commit serverCommit
+		path := c.repoPath("/commits/" + url.PathEscape(t.LatestCommit))
+		if _, err := c.get(ctx, c.APIURL+path, &commit); err != nil {
+			return p, nil, err
+		}
+		ret = append(ret, data.Tag{
+			Name:   t.DisplayID,
+			Commit: t.LatestCommit,
+			Date:   millisToTime(commit.CommitterTimestamp),
+			URL:    c.tagURL(t.DisplayID),
+		})
+	}
+	return p, ret, nil
+}
@@ -0,0 +1,129 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package bitbucket_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/bitbucket"
+	"github.com/artem-sidorenko/chagen/internal/testing/helpers"
+)
+
+func TestConnector_Tags(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name        string
+		server      bool
+		repo        string
+		want        data.Tags
+		wantErr     error
+		wantMaxtags []int
+	}{
+		{
+			name: "Bitbucket Cloud with next links",
+			repo: "testrepo",
+			want: data.Tags{
+				{
+					Name:   "v0.1.1",
+					Commit: "8f2b0e4c6a1d3f5b7c9e0a2d4f6b8c0e1a3d5f7b",
+					Date:   time.Date(2019, 3, 19, 9, 0, 0, 0, time.UTC),
+					URL:    "https://bitbucket.org/testowner/testrepo/src/v0.1.1",
+				},
+				{
+					Name:   "v0.1.0",
+					Commit: "1a3c5e7b9d0f2a4c6e8b0d1f3a5c7e9b2d4f6a8c",
+					Date:   time.Date(2019, 3, 17, 9, 0, 0, 0, time.UTC),
+					URL:    "https://bitbucket.org/testowner/testrepo/src/v0.1.0",
+				},
+			},
+			// the total amount is provided by the first page
+			wantMaxtags: []int{2},
+		},
+		{
+			name:   "Bitbucket Server with isLastPage",
+			server: true,
+			repo:   "testrepo",
+			want: data.Tags{
+				{
+					Name:   "v0.1.1",
+					Commit: "8f2b0e4c6a1d3f5b7c9e0a2d4f6b8c0e1a3d5f7b",
+					Date:   time.Date(2019, 3, 19, 9, 0, 0, 0, time.UTC),
+					URL:    srv.URL + "/projects/TEST/repos/testrepo/browse?at=refs%2Ftags%2Fv0.1.1",
+				},
+				{
+					Name:   "v0.1.0",
+					Commit: "1a3c5e7b9d0f2a4c6e8b0d1f3a5c7e9b2d4f6a8c",
+					Date:   time.Date(2019, 3, 17, 9, 0, 0, 0, time.UTC),
+					URL:    srv.URL + "/projects/TEST/repos/testrepo/browse?at=refs%2Ftags%2Fv0.1.0",
+				},
+			},
+			// the amount is known after the last page
+			wantMaxtags: []int{2},
+		},
+		{
+			name:    "API call fails",
+			repo:    "missingrepo",
+			wantErr: errors.New("Bitbucket query 'Tags' failed: GET /repositories/testowner/missingrepo/refs/tags: 404 Not Found"), // nolint: lll
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bitbucket.TagsPerPage = 1
+			c, err := newTestConnector(srv, tt.server, tt.repo, nil)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			cerr := make(chan error, 1)
+
+			cgot, _, cmaxtags := c.Tags(context.Background(), cerr)
+
+			var got data.Tags
+			for t := range cgot {
+				got = append(got, t)
+			}
+			// the max channel is closed after all tags are delivered
+			gotmaxtags := helpers.GetChannelValuesInt(cmaxtags)
+			// sort the tags to have the stable order
+			sort.Sort(&got)
+
+			var err2 error
+			select {
+			case err2 = <-cerr:
+			default:
+			}
+
+			if !reflect.DeepEqual(err2, tt.wantErr) {
+				t.Errorf("Connector.Tags() error = %v, wantErr %v", err2, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Connector.Tags() = %+v, want %+v", got, tt.want)
+			}
+			if err2 == nil && !reflect.DeepEqual(gotmaxtags, tt.wantMaxtags) {
+				t.Errorf("Connector.Tags() maxtags = %v, want %v", gotmaxtags, tt.wantMaxtags)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// GetNewTagURL returns the URL for a new tag, which does not exist yet
+func (c *Connector) GetNewTagURL(TagName string) (string, error) {
+	return c.tagURL(TagName), nil
+}
+
+// CompareURL implements the connectors.CompareURLProvider interface
+func (c *Connector) CompareURL(from, to string) (string, error) {
+	if c.Server {
+		return fmt.Sprintf("%s/compare/diff?sourceBranch=%s&targetBranch=%s",
+			c.ProjectURL, url.QueryEscape("refs/tags/"+to), url.QueryEscape("refs/tags/"+from)), nil
+	}
+	// Bitbucket Cloud separates the revisions via carriage return
+	return fmt.Sprintf("%s/branches/compare/%s%%0D%s#diff", c.ProjectURL, to, from), nil
+}
+
+// tagURL returns the URL of the source tree at given tag,
+// Bitbucket has no dedicated pages for tags
+func (c *Connector) tagURL(tagName string) string {
+	if c.Server {
+		return fmt.Sprintf("%s/browse?at=%s", c.ProjectURL, url.QueryEscape("refs/tags/"+tagName))
+	}
+	return fmt.Sprintf("%s/src/%s", c.ProjectURL, tagName)
+}
@@ -0,0 +1,61 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package bitbucket_test
+
+import (
+	"testing"
+)
+
+func TestConnector_URLs(t *testing.T) {
+	tests := []struct {
+		name        string
+		flags       map[string]string
+		wantTagURL  string
+		wantCompare string
+	}{
+		{
+			name:        "Bitbucket Cloud",
+			wantTagURL:  "https://bitbucket.org/testowner/testrepo/src/v1.0.0",
+			wantCompare: "https://bitbucket.org/testowner/testrepo/branches/compare/v1.0.0%0Dv0.1.0#diff",
+		},
+		{
+			name: "Bitbucket Server",
+			flags: map[string]string{
+				"bitbucket-url":   "https://bitbucket.example.com",
+				"bitbucket-owner": "TEST",
+			},
+			wantTagURL: "https://bitbucket.example.com/projects/TEST/repos/testrepo/browse?at=refs%2Ftags%2Fv1.0.0",
+			wantCompare: "https://bitbucket.example.com/projects/TEST/repos/testrepo/compare/diff" +
+				"?sourceBranch=refs%2Ftags%2Fv1.0.0&targetBranch=refs%2Ftags%2Fv0.1.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newTestConnector(nil, false, "testrepo", tt.flags)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if got, _ := c.GetNewTagURL("v1.0.0"); got != tt.wantTagURL {
+				t.Errorf("GetNewTagURL() = %v, want %v", got, tt.wantTagURL)
+			}
+			if got, _ := c.CompareURL("v0.1.0", "v1.0.0"); got != tt.wantCompare {
+				t.Errorf("CompareURL() = %v, want %v", got, tt.wantCompare)
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ package connectors
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/urfave/cli"
 )
@@ -26,14 +28,29 @@ import (
 // NewConnectorFunc describes the constructor of Connector
 type NewConnectorFunc func(*cli.Context) (Connector, error)
 
+// NewEndpointConnectorFunc describes the constructor of Connector,
+// which gets the argument of its endpoint (see SplitEndpoint)
+type NewEndpointConnectorFunc func(ctx *cli.Context, arg string) (Connector, error)
+
 // ConnectorCLIFlags describes the function, which returns the configured
 // CLI flags for particular connector
 type ConnectorCLIFlags func() []cli.Flag
 
 type connector struct {
 	name         string
-	newConnector NewConnectorFunc
+	newConnector NewEndpointConnectorFunc
 	CLIFlags     ConnectorCLIFlags
+	envVars      []string
+	prototype    Connector
+}
+
+// ConnectorInfo describes a registered connector for humans
+type ConnectorInfo struct {
+	ID       string
+	Name     string
+	CLIFlags []cli.Flag
+	EnvVars  []string
+	Features []string
 }
 
 var connectors = make(map[string]connector) // nolint: gochecknoglobals
@@ -44,6 +61,18 @@ var connectors = make(map[string]connector) // nolint: gochecknoglobals
 // newConnector is the connector constructor function
 // CLIFlag is a function, which returns the configured CLI flags
 func RegisterConnector(id, name string, newConnector NewConnectorFunc, CLIFlags ConnectorCLIFlags) {
+	RegisterEndpointConnector(id, name, func(ctx *cli.Context, _ string) (Connector, error) {
+		return newConnector(ctx)
+	}, CLIFlags)
+}
+
+// RegisterEndpointConnector registers the new connector like RegisterConnector,
+// the constructor gets the argument of the endpoint, e.g. the path of exec:<path>
+func RegisterEndpointConnector(
+	id, name string,
+	newConnector NewEndpointConnectorFunc,
+	CLIFlags ConnectorCLIFlags,
+) {
 	connectors[id] = connector{
 		name:         name,
 		newConnector: newConnector,
@@ -51,14 +80,40 @@ func RegisterConnector(id, name string, newConnector NewConnectorFunc, CLIFlags
 	}
 }
 
-// NewConnector returns the Connector of given id
+// RegisterConnectorDetails registers the details of already registered connector,
+// which are shown to humans. Unknown connectors are ignored
+// envVars are the environment variables used by connector
+// prototype is an empty value of connector, which is used to detect the optional features
+func RegisterConnectorDetails(id string, envVars []string, prototype Connector) {
+	conn, ok := connectors[id]
+	if !ok {
+		return
+	}
+	conn.envVars = envVars
+	conn.prototype = prototype
+	connectors[id] = conn
+}
+
+// SplitEndpoint splits the endpoint into the connector id and its argument,
+// e.g. exec:/path/to/plugin results in exec and /path/to/plugin
+func SplitEndpoint(endpoint string) (id, arg string) {
+	parts := strings.SplitN(endpoint, ":", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return parts[0], ""
+}
+
+// NewConnector returns the Connector of given id,
+// the id can contain an argument for the connector (see SplitEndpoint)
 // if this connector is missing, error is returned
 func NewConnector(id string, ctx *cli.Context) (Connector, error) {
+	id, arg := SplitEndpoint(id)
 	if err := checkConnector(id); err != nil {
 		return nil, err
 	}
 
-	conn, err := connectors[id].newConnector(ctx)
+	conn, err := connectors[id].newConnector(ctx, arg)
 	if err != nil {
 		return nil, err
 	}
@@ -75,6 +130,30 @@ func CLIFlags(id string) ([]cli.Flag, error) {
 	return connectors[id].CLIFlags(), nil
 }
 
+// Info returns the details of given connector
+// if this connector is missing, error is returned
+func Info(id string) (ConnectorInfo, error) {
+	if err := checkConnector(id); err != nil {
+		return ConnectorInfo{}, err
+	}
+
+	conn := connectors[id]
+	ret := ConnectorInfo{
+		ID:      id,
+		Name:    conn.name,
+		EnvVars: conn.envVars,
+	}
+	if conn.CLIFlags != nil {
+		ret.CLIFlags = conn.CLIFlags()
+	}
+	for _, f := range Features() {
+		if Supports(conn.prototype, f) {
+			ret.Features = append(ret.Features, f)
+		}
+	}
+	return ret, nil
+}
+
 // checkConnector checks if given connector is registered.
 // Returns nil if everything ok, error otherwise
 func checkConnector(id string) error {
@@ -84,9 +163,11 @@ func checkConnector(id string) error {
 	return fmt.Errorf("unknown connector: %s", id)
 }
 
-// ConnectorRegistered verifies if given connector is registered
+// ConnectorRegistered verifies if given connector is registered,
+// the id can contain an argument for the connector (see SplitEndpoint)
 // returns true if yes, false otherwise
 func ConnectorRegistered(id string) bool {
+	id, _ = SplitEndpoint(id)
 	_, ok := connectors[id]
 	return ok
 }
@@ -97,11 +178,12 @@ func ResetConnectors() {
 	connectors = make(map[string]connector)
 }
 
-// RegisteredConnectors returns a slice of registered connector ids
+// RegisteredConnectors returns a sorted slice of registered connector ids
 func RegisteredConnectors() []string {
 	var ret []string
 	for k := range connectors {
 		ret = append(ret, k)
 	}
+	sort.Strings(ret)
 	return ret
 }
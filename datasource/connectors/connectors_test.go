@@ -20,7 +20,6 @@ import (
 	"context"
 	"errors"
 	"reflect"
-	"sort"
 	"testing"
 
 	"github.com/artem-sidorenko/chagen/data"
@@ -158,6 +157,23 @@ func TestNewConnector(t *testing.T) {
 	}
 }
 
+func TestNewConnectorWithArgument(t *testing.T) {
+	connectors.ResetConnectors()
+	var gotArg string
+	connectors.RegisterEndpointConnector("testarg", "TestArg",
+		func(_ *cli.Context, arg string) (connectors.Connector, error) {
+			gotArg = arg
+			return &testConnector{}, nil
+		}, nil)
+
+	if _, err := connectors.NewConnector("testarg:/path/to/plugin", nil); err != nil {
+		t.Fatalf("NewConnector() error = %v", err)
+	}
+	if gotArg != "/path/to/plugin" {
+		t.Errorf("NewConnector() argument = %v, want %v", gotArg, "/path/to/plugin")
+	}
+}
+
 func TestConnectorRegistered(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -171,6 +187,12 @@ func TestConnectorRegistered(t *testing.T) {
 			id:            "testconn2",
 			want:          true,
 		},
+		{
+			name:          "Registered connector with argument is requested",
+			regConnectors: []string{"testconn1"},
+			id:            "testconn1:/path/to/plugin",
+			want:          true,
+		},
 		{
 			name:          "Not registered connector is requested",
 			regConnectors: []string{"testconn1"},
@@ -189,6 +211,26 @@ func TestConnectorRegistered(t *testing.T) {
 	}
 }
 
+func TestSplitEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		wantID   string
+		wantArg  string
+	}{
+		{"github", "github", ""},
+		{"exec:/path/to/plugin", "exec", "/path/to/plugin"},
+		{"exec:C:\\plugin.exe", "exec", "C:\\plugin.exe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			id, arg := connectors.SplitEndpoint(tt.endpoint)
+			if id != tt.wantID || arg != tt.wantArg {
+				t.Errorf("SplitEndpoint() = %v, %v, want %v, %v", id, arg, tt.wantID, tt.wantArg)
+			}
+		})
+	}
+}
+
 func TestRegisteredConnectors(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -197,15 +239,14 @@ func TestRegisteredConnectors(t *testing.T) {
 	}{
 		{
 			name:          "Registered connectors",
-			regConnectors: []string{"testconn1", "testconn2"},
-			want:          []string{"testconn1", "testconn2"},
+			regConnectors: []string{"testconn2", "testconn3", "testconn1"},
+			want:          []string{"testconn1", "testconn2", "testconn3"},
 		}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			registerConnectors(tt.regConnectors)
 
 			got := connectors.RegisteredConnectors()
-			sort.Strings(got)
 
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("RegisteredConnectors() = %v, want %v", got, tt.want)
@@ -213,3 +254,64 @@ func TestRegisteredConnectors(t *testing.T) {
 		})
 	}
 }
+
+type testCommitConnector struct {
+	testConnector
+}
+
+func (t *testCommitConnector) Commits(_ context.Context, _ chan<- error, _ data.Tags) (
+	<-chan data.Commit, <-chan bool, <-chan int,
+) {
+	return nil, nil, nil
+}
+
+func TestInfo(t *testing.T) {
+	connectors.ResetConnectors()
+	connectors.RegisterConnector("testexisting", "TestExisting", newTestConnector, CLIFlags)
+	connectors.RegisterConnectorDetails("testexisting", []string{"TEST_TOKEN"}, &testCommitConnector{})
+	connectors.RegisterConnector("testplain", "TestPlain", newTestConnector, nil)
+
+	tests := []struct {
+		name    string
+		id      string
+		want    connectors.ConnectorInfo
+		wantErr error
+	}{
+		{
+			name: "Connector with details",
+			id:   "testexisting",
+			want: connectors.ConnectorInfo{
+				ID:       "testexisting",
+				Name:     "TestExisting",
+				CLIFlags: CLIFlags(),
+				EnvVars:  []string{"TEST_TOKEN"},
+				Features: []string{connectors.FeatureCommits},
+			},
+		},
+		{
+			name: "Connector without details",
+			id:   "testplain",
+			want: connectors.ConnectorInfo{
+				ID:   "testplain",
+				Name: "TestPlain",
+			},
+		},
+		{
+			name:    "Connector does not exist",
+			id:      "testmissing",
+			wantErr: errors.New("unknown connector: testmissing"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := connectors.Info(tt.id)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Info() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Info() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// chagen-snapshot-plugin is the reference plugin for the exec endpoint,
+// it serves the data snapshots created by the export command:
+//
+//	chagen generate --endpoint exec:chagen-snapshot-plugin --exec-option snapshot=data.json
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/exec"
+)
+
+func main() {
+	if err := exec.Serve(os.Stdin, os.Stdout, exec.SnapshotPlugin{}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err) // nolint: errcheck
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,341 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package exec implements the connector, which talks to an external
+// plugin program via JSON lines on stdin/stdout (see Request)
+package exec
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/urfave/cli"
+)
+
+// maxMessageSize limits the size of a single message of plugin
+const maxMessageSize = 10 * 1024 * 1024
+
+// errNoHandshake is returned if the plugin exits without any handshake
+var errNoHandshake = errors.New("plugin did not send the handshake") // nolint: gochecknoglobals
+
+// Connector implements the connector to an external plugin
+type Connector struct {
+	Path    string
+	Options map[string]string
+}
+
+// formatErrorCode formats the error message for this connector
+func formatErrorCode(query string, err error) error {
+	return helpers.FormatErrorCode("Plugin", query, err)
+}
+
+// RepositoryExists asks the plugin, if the configured repository is present
+func (c *Connector) RepositoryExists() (bool, error) {
+	var exists bool
+	err := c.run(context.Background(), Request{Method: MethodRepositoryExists}, func(m Message) error {
+		if m.Type != MessageResult {
+			return fmt.Errorf("unexpected message type: %v", m.Type)
+		}
+		exists = m.Exists
+		return nil
+	})
+	if err != nil {
+		return false, formatErrorCode(MethodRepositoryExists, err)
+	}
+	return exists, nil
+}
+
+// GetNewTagURL asks the plugin for the URL of a new tag, which does not exist yet
+func (c *Connector) GetNewTagURL(TagName string) (string, error) {
+	var url string
+	err := c.run(context.Background(), Request{Method: MethodNewTagURL, Tag: TagName}, func(m Message) error {
+		if m.Type != MessageResult {
+			return fmt.Errorf("unexpected message type: %v", m.Type)
+		}
+		url = m.URL
+		return nil
+	})
+	if err != nil {
+		return "", formatErrorCode(MethodNewTagURL, err)
+	}
+	return url, nil
+}
+
+// Tags returns the tags streamed by the plugin via channels.
+// Returns possible errors via given cerr channel
+// ctags returns tags
+// ctagscounter returns the channel, which ticks when a tag is proceeded
+// cmaxtags returns the max available amount of tags
+func (c *Connector) Tags(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	ctags <-chan data.Tag,
+	ctagscounter <-chan bool,
+	cmaxtags <-chan int,
+) {
+	tags := make(chan data.Tag)
+	counter, max := c.stream(ctx, cerr, MethodTags, func(m Message) error {
+		if m.Type != MessageTag || m.Tag == nil {
+			return fmt.Errorf("unexpected message type: %v", m.Type)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case tags <- *m.Tag:
+			return nil
+		}
+	}, func() { close(tags) })
+
+	return tags, counter, max
+}
+
+// Issues returns the issues streamed by the plugin via channels.
+// Returns possible errors via given cerr channel
+// cissues returns issues
+// cissuescounter returns the channel, which ticks when an issue is proceeded
+// cmaxissues returns the max available amount of issues
+func (c *Connector) Issues(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	cissues <-chan data.Issue,
+	cissuescounter <-chan bool,
+	cmaxissues <-chan int,
+) {
+	issues := make(chan data.Issue)
+	counter, max := c.stream(ctx, cerr, MethodIssues, func(m Message) error {
+		if m.Type != MessageIssue || m.Issue == nil {
+			return fmt.Errorf("unexpected message type: %v", m.Type)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case issues <- *m.Issue:
+			return nil
+		}
+	}, func() { close(issues) })
+
+	return issues, counter, max
+}
+
+// MRs returns the MRs streamed by the plugin via channels.
+// Returns possible errors via given cerr channel
+// cmrs returns MRs
+// cmrscounter returns the channel, which ticks when a MR is proceeded
+// cmaxmrs returns the max available amount of MRs
+func (c *Connector) MRs(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	cmrs <-chan data.MR,
+	cmrscounter <-chan bool,
+	cmaxmrs <-chan int,
+) {
+	mrs := make(chan data.MR)
+	counter, max := c.stream(ctx, cerr, MethodMRs, func(m Message) error {
+		if m.Type != MessageMR || m.MR == nil {
+			return fmt.Errorf("unexpected message type: %v", m.Type)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case mrs <- *m.MR:
+			return nil
+		}
+	}, func() { close(mrs) })
+
+	return mrs, counter, max
+}
+
+// stream runs the given streaming method of plugin in background.
+// The max and progress messages are sent to the returned channels,
+// all other messages are passed to handle. done is called
+// after the plugin has finished
+func (c *Connector) stream(
+	ctx context.Context,
+	cerr chan<- error,
+	method string,
+	handle func(Message) error,
+	done func(),
+) (
+	<-chan bool,
+	<-chan int,
+) {
+	counter := make(chan bool, 100)
+	max := make(chan int, 1)
+
+	go func() {
+		defer done()
+		defer close(counter)
+		defer close(max)
+
+		maxSent := false
+		err := c.run(ctx, Request{Method: method}, func(m Message) error {
+			switch m.Type {
+			case MessageMax:
+				// the max amount can be sent only once
+				if !maxSent {
+					max <- m.Max
+					maxSent = true
+				}
+				return nil
+			case MessageProgress:
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case counter <- true:
+					return nil
+				}
+			}
+			return handle(m)
+		})
+		// the errors after cancelling are caused by the stopped plugin
+		if err != nil && ctx.Err() == nil {
+			helpers.NonBlockingErrSend(ctx, cerr, formatErrorCode(method, err))
+		}
+	}()
+
+	return counter, max
+}
+
+// run starts the plugin, sends the request and passes the
+// messages after the handshake to handle
+func (c *Connector) run(ctx context.Context, req Request, handle func(Message) error) error {
+	req.Protocol = ProtocolVersion
+	req.Options = c.Options
+	in, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, c.Path) // nolint: gosec
+	cmd.Stdin = bytes.NewReader(append(in, '\n'))
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	err = readMessages(stdout, handle)
+	// the plugin should be stopped, if we do not read its output anymore
+	if err != nil && err != errNoHandshake {
+		cmd.Process.Kill() // nolint: errcheck, gosec
+	}
+	// the exit status of plugin describes the failure better
+	// than the missing handshake, e.g. if the plugin crashed
+	if werr := cmd.Wait(); werr != nil && (err == nil || err == errNoHandshake) {
+		err = werr
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%v: %v", werr, msg)
+		}
+	}
+	return err
+}
+
+// readMessages reads the messages of plugin, verifies the handshake
+// and passes all following messages to handle
+func readMessages(stdout io.Reader, handle func(Message) error) error {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(nil, maxMessageSize)
+
+	handshake := false
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var m Message
+		if err := json.Unmarshal(line, &m); err != nil {
+			return fmt.Errorf("can't parse the message of plugin: %v", err)
+		}
+
+		if !handshake {
+			if m.Type != MessageHandshake {
+				return fmt.Errorf("unexpected message type before handshake: %v", m.Type)
+			}
+			if m.Protocol != ProtocolVersion {
+				return fmt.Errorf("unsupported protocol version of plugin: %v", m.Protocol)
+			}
+			handshake = true
+			continue
+		}
+
+		if m.Type == MessageError {
+			return errors.New(m.Error)
+		}
+		if err := handle(m); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if !handshake {
+		return errNoHandshake
+	}
+	return nil
+}
+
+// New returns a new Connector, the path to plugin is given via endpoint exec:<path>,
+// e.g. --endpoint exec:<path> or --issues-endpoint exec:<path>
+func New(ctx *cli.Context, path string) (connectors.Connector, error) {
+	if path == "" {
+		return nil, errors.New("path to the plugin is required: exec:<path>")
+	}
+
+	options := map[string]string{}
+	for _, o := range ctx.StringSlice("exec-option") {
+		kv := strings.SplitN(o, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("option --exec-option should be in format key=value: %v", o)
+		}
+		options[kv[0]] = kv[1]
+	}
+
+	return &Connector{Path: path, Options: options}, nil
+}
+
+// CLIFlags returns the possible CLI flags for this connector
+func CLIFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "exec-option",
+			Usage: "Option `key=value` passed to the plugin of exec endpoint, can be repeated",
+		},
+	}
+}
+
+func init() { // nolint: gochecknoinits
+	connectors.RegisterEndpointConnector("exec", "External plugin", New, CLIFlags)
+	connectors.RegisterConnectorDetails("exec", nil, &Connector{})
+}
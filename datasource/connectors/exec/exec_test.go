@@ -0,0 +1,270 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package exec_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource"
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/exec"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/file"
+	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+	"github.com/artem-sidorenko/chagen/internal/testing/testdata"
+)
+
+// pluginEnvVar is set, if the test binary should act as the reference plugin
+const pluginEnvVar = "CHAGEN_TEST_EXEC_PLUGIN"
+
+// TestMain runs the test binary as reference plugin, if requested.
+// This way the tests can start the plugin without building it
+func TestMain(m *testing.M) {
+	if os.Getenv(pluginEnvVar) == "1" {
+		if err := exec.Serve(os.Stdin, os.Stdout, exec.SnapshotPlugin{}); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	os.Setenv(pluginEnvVar, "1") // nolint: errcheck, gosec
+	os.Exit(m.Run())
+}
+
+// writeTestSnapshot writes the testdata as snapshot and returns its path
+func writeTestSnapshot(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "chagen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	err = file.WriteSnapshot(
+		buf,
+		"https://test.example.com/releases/"+file.NewTagPlaceholder,
+		testdata.DataTags(),
+		testdata.DataIssues(),
+		testdata.DataMRs(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "data.json")
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// newConnector creates the connector for given endpoint, the endpoint isn't set
+// as CLI flag as the connector can be used as --issues-endpoint too
+func newConnector(endpoint string, options ...string) (connectors.Connector, error) {
+	// the repeated flags are passed as arguments
+	var args []string
+	for _, o := range options {
+		args = append(args, "--exec-option", o)
+	}
+	ctx := tcli.TestContextWithArgs(exec.CLIFlags(), nil, args)
+	return connectors.NewConnector(endpoint, ctx)
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		options  []string
+		want     connectors.Connector
+		wantErr  error
+	}{
+		{
+			name:     "Plugin with options",
+			endpoint: "exec:/usr/bin/plugin",
+			options:  []string{"project=foo", "token=a=b"},
+			want: &exec.Connector{
+				Path:    "/usr/bin/plugin",
+				Options: map[string]string{"project": "foo", "token": "a=b"},
+			},
+		},
+		{
+			name:     "Missing plugin path",
+			endpoint: "exec",
+			wantErr:  errors.New("path to the plugin is required: exec:<path>"),
+		},
+		{
+			name:     "Wrong option",
+			endpoint: "exec:/usr/bin/plugin",
+			options:  []string{"project"},
+			wantErr:  errors.New("option --exec-option should be in format key=value: project"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newConnector(tt.endpoint, tt.options...)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("New() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnector_Data(t *testing.T) {
+	path := writeTestSnapshot(t)
+	defer os.RemoveAll(filepath.Dir(path)) // nolint: errcheck
+
+	conn, err := newConnector("exec:"+os.Args[0], "snapshot="+path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	exists, err := conn.RepositoryExists()
+	if err != nil || !exists {
+		t.Fatalf("RepositoryExists() = %v, error = %v", exists, err)
+	}
+
+	tags, issues, mrs, err := datasource.GetConnectorData(
+		conn, &datasource.Options{NewRelease: "v1.0.0"}, ioutil.Discard)
+	if err != nil {
+		t.Fatalf("GetConnectorData() error = %v", err)
+	}
+
+	wantTags, wantIssues, wantMRs := data.Tags(testdata.DataTags()),
+		data.Issues(testdata.DataIssues()), data.MRs(testdata.DataMRs())
+	// JSON provides the dates always in UTC
+	data.UTCDate(wantTags, wantIssues, wantMRs)
+	// the pipeline marks the returning authors
+	data.MarkReturningAuthors(wantMRs)
+
+	newTag := tags[len(tags)-1]
+	if newTag.URL != "https://test.example.com/releases/v1.0.0" {
+		t.Errorf("URL of new tag = %v, want %v", newTag.URL, "https://test.example.com/releases/v1.0.0")
+	}
+	if tags = tags[:len(tags)-1]; !reflect.DeepEqual(tags, wantTags) {
+		t.Errorf("Tags = %+v, want %+v", tags, wantTags)
+	}
+	if !reflect.DeepEqual(issues, wantIssues) {
+		t.Errorf("Issues = %+v, want %+v", issues, wantIssues)
+	}
+	if !reflect.DeepEqual(mrs, wantMRs) {
+		t.Errorf("MRs = %+v, want %+v", mrs, wantMRs)
+	}
+}
+
+func TestConnector_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		plugin  string
+		wantErr error
+	}{
+		{
+			name:    "Error of plugin",
+			plugin:  os.Args[0],
+			wantErr: errors.New("Plugin query 'repository_exists' failed: option snapshot is required"),
+		},
+		{
+			name:    "Missing handshake",
+			plugin:  "/bin/true",
+			wantErr: errors.New("Plugin query 'repository_exists' failed: plugin did not send the handshake"),
+		},
+		{
+			name:    "Failed plugin",
+			plugin:  "/bin/false",
+			wantErr: errors.New("Plugin query 'repository_exists' failed: exit status 1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := newConnector("exec:" + tt.plugin)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			_, err = conn.RepositoryExists()
+			if err == nil || err.Error() != tt.wantErr.Error() {
+				t.Errorf("RepositoryExists() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConnector_StreamError(t *testing.T) {
+	conn, err := newConnector("exec:" + os.Args[0])
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// all streams fail, the late errors should not break the pipeline
+	for i := 0; i < 20; i++ {
+		_, _, _, err = datasource.GetConnectorData(conn, &datasource.Options{}, ioutil.Discard)
+		if err == nil || !strings.HasSuffix(err.Error(), "failed: option snapshot is required") {
+			t.Errorf("GetConnectorData() error = %v, want error of plugin", err)
+		}
+	}
+	// give the late errors a chance to be sent
+	time.Sleep(time.Millisecond * 200)
+}
+
+func TestServe(t *testing.T) {
+	tests := []struct {
+		name    string
+		request string
+		want    string
+	}{
+		{
+			name:    "Unsupported method",
+			request: `{"protocol": 1, "method": "wrong"}`,
+			want: `{"type":"handshake","protocol":1}
+{"type":"error","error":"unsupported method: wrong"}
+`,
+		},
+		{
+			name:    "Unsupported protocol",
+			request: `{"protocol": 2, "method": "tags"}`,
+			want: `{"type":"handshake","protocol":1}
+{"type":"error","error":"unsupported protocol version: 2"}
+`,
+		},
+		{
+			name:    "Broken request",
+			request: `{"protocol": 1`,
+			want: `{"type":"handshake","protocol":1}
+{"type":"error","error":"can't parse the request: unexpected EOF"}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			if err := exec.Serve(strings.NewReader(tt.request), out, exec.SnapshotPlugin{}); err != nil {
+				t.Fatalf("Serve() error = %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("Serve() output = %v, want %v", out.String(), tt.want)
+			}
+		})
+	}
+}
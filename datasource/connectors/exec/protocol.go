@@ -0,0 +1,103 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package exec
+
+import (
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// ProtocolVersion is the version of the plugin protocol,
+// it is increased on every incompatible change
+const ProtocolVersion = 1
+
+// methods of the plugin protocol
+const (
+	MethodRepositoryExists = "repository_exists"
+	MethodNewTagURL        = "new_tag_url"
+	MethodTags             = "tags"
+	MethodIssues           = "issues"
+	MethodMRs              = "mrs"
+)
+
+// message types of the plugin protocol
+const (
+	MessageHandshake = "handshake"
+	MessageMax       = "max"
+	MessageProgress  = "progress"
+	MessageTag       = "tag"
+	MessageIssue     = "issue"
+	MessageMR        = "mr"
+	MessageResult    = "result"
+	MessageError     = "error"
+)
+
+// Request is sent by chagen to the plugin.
+//
+// The plugin is started for every request, it reads exactly one JSON line
+// from stdin, afterwards stdin is closed:
+//
+//	{"protocol": 1, "method": "tags", "options": {"project": "foo"}}
+//
+// options contains the values of --exec-option key=value flags,
+// tag is set only for the method new_tag_url.
+//
+// The plugin answers with JSON lines (Message) on stdout, the first line
+// is always the handshake with the protocol version of plugin:
+//
+//	{"type": "handshake", "protocol": 1}
+//
+// The methods repository_exists and new_tag_url are answered with a result:
+//
+//	{"type": "result", "exists": true}
+//	{"type": "result", "url": "https://example.com/releases/v0.1.0"}
+//
+// The methods tags, issues and mrs stream the data, the objects have
+// the same format like in the data snapshots of export command.
+// Optionally the max amount of objects and the progress ticks can be sent
+// to show the progress of fetching:
+//
+//	{"type": "max", "max": 2}
+//	{"type": "tag", "tag": {"name": "v0.1.0", "date": "2019-03-18T10:00:00Z", "url": "..."}}
+//	{"type": "progress"}
+//	{"type": "issue", "issue": {"id": 12, "name": "Title", "closed_date": "...", "url": "..."}}
+//	{"type": "mr", "mr": {"id": 13, "name": "Title", "merged_date": "...", "url": "..."}}
+//
+// Errors are reported with an error message, the request is finished then:
+//
+//	{"type": "error", "error": "project foo not found"}
+//
+// The request is finished, when the plugin closes stdout and exits with code 0.
+// Everything written to stderr is shown in the error messages.
+type Request struct {
+	Protocol int               `json:"protocol"`
+	Method   string            `json:"method"`
+	Tag      string            `json:"tag,omitempty"`
+	Options  map[string]string `json:"options,omitempty"`
+}
+
+// Message is sent by the plugin to chagen, see Request for the details
+type Message struct {
+	Type     string      `json:"type"`
+	Protocol int         `json:"protocol,omitempty"`
+	Max      int         `json:"max,omitempty"`
+	Tag      *data.Tag   `json:"tag,omitempty"`
+	Issue    *data.Issue `json:"issue,omitempty"`
+	MR       *data.MR    `json:"mr,omitempty"`
+	Exists   bool        `json:"exists,omitempty"`
+	URL      string      `json:"url,omitempty"`
+	Error    string      `json:"error,omitempty"`
+}
@@ -0,0 +1,115 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package exec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// Plugin is implemented by the plugins written in Go,
+// Serve implements the protocol for them.
+// options are the values of --exec-option flags
+type Plugin interface {
+	RepositoryExists(options map[string]string) (bool, error)
+	NewTagURL(options map[string]string, tag string) (string, error)
+	Tags(options map[string]string) (data.Tags, error)
+	Issues(options map[string]string) (data.Issues, error)
+	MRs(options map[string]string) (data.MRs, error)
+}
+
+// Serve reads the request of chagen from r and writes the answer of plugin p to w.
+// The errors of plugin are sent to chagen, only the errors of
+// reading and writing are returned
+func Serve(r io.Reader, w io.Writer, p Plugin) error {
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(Message{Type: MessageHandshake, Protocol: ProtocolVersion}); err != nil {
+		return err
+	}
+
+	var req Request
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return enc.Encode(Message{Type: MessageError, Error: fmt.Sprintf("can't parse the request: %v", err)})
+	}
+
+	msgs, err := answer(req, p)
+	if err != nil {
+		msgs = append(msgs, Message{Type: MessageError, Error: err.Error()})
+	}
+	for _, m := range msgs {
+		if err := enc.Encode(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// answer returns the messages for given request
+func answer(req Request, p Plugin) ([]Message, error) { // nolint: gocyclo
+	if req.Protocol != ProtocolVersion {
+		return nil, fmt.Errorf("unsupported protocol version: %v", req.Protocol)
+	}
+
+	var msgs []Message
+	switch req.Method {
+	case MethodRepositoryExists:
+		exists, err := p.RepositoryExists(req.Options)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, Message{Type: MessageResult, Exists: exists})
+	case MethodNewTagURL:
+		url, err := p.NewTagURL(req.Options, req.Tag)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, Message{Type: MessageResult, URL: url})
+	case MethodTags:
+		tags, err := p.Tags(req.Options)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, Message{Type: MessageMax, Max: len(tags)})
+		for i := range tags {
+			msgs = append(msgs, Message{Type: MessageTag, Tag: &tags[i]}, Message{Type: MessageProgress})
+		}
+	case MethodIssues:
+		issues, err := p.Issues(req.Options)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, Message{Type: MessageMax, Max: len(issues)})
+		for i := range issues {
+			msgs = append(msgs, Message{Type: MessageIssue, Issue: &issues[i]}, Message{Type: MessageProgress})
+		}
+	case MethodMRs:
+		mrs, err := p.MRs(req.Options)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, Message{Type: MessageMax, Max: len(mrs)})
+		for i := range mrs {
+			msgs = append(msgs, Message{Type: MessageMR, MR: &mrs[i]}, Message{Type: MessageProgress})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported method: %v", req.Method)
+	}
+	return msgs, nil
+}
@@ -0,0 +1,95 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package exec
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/file"
+)
+
+// SnapshotPlugin is the reference plugin, which serves the data snapshot
+// created by the export command. The path to the snapshot is
+// configured via --exec-option snapshot=<path>
+type SnapshotPlugin struct{}
+
+// read reads the configured snapshot
+func (SnapshotPlugin) read(options map[string]string) (*file.Snapshot, error) {
+	path := options["snapshot"]
+	if path == "" {
+		return nil, errors.New("option snapshot is required")
+	}
+
+	f, err := os.Open(path) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint: errcheck
+
+	return file.ReadSnapshot(f)
+}
+
+// RepositoryExists implements the Plugin interface,
+// the repository exists if the snapshot can be read
+func (p SnapshotPlugin) RepositoryExists(options map[string]string) (bool, error) {
+	if _, err := p.read(options); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// NewTagURL implements the Plugin interface
+func (p SnapshotPlugin) NewTagURL(options map[string]string, tag string) (string, error) {
+	s, err := p.read(options)
+	if err != nil {
+		return "", err
+	}
+	if s.NewTagURL == "" {
+		return "", errors.New("data snapshot does not provide the URL for new tags")
+	}
+	return strings.Replace(s.NewTagURL, file.NewTagPlaceholder, tag, -1), nil
+}
+
+// Tags implements the Plugin interface
+func (p SnapshotPlugin) Tags(options map[string]string) (data.Tags, error) {
+	s, err := p.read(options)
+	if err != nil {
+		return nil, err
+	}
+	return s.Tags, nil
+}
+
+// Issues implements the Plugin interface
+func (p SnapshotPlugin) Issues(options map[string]string) (data.Issues, error) {
+	s, err := p.read(options)
+	if err != nil {
+		return nil, err
+	}
+	return s.Issues, nil
+}
+
+// MRs implements the Plugin interface
+func (p SnapshotPlugin) MRs(options map[string]string) (data.MRs, error) {
+	s, err := p.read(options)
+	if err != nil {
+		return nil, err
+	}
+	return s.MRs, nil
+}
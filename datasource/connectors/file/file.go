@@ -0,0 +1,165 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package file implements the connector, which reads
+// the data snapshots created by the export command
+package file
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+
+	"github.com/urfave/cli"
+)
+
+// Connector implements the connector to the data snapshot
+type Connector struct {
+	snapshot *Snapshot
+}
+
+// RepositoryExists checks if referenced repository is present,
+// the snapshot was already read in New, so its always present
+func (c *Connector) RepositoryExists() (bool, error) {
+	return true, nil
+}
+
+// Tags implements the connectors.Connector interface
+func (c *Connector) Tags(
+	ctx context.Context,
+	_ chan<- error,
+) (
+	<-chan data.Tag,
+	<-chan bool,
+	<-chan int,
+) {
+	ctags := make(chan data.Tag)
+
+	go func() {
+		defer close(ctags)
+
+		for _, t := range c.snapshot.Tags {
+			select {
+			case <-ctx.Done():
+				return
+			case ctags <- t:
+			}
+		}
+	}()
+
+	return ctags, nil, nil
+}
+
+// Issues implements the connectors.Connector interface
+func (c *Connector) Issues(
+	ctx context.Context,
+	_ chan<- error,
+) (
+	<-chan data.Issue,
+	<-chan bool,
+	<-chan int,
+) {
+	cissues := make(chan data.Issue)
+
+	go func() {
+		defer close(cissues)
+
+		for _, i := range c.snapshot.Issues {
+			select {
+			case <-ctx.Done():
+				return
+			case cissues <- i:
+			}
+		}
+	}()
+
+	return cissues, nil, nil
+}
+
+// MRs implements the connectors.Connector interface
+func (c *Connector) MRs(
+	ctx context.Context,
+	_ chan<- error,
+) (
+	<-chan data.MR,
+	<-chan bool,
+	<-chan int,
+) {
+	cmrs := make(chan data.MR)
+
+	go func() {
+		defer close(cmrs)
+
+		for _, m := range c.snapshot.MRs {
+			select {
+			case <-ctx.Done():
+				return
+			case cmrs <- m:
+			}
+		}
+	}()
+
+	return cmrs, nil, nil
+}
+
+// GetNewTagURL returns the URL for a new tag, which does not exist yet
+func (c *Connector) GetNewTagURL(TagName string) (string, error) {
+	if c.snapshot.NewTagURL == "" {
+		return "", errors.New("data snapshot does not provide the URL for new tags")
+	}
+	return strings.Replace(c.snapshot.NewTagURL, NewTagPlaceholder, TagName, -1), nil
+}
+
+// New returns a new Connector, which reads the configured data snapshot
+func New(ctx *cli.Context) (connectors.Connector, error) {
+	path := ctx.String("file-snapshot")
+	if path == "" {
+		return nil, errors.New("option --file-snapshot is required")
+	}
+
+	f, err := os.Open(path) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint: errcheck
+
+	s, err := ReadSnapshot(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Connector{snapshot: s}, nil
+}
+
+// CLIFlags returns the possible CLI flags for this connector
+func CLIFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "file-snapshot",
+			Usage: "Path to the data snapshot created by the export command",
+			Value: "data.json",
+		},
+	}
+}
+
+func init() { // nolint: gochecknoinits
+	connectors.RegisterConnector("file", "File", New, CLIFlags)
+	connectors.RegisterConnectorDetails("file", nil, &Connector{})
+}
@@ -0,0 +1,186 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package file_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource"
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/file"
+	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+	"github.com/artem-sidorenko/chagen/internal/testing/testdata"
+)
+
+// writeTestFile writes the given content to a temporary file and returns its path
+func writeTestFile(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "chagen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "data.json")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// writeTestSnapshot writes the testdata as snapshot and returns its path
+func writeTestSnapshot(t *testing.T) string {
+	buf := &bytes.Buffer{}
+	err := file.WriteSnapshot(
+		buf,
+		"https://test.example.com/releases/"+file.NewTagPlaceholder,
+		testdata.DataTags(),
+		testdata.DataIssues(),
+		testdata.DataMRs(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writeTestFile(t, buf.String())
+}
+
+func newConnector(path string) (connectors.Connector, error) {
+	return file.New(tcli.TestContext(file.CLIFlags(), map[string]string{"file-snapshot": path}))
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		path    string
+		wantErr error
+	}{
+		{
+			name:    "Missing option",
+			wantErr: errors.New("option --file-snapshot is required"),
+		},
+		{
+			name:    "Broken JSON",
+			content: `{"schema_version": 1`,
+			wantErr: errors.New("can't parse the data snapshot: unexpected EOF"),
+		},
+		{
+			name:    "Unsupported schema version",
+			content: `{"schema_version": 2}`,
+			wantErr: errors.New("unsupported schema version of data snapshot: 2"),
+		},
+		{
+			name:    "Proper snapshot",
+			content: `{"schema_version": 1, "tags": [], "issues": [], "mrs": []}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := ""
+			if tt.content != "" {
+				path = writeTestFile(t, tt.content)
+				defer os.RemoveAll(filepath.Dir(path)) // nolint: errcheck
+			}
+
+			_, err := newConnector(path)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewMissingFile(t *testing.T) {
+	if _, err := newConnector("/nonexistent/data.json"); err == nil {
+		t.Error("New() expected error for missing file")
+	}
+}
+
+func TestConnector_Data(t *testing.T) {
+	path := writeTestSnapshot(t)
+	defer os.RemoveAll(filepath.Dir(path)) // nolint: errcheck
+
+	conn, err := newConnector(path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tags, issues, mrs, err := datasource.GetConnectorData(conn, &datasource.Options{}, ioutil.Discard)
+	if err != nil {
+		t.Fatalf("GetConnectorData() error = %v", err)
+	}
+
+	wantTags, wantIssues, wantMRs := data.Tags(testdata.DataTags()),
+		data.Issues(testdata.DataIssues()), data.MRs(testdata.DataMRs())
+	// JSON provides the dates always in UTC
+	data.UTCDate(wantTags, wantIssues, wantMRs)
+	// the pipeline marks the returning authors
+	data.MarkReturningAuthors(wantMRs)
+
+	if !reflect.DeepEqual(tags, wantTags) {
+		t.Errorf("Tags = %+v, want %+v", tags, wantTags)
+	}
+	if !reflect.DeepEqual(issues, wantIssues) {
+		t.Errorf("Issues = %+v, want %+v", issues, wantIssues)
+	}
+	if !reflect.DeepEqual(mrs, wantMRs) {
+		t.Errorf("MRs = %+v, want %+v", mrs, wantMRs)
+	}
+}
+
+func TestConnector_GetNewTagURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "URL with placeholder",
+			content: `{"schema_version": 1, "new_tag_url": "https://example.com/tags/CHAGEN-NEW-TAG"}`,
+			want:    "https://example.com/tags/v1.0.0",
+		},
+		{
+			name:    "Missing URL",
+			content: `{"schema_version": 1}`,
+			wantErr: errors.New("data snapshot does not provide the URL for new tags"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTestFile(t, tt.content)
+			defer os.RemoveAll(filepath.Dir(path)) // nolint: errcheck
+
+			conn, err := newConnector(path)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			got, err := conn.GetNewTagURL("v1.0.0")
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("GetNewTagURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetNewTagURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// SchemaVersion is the version of the snapshot format,
+// it is increased on every incompatible change
+const SchemaVersion = 1
+
+// NewTagPlaceholder is used in Snapshot.NewTagURL instead of the tag name
+const NewTagPlaceholder = "CHAGEN-NEW-TAG"
+
+// Snapshot describes the data of a repository, which is exported to JSON.
+//
+// The JSON document of schema version 1 looks like:
+//
+//	{
+//	  "schema_version": 1,
+//	  "new_tag_url": "https://github.com/owner/repo/releases/CHAGEN-NEW-TAG",
+//	  "tags": [
+//	    {
+//	      "name": "v0.1.0",
+//	      "commit": "<SHA of tagged commit, optional>",
+//	      "date": "2019-03-18T10:00:00Z",
+//	      "url": "https://github.com/owner/repo/releases/v0.1.0",
+//	      "title": "<name of hosted release, optional>",
+//	      "description": "<description of release, optional>",
+//	      "prerelease": false,
+//	      "draft": false
+//	    }
+//	  ],
+//	  "issues": [
+//	    {
+//	      "id": 12,
+//	      "name": "Title of issue",
+//	      "closed_date": "2019-03-17T10:00:00Z",
+//	      "url": "https://github.com/owner/repo/issues/12",
+//	      "labels": ["bug"],
+//	      "closed_by": [13],
+//	      "close_reason": "completed"
+//	    }
+//	  ],
+//	  "mrs": [
+//	    {
+//	      "id": 13,
+//	      "name": "Title of MR/PR",
+//	      "url": "https://github.com/owner/repo/pull/13",
+//	      "author": "login",
+//	      "author_url": "https://github.com/login",
+//	      "merged_date": "2019-03-17T09:00:00Z",
+//	      "labels": ["bugfix"],
+//	      "description": "Fixes #12",
+//	      "closes": [12]
+//	    }
+//	  ]
+//	}
+//
+// Dates are in RFC 3339 format, labels, closed_by, close_reason,
+// description and closes are optional.
+// The issues of external trackers like Jira have the field key (e.g. "ABC-12")
+// and the id 0, MRs reference them via the optional issue_keys.
+// new_tag_url is used for the new releases, CHAGEN-NEW-TAG is replaced with the release name
+type Snapshot struct {
+	SchemaVersion int         `json:"schema_version"`
+	NewTagURL     string      `json:"new_tag_url,omitempty"`
+	Tags          data.Tags   `json:"tags"`
+	Issues        data.Issues `json:"issues"`
+	MRs           data.MRs    `json:"mrs"`
+}
+
+// WriteSnapshot writes the given data as JSON snapshot to wr
+func WriteSnapshot(
+	wr io.Writer,
+	newTagURL string,
+	tags data.Tags,
+	issues data.Issues,
+	mrs data.MRs,
+) error {
+	s := Snapshot{
+		SchemaVersion: SchemaVersion,
+		NewTagURL:     newTagURL,
+		Tags:          tags,
+		Issues:        issues,
+		MRs:           mrs,
+	}
+	// we want to have empty lists instead of null in the JSON
+	if s.Tags == nil {
+		s.Tags = data.Tags{}
+	}
+	if s.Issues == nil {
+		s.Issues = data.Issues{}
+	}
+	if s.MRs == nil {
+		s.MRs = data.MRs{}
+	}
+
+	enc := json.NewEncoder(wr)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// ReadSnapshot reads the JSON snapshot from r and verifies its schema version
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("can't parse the data snapshot: %v", err)
+	}
+
+	if s.SchemaVersion != SchemaVersion {
+		return nil, fmt.Errorf("unsupported schema version of data snapshot: %v", s.SchemaVersion)
+	}
+
+	return &s, nil
+}
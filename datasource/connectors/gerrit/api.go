@@ -0,0 +1,152 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// xssiPrefix is prepended by Gerrit to all JSON responses
+// as protection against cross site script inclusion
+const xssiPrefix = ")]}'"
+
+// timestampLayout is the format of Gerrit timestamps, they are always in UTC
+const timestampLayout = "2006-01-02 15:04:05.999999999"
+
+// apiTimestamp is the timestamp of Gerrit API
+type apiTimestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (t *apiTimestamp) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	ts, err := time.Parse(timestampLayout, s)
+	if err != nil {
+		return err
+	}
+	t.Time = ts.UTC()
+	return nil
+}
+
+// the API structures, only the needed fields are described
+
+type apiAccount struct {
+	AccountID int    `json:"_account_id"`
+	Name      string `json:"name"`
+	Username  string `json:"username"`
+}
+
+type apiGitPerson struct {
+	Date apiTimestamp `json:"date"`
+}
+
+type apiTag struct {
+	Ref      string        `json:"ref"`
+	Revision string        `json:"revision"`
+	Object   string        `json:"object"` // tagged commit of annotated tag
+	Message  string        `json:"message"`
+	Tagger   *apiGitPerson `json:"tagger"`
+}
+
+type apiCommit struct {
+	Author    apiGitPerson `json:"author"`
+	Committer apiGitPerson `json:"committer"`
+	Message   string       `json:"message"`
+}
+
+type apiRevision struct {
+	Commit *apiCommit             `json:"commit"`
+	Files  map[string]interface{} `json:"files"`
+}
+
+type apiChange struct {
+	Number          int                    `json:"_number"`
+	Project         string                 `json:"project"`
+	Subject         string                 `json:"subject"`
+	Topic           string                 `json:"topic"`
+	Hashtags        []string               `json:"hashtags"`
+	Owner           apiAccount             `json:"owner"`
+	Submitted       *apiTimestamp          `json:"submitted"`
+	CurrentRevision string                 `json:"current_revision"`
+	Revisions       map[string]apiRevision `json:"revisions"`
+	MoreChanges     bool                   `json:"_more_changes"`
+}
+
+// projectPath returns the API path of given project resource
+func (c *Connector) projectPath(resource string) string {
+	// the slashes in the project names are escaped too
+	return fmt.Sprintf("/projects/%s%s", url.PathEscape(c.Project), resource)
+}
+
+// get requests the given API path and decodes the JSON response to v.
+// The authenticated API below /a/ is used, if the credentials are given.
+// The response is returned for the inspection of status code
+func (c *Connector) get(
+	ctx context.Context,
+	path string,
+	query url.Values,
+	v interface{},
+) (*http.Response, error) {
+	u := c.BaseURL
+	if c.User != "" {
+		u += "/a"
+	}
+	u += path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	if c.User != "" {
+		req.SetBasicAuth(c.User, c.Token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, fmt.Errorf("GET %v: %v", path, resp.Status)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp, err
+	}
+	return resp, json.Unmarshal(stripXSSIPrefix(body), v)
+}
+
+// stripXSSIPrefix removes the XSSI protection prefix from the Gerrit response
+func stripXSSIPrefix(body []byte) []byte {
+	return bytes.TrimPrefix(bytes.TrimLeft(body, " \r\n"), []byte(xssiPrefix))
+}
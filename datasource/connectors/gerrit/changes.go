@@ -0,0 +1,152 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// ChangesPerPage defined how many changes are fetched per page
+var ChangesPerPage = 50 // nolint: gochecknoglobals
+
+// MRs returns the merged changes via channels.
+// Returns possible errors via given cerr channel
+// cmrs returns changes
+// cmrscounter returns the channel, which ticks when a change is proceeded
+// cmaxmrs returns the max available amount of changes
+func (c *Connector) MRs(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	cmrs <-chan data.MR,
+	cmrscounter <-chan bool,
+	cmaxmrs <-chan int,
+) {
+	mrs := make(chan data.MR)
+	maxmrs := make(chan int, 1)
+	mrscounter := make(chan bool, 100)
+
+	go func() {
+		defer close(mrs)
+		defer close(mrscounter)
+		defer close(maxmrs)
+
+		query := url.Values{
+			"q": {fmt.Sprintf("status:merged project:%s", c.Project)},
+			"n": {strconv.Itoa(ChangesPerPage)},
+			"o": {"CURRENT_REVISION", "CURRENT_COMMIT", "DETAILED_ACCOUNTS"},
+		}
+		if c.FetchFiles {
+			query.Add("o", "CURRENT_FILES")
+		}
+
+		// the changes are paged via skip, the cursor is the amount of changes to skip
+		helpers.FetchCursorPages(ctx, cerr, maxmrs,
+			func(ctx context.Context, cursor string) (helpers.CursorPage, error) {
+				q := copyValues(query)
+				if cursor != "" {
+					q.Set("S", cursor)
+				}
+				var changes []apiChange
+				if _, err := c.get(ctx, "/changes/", q, &changes); err != nil {
+					return helpers.CursorPage{}, formatErrorCode("MRs", err)
+				}
+
+				for _, ch := range changes {
+					select {
+					case <-ctx.Done():
+						return helpers.CursorPage{}, ctx.Err()
+					case mrs <- c.mr(ch):
+						mrscounter <- true
+					}
+				}
+
+				p := helpers.CursorPage{Items: len(changes)}
+				// Gerrit marks the last change of page, if there are further changes
+				if len(changes) > 0 && changes[len(changes)-1].MoreChanges {
+					skip, _ := strconv.Atoi(cursor) // nolint: gosec
+					p.Next = strconv.Itoa(skip + len(changes))
+				}
+				return p, nil
+			})
+	}()
+
+	return mrs, mrscounter, maxmrs
+}
+
+// mr converts the merged Gerrit change to our data structure.
+// The body of commit message is used as description
+func (c *Connector) mr(ch apiChange) data.MR {
+	owner := ch.Owner.Username
+	if owner == "" {
+		owner = strconv.Itoa(ch.Owner.AccountID)
+	}
+	ret := data.MR{
+		ID:          ch.Number,
+		Name:        ch.Subject,
+		URL:         c.changeURL(ch.Number),
+		Author:      owner,
+		AuthorURL:   c.ownerURL(owner),
+		Labels:      ch.Hashtags,
+		MergeCommit: ch.CurrentRevision,
+	}
+	if ch.Owner.Username == "" && ch.Owner.Name != "" {
+		ret.Author = ch.Owner.Name
+	}
+	if ch.Submitted != nil {
+		ret.MergedDate = ch.Submitted.Time
+	}
+
+	rev := ch.Revisions[ch.CurrentRevision]
+	if rev.Commit != nil {
+		parts := strings.SplitN(rev.Commit.Message, "\n", 2)
+		if len(parts) == 2 {
+			ret.Description = strings.TrimSpace(parts[1])
+		}
+	}
+	ret.IssueKeys = data.ParseIssueKeys(ch.Subject, ret.Description, ch.Topic)
+
+	if c.FetchFiles {
+		for f := range rev.Files {
+			// skip the magic files like /COMMIT_MSG
+			if !strings.HasPrefix(f, "/") {
+				ret.Files = append(ret.Files, f)
+			}
+		}
+		sort.Strings(ret.Files)
+	}
+	return ret
+}
+
+// copyValues returns a copy of given query values,
+// so they can be modified for every page
+func copyValues(v url.Values) url.Values {
+	ret := url.Values{}
+	for k, vs := range v {
+		ret[k] = append([]string(nil), vs...)
+	}
+	return ret
+}
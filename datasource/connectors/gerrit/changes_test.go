@@ -0,0 +1,121 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gerrit_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/internal/testing/helpers"
+)
+
+func TestConnector_MRs(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name      string
+		flags     map[string]string
+		wantFiles [][]string
+	}{
+		{
+			name:      "Merged changes",
+			wantFiles: [][]string{nil, nil, nil},
+		},
+		{
+			name:  "Merged changes with files",
+			flags: map[string]string{"path": "config"},
+			wantFiles: [][]string{
+				{"config/config.go", "config/config_test.go"},
+				{"web/login.go"},
+				{"README.md"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newTestConnector(srv.URL, "platform/tools", tt.flags)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			cerr := make(chan error, 1)
+
+			cgot, _, cmaxmrs := c.MRs(context.Background(), cerr)
+
+			var got data.MRs
+			for m := range cgot {
+				got = append(got, m)
+			}
+			gotmaxmrs := helpers.GetChannelValuesInt(cmaxmrs)
+
+			select {
+			case err := <-cerr:
+				t.Fatalf("Connector.MRs() error = %v", err)
+			default:
+			}
+
+			want := data.MRs{
+				{
+					ID:          1042,
+					Name:        "Fix crash on empty config",
+					URL:         srv.URL + "/c/platform/tools/+/1042",
+					Author:      "contributor",
+					AuthorURL:   srv.URL + "/q/owner:contributor%20status:merged",
+					MergedDate:  time.Date(2019, 3, 18, 7, 30, 0, 0, time.UTC),
+					Labels:      []string{"bugfix"},
+					Description: "The empty config is handled now.\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940", // nolint: lll
+					Files:       tt.wantFiles[0],
+					MergeCommit: "8f2b0e4c6a1d3f5b7c9e0a2d4f6b8c0e1a3d5f7b",
+					IssueKeys:   []string{"PROJ-12"},
+				},
+				{
+					ID:          1040,
+					Name:        "Add login page",
+					URL:         srv.URL + "/c/platform/tools/+/1040",
+					Author:      "Jane Doe",
+					AuthorURL:   srv.URL + "/q/owner:1000097%20status:merged",
+					MergedDate:  time.Date(2019, 3, 16, 12, 0, 0, 0, time.UTC),
+					Description: "Change-Id: I0f8a7b6c5d4e3f2a1b0c9d8e7f6a5b4c3d2e1f0a",
+					Files:       tt.wantFiles[1],
+					MergeCommit: "1a3c5e7b9d0f2a4c6e8b0d1f3a5c7e9b2d4f6a8c",
+				},
+				{
+					ID:          1001,
+					Name:        "Initial commit",
+					URL:         srv.URL + "/c/platform/tools/+/1001",
+					Author:      "maintainer",
+					AuthorURL:   srv.URL + "/q/owner:maintainer%20status:merged",
+					MergedDate:  time.Date(2019, 3, 15, 10, 0, 0, 0, time.UTC),
+					Description: "See PROJ-1 for details\n\nChange-Id: I1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b",
+					Files:       tt.wantFiles[2],
+					MergeCommit: "2b4d6f8a0c1e3b5d7f9a1c2e4b6d8f0a3c5e7b9d",
+					IssueKeys:   []string{"PROJ-1"},
+				},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Connector.MRs() = %+v, want %+v", got, want)
+			}
+			// the amount of changes is known after the last page
+			if !reflect.DeepEqual(gotmaxmrs, []int{3}) {
+				t.Errorf("Connector.MRs() maxmrs = %v, want %v", gotmaxmrs, []int{3})
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package gerrit implements the connector for Gerrit Code Review,
+// the merged changes are used as MRs
+package gerrit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+
+	"github.com/urfave/cli"
+)
+
+// AccessTokenEnvVar contains the name of environment variable
+// which sets the HTTP password of user
+const AccessTokenEnvVar = "CHAGEN_GERRIT_TOKEN" // nolint: gosec
+
+// UserEnvVar contains the name of environment variable which sets the user name,
+// the anonymous access is used if it is not set
+const UserEnvVar = "CHAGEN_GERRIT_USER"
+
+// Connector implements the Gerrit connector
+type Connector struct {
+	context       context.Context
+	client        *http.Client
+	BaseURL       string
+	User          string
+	Token         string
+	Project       string
+	TagDateSource connectors.TagDateSource
+	FetchFiles    bool
+}
+
+// RepositoryExists checks if referenced project is present
+func (c *Connector) RepositoryExists() (bool, error) {
+	var project struct{}
+	resp, err := c.get(c.context, c.projectPath(""), nil, &project)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound { // not found isn't an error
+			return false, nil
+		}
+		return false, formatErrorCode("RepositoryExists", err)
+	}
+	return true, nil
+}
+
+// New returns a new initialized Connector or error if any
+func New(ctx *cli.Context) (connectors.Connector, error) {
+	baseURL := strings.TrimSuffix(ctx.String("gerrit-url"), "/")
+	if baseURL == "" {
+		return nil, errors.New("option --gerrit-url is required")
+	}
+	project := ctx.String("gerrit-project")
+	if project == "" {
+		return nil, errors.New("option --gerrit-project is required")
+	}
+	tagDateSource, err := connectors.GetTagDateSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Gerrit has no releases
+	if tagDateSource == connectors.TagDateRelease {
+		return nil, fmt.Errorf("tag date source %v is not supported by Gerrit", tagDateSource)
+	}
+
+	return &Connector{
+		context:       context.Background(),
+		client:        http.DefaultClient,
+		BaseURL:       baseURL,
+		User:          os.Getenv(UserEnvVar),
+		Token:         os.Getenv(AccessTokenEnvVar),
+		Project:       project,
+		TagDateSource: tagDateSource,
+		FetchFiles:    connectors.FetchMRFiles(ctx),
+	}, nil
+}
+
+// CLIFlags returns the possible CLI flags for this connector
+func CLIFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "gerrit-url",
+			Usage: "Base URL of the Gerrit instance, e.g. https://review.example.com",
+		},
+		cli.StringFlag{
+			Name:  "gerrit-project",
+			Usage: "Name of Gerrit project, e.g. platform/tools",
+		},
+	}
+}
+
+func init() { // nolint: gochecknoinits
+	connectors.RegisterConnector("gerrit", "Gerrit", New, CLIFlags)
+	connectors.RegisterConnectorDetails("gerrit",
+		[]string{AccessTokenEnvVar, UserEnvVar}, &Connector{})
+}
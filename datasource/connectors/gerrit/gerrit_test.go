@@ -0,0 +1,210 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gerrit_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/gerrit"
+	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+)
+
+const (
+	testUser  = "testuser"
+	testToken = "testtoken"
+)
+
+// recorded returns the path of recorded API response
+func recorded(name string) string {
+	return filepath.Join("testdata", name+".json")
+}
+
+// newTestServer returns the server, which replays the recorded API responses
+// of platform/tools project from testdata. The file name is derived from the path
+// and the skip parameter, e.g. /a/changes/?S=2 is served from changes_skip2.json.
+// The recorded responses contain the XSSI protection prefix like the real ones
+func newTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != testUser || pass != testToken {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		path := r.URL.EscapedPath()
+		var name string
+		switch {
+		case path == "/a/changes/":
+			name = "changes"
+		case strings.HasPrefix(path, "/a/projects/platform%2Ftools"):
+			name = "project"
+			if resource := strings.Trim(strings.TrimPrefix(path, "/a/projects/platform%2Ftools"), "/"); resource != "" {
+				name = strings.Replace(resource, "/", "_", -1)
+			}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("n") != "" {
+			skip := r.URL.Query().Get("S")
+			if skip == "" {
+				skip = "0"
+			}
+			name += "_skip" + skip
+		}
+
+		content, err := ioutil.ReadFile(recorded(name))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content) // nolint: errcheck, gosec
+	}))
+}
+
+// newTestConnector returns the connector configured for the test server
+func newTestConnector(url, project string, flags map[string]string) (*gerrit.Connector, error) {
+	os.Setenv(gerrit.UserEnvVar, testUser)         // nolint: errcheck, gosec
+	os.Setenv(gerrit.AccessTokenEnvVar, testToken) // nolint: errcheck, gosec
+	defer os.Unsetenv(gerrit.UserEnvVar)           // nolint: errcheck
+	defer os.Unsetenv(gerrit.AccessTokenEnvVar)    // nolint: errcheck
+
+	cliFlags := map[string]string{
+		"gerrit-url":     url,
+		"gerrit-project": project,
+	}
+	for k, v := range flags {
+		cliFlags[k] = v
+	}
+	conn, err := gerrit.New(tcli.TestContext(
+		append(gerrit.CLIFlags(), connectors.CommonCLIFlags()...),
+		cliFlags,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return conn.(*gerrit.Connector), nil
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		want    *gerrit.Connector
+		wantErr error
+	}{
+		{
+			name:  "All options",
+			flags: map[string]string{"tag-date-source": "tag"},
+			want: &gerrit.Connector{
+				BaseURL:       "https://review.example.com",
+				User:          testUser,
+				Token:         testToken,
+				Project:       "platform/tools",
+				TagDateSource: connectors.TagDateTag,
+			},
+		},
+		{
+			name:    "Missing URL",
+			flags:   map[string]string{"gerrit-url": ""},
+			wantErr: errors.New("option --gerrit-url is required"),
+		},
+		{
+			name:    "Missing project",
+			flags:   map[string]string{"gerrit-project": ""},
+			wantErr: errors.New("option --gerrit-project is required"),
+		},
+		{
+			name:    "Unsupported tag date source",
+			flags:   map[string]string{"tag-date-source": "release"},
+			wantErr: errors.New("tag date source release is not supported by Gerrit"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newTestConnector("https://review.example.com/", "platform/tools", tt.flags)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if c.BaseURL != tt.want.BaseURL || c.User != tt.want.User ||
+				c.Token != tt.want.Token || c.Project != tt.want.Project ||
+				c.TagDateSource != tt.want.TagDateSource {
+				t.Errorf("New() = %+v, want %+v", c, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnector_RepositoryExists(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		project string
+		token   string
+		want    bool
+		wantErr error
+	}{
+		{
+			name:    "Existing project",
+			project: "platform/tools",
+			want:    true,
+		},
+		{
+			name:    "Missing project",
+			project: "platform/missing",
+		},
+		{
+			name:    "Wrong password",
+			project: "platform/tools",
+			token:   "wrongtoken",
+			wantErr: errors.New("Gerrit query 'RepositoryExists' failed: GET /projects/platform%2Ftools: 401 Unauthorized"), // nolint: lll
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newTestConnector(srv.URL, tt.project, nil)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if tt.token != "" {
+				c.Token = tt.token
+			}
+
+			got, err := c.RepositoryExists()
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("RepositoryExists() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RepositoryExists() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
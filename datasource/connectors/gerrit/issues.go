@@ -0,0 +1,54 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// Issues returns no issues, as Gerrit has no issue tracker.
+// The keys of issues in external trackers are provided by MRs
+// Returns possible errors via given cerr channel
+// cissues returns issues
+// cissuescounter returns the channel, which ticks when an issue is proceeded
+// cmaxissues returns the max available amount of issues
+func (c *Connector) Issues(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	cissues <-chan data.Issue,
+	cissuescounter <-chan bool,
+	cmaxissues <-chan int,
+) {
+	issues := make(chan data.Issue)
+	maxissues := make(chan int, 1)
+	issuescounter := make(chan bool, 100)
+
+	go func() {
+		defer close(issues)
+		defer close(issuescounter)
+		defer close(maxissues)
+
+		helpers.NonBlockingMaxSend(ctx, maxissues, 0)
+	}()
+
+	return issues, issuescounter, maxissues
+}
@@ -0,0 +1,126 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// TagsPerPage defined how many tags are fetched per page
+var TagsPerPage = 50 // nolint: gochecknoglobals
+
+// Tags returns the tags via channels.
+// Returns possible errors via given cerr channel
+// ctags returns tags
+// ctagscounter returns the channel, which ticks when a tag is proceeded
+// cmaxtags returns the max available amount of tags
+func (c *Connector) Tags(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	ctags <-chan data.Tag,
+	ctagscounter <-chan bool,
+	cmaxtags <-chan int,
+) {
+	tags := make(chan data.Tag)
+	maxtags := make(chan int, 1)
+	tagscounter := make(chan bool, 100)
+
+	go func() {
+		defer close(tags)
+		defer close(tagscounter)
+		defer close(maxtags)
+
+		// the tags are paged via skip, the cursor is the amount of tags to skip
+		helpers.FetchCursorPages(ctx, cerr, maxtags,
+			func(ctx context.Context, cursor string) (helpers.CursorPage, error) {
+				query := url.Values{"n": {strconv.Itoa(TagsPerPage)}}
+				if cursor != "" {
+					query.Set("S", cursor)
+				}
+				var rtags []apiTag
+				if _, err := c.get(ctx, c.projectPath("/tags/"), query, &rtags); err != nil {
+					return helpers.CursorPage{}, formatErrorCode("Tags", err)
+				}
+
+				for _, t := range rtags {
+					tag, err := c.tag(ctx, t)
+					if err != nil {
+						return helpers.CursorPage{}, formatErrorCode("Tags", err)
+					}
+					select {
+					case <-ctx.Done():
+						return helpers.CursorPage{}, ctx.Err()
+					case tags <- tag:
+						tagscounter <- true
+					}
+				}
+
+				p := helpers.CursorPage{Items: len(rtags)}
+				// a full page means, there might be further tags
+				if len(rtags) == TagsPerPage {
+					skip, _ := strconv.Atoi(cursor) // nolint: gosec
+					p.Next = strconv.Itoa(skip + len(rtags))
+				}
+				return p, nil
+			})
+	}()
+
+	return tags, tagscounter, maxtags
+}
+
+// tag converts the Gerrit tag to our data structure,
+// the tagged commit is fetched for the dates
+func (c *Connector) tag(ctx context.Context, t apiTag) (data.Tag, error) {
+	sha := t.Revision
+	// annotated tags reference the commit via object
+	if t.Object != "" {
+		sha = t.Object
+	}
+
+	var commit apiCommit
+	if _, err := c.get(ctx, c.projectPath("/commits/"+url.PathEscape(sha)), nil, &commit); err != nil {
+		return data.Tag{}, err
+	}
+
+	name := strings.TrimPrefix(t.Ref, "refs/tags/")
+	ret := data.Tag{
+		Name:        name,
+		Commit:      sha,
+		Date:        commit.Committer.Date.Time,
+		URL:         c.tagURL(name),
+		Description: strings.TrimSpace(t.Message),
+	}
+	switch c.TagDateSource {
+	case connectors.TagDateAuthor:
+		ret.Date = commit.Author.Date.Time
+	case connectors.TagDateTag:
+		// the committer date is used for lightweight tags
+		if t.Tagger != nil {
+			ret.Date = t.Tagger.Date.Time
+		}
+	}
+	return ret, nil
+}
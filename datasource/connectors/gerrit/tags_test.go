@@ -0,0 +1,121 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gerrit_test
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/gerrit"
+	"github.com/artem-sidorenko/chagen/internal/testing/helpers"
+)
+
+func TestConnector_Tags(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	gitiles := srv.URL + "/plugins/gitiles/platform/tools/+/refs/tags/"
+	tests := []struct {
+		name  string
+		flags map[string]string
+		dates []time.Time
+	}{
+		{
+			name: "Commit dates",
+			dates: []time.Time{
+				time.Date(2019, 3, 19, 9, 0, 0, 0, time.UTC),
+				time.Date(2019, 3, 18, 9, 0, 0, 0, time.UTC),
+				time.Date(2019, 3, 17, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "Author dates",
+			flags: map[string]string{"tag-date-source": "author"},
+			dates: []time.Time{
+				time.Date(2019, 3, 19, 8, 0, 0, 0, time.UTC),
+				time.Date(2019, 3, 18, 8, 0, 0, 0, time.UTC),
+				time.Date(2019, 3, 17, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "Tag dates with fallback for lightweight tags",
+			flags: map[string]string{"tag-date-source": "tag"},
+			dates: []time.Time{
+				time.Date(2019, 3, 20, 12, 0, 0, 0, time.UTC),
+				time.Date(2019, 3, 18, 9, 0, 0, 0, time.UTC),
+				time.Date(2019, 3, 17, 9, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gerrit.TagsPerPage = 2
+			c, err := newTestConnector(srv.URL, "platform/tools", tt.flags)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			cerr := make(chan error, 1)
+
+			cgot, _, cmaxtags := c.Tags(context.Background(), cerr)
+
+			var got data.Tags
+			for t := range cgot {
+				got = append(got, t)
+			}
+			gotmaxtags := helpers.GetChannelValuesInt(cmaxtags)
+			sort.Sort(&got)
+
+			select {
+			case err := <-cerr:
+				t.Fatalf("Connector.Tags() error = %v", err)
+			default:
+			}
+
+			want := data.Tags{
+				{
+					Name:        "v0.2.0",
+					Commit:      "8f2b0e4c6a1d3f5b7c9e0a2d4f6b8c0e1a3d5f7b",
+					Date:        tt.dates[0],
+					URL:         gitiles + "v0.2.0",
+					Description: "Release v0.2.0",
+				},
+				{
+					Name:   "v0.1.1",
+					Commit: "1a3c5e7b9d0f2a4c6e8b0d1f3a5c7e9b2d4f6a8c",
+					Date:   tt.dates[1],
+					URL:    gitiles + "v0.1.1",
+				},
+				{
+					Name:   "v0.1.0",
+					Commit: "2b4d6f8a0c1e3b5d7f9a1c2e4b6d8f0a3c5e7b9d",
+					Date:   tt.dates[2],
+					URL:    gitiles + "v0.1.0",
+				},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Connector.Tags() = %+v, want %+v", got, want)
+			}
+			if !reflect.DeepEqual(gotmaxtags, []int{3}) {
+				t.Errorf("Connector.Tags() maxtags = %v, want %v", gotmaxtags, []int{3})
+			}
+		})
+	}
+}
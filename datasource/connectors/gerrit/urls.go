@@ -0,0 +1,56 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gerrit
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// GetNewTagURL returns the URL for a new tag, which does not exist yet
+func (c *Connector) GetNewTagURL(TagName string) (string, error) {
+	return c.tagURL(TagName), nil
+}
+
+// CompareURL implements the connectors.CompareURLProvider interface
+func (c *Connector) CompareURL(from, to string) (string, error) {
+	return fmt.Sprintf("%s/+log/%s..%s", c.gitilesURL(), from, to), nil
+}
+
+// changeURL returns the URL of given change
+func (c *Connector) changeURL(number int) string {
+	return fmt.Sprintf("%s/c/%s/+/%d", c.BaseURL, strings.Trim(c.Project, "/"), number)
+}
+
+// ownerURL returns the URL of the search for merged changes of given owner,
+// Gerrit has no profile pages
+func (c *Connector) ownerURL(owner string) string {
+	return fmt.Sprintf("%s/q/%s", c.BaseURL, url.PathEscape("owner:"+owner+" status:merged"))
+}
+
+// tagURL returns the URL of the tag in Gitiles,
+// Gerrit itself has no pages for tags
+func (c *Connector) tagURL(tagName string) string {
+	return fmt.Sprintf("%s/+/refs/tags/%s", c.gitilesURL(), tagName)
+}
+
+// gitilesURL returns the URL of project in Gitiles,
+// which is used for the repository browsing
+func (c *Connector) gitilesURL() string {
+	return fmt.Sprintf("%s/plugins/gitiles/%s", c.BaseURL, strings.Trim(c.Project, "/"))
+}
@@ -0,0 +1,160 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+)
+
+// the API structures, only the needed fields are described
+
+type apiUser struct {
+	Login   string `json:"login"`
+	HTMLURL string `json:"html_url"`
+}
+
+type apiLabel struct {
+	Name string `json:"name"`
+}
+
+type apiTag struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA     string    `json:"sha"`
+		Created time.Time `json:"created"`
+	} `json:"commit"`
+}
+
+type apiIssue struct {
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	HTMLURL     string     `json:"html_url"`
+	ClosedAt    *time.Time `json:"closed_at"`
+	Labels      []apiLabel `json:"labels"`
+	PullRequest *struct{}  `json:"pull_request"`
+}
+
+type apiPullRequest struct {
+	Number         int         `json:"number"`
+	Title          string      `json:"title"`
+	HTMLURL        string      `json:"html_url"`
+	Body           string      `json:"body"`
+	User           apiUser     `json:"user"`
+	Labels         []apiLabel  `json:"labels"`
+	Merged         bool        `json:"merged"`
+	MergedAt       *time.Time  `json:"merged_at"`
+	MergeCommitSHA string      `json:"merge_commit_sha"`
+	Head           apiPRBranch `json:"head"`
+}
+
+type apiPRBranch struct {
+	Ref string `json:"ref"`
+}
+
+type apiChangedFile struct {
+	Filename string `json:"filename"`
+}
+
+// repoPath returns the API path of given repository resource
+func (c *Connector) repoPath(resource string) string {
+	return fmt.Sprintf("/repos/%s/%s%s", url.PathEscape(c.Owner), url.PathEscape(c.Repo), resource)
+}
+
+// get requests the given API path and decodes the JSON response to v.
+// The response is returned for the inspection of status code and headers
+func (c *Connector) get(
+	ctx context.Context,
+	path string,
+	query url.Values,
+	v interface{},
+) (*http.Response, error) {
+	u := c.BaseURL + "/api/v1" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "token "+c.Token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, fmt.Errorf("GET %v: %v", path, resp.Status)
+	}
+	return resp, json.NewDecoder(resp.Body).Decode(v)
+}
+
+// list requests the given page of API list and decodes it to v
+func (c *Connector) list(
+	ctx context.Context,
+	path string,
+	page, perPage int,
+	query url.Values,
+	v interface{},
+) (helpers.Page, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(perPage))
+
+	resp, err := c.get(ctx, path, query, v)
+	if err != nil {
+		return helpers.Page{}, err
+	}
+	return helpers.Page{LastPage: lastPage(resp.Header.Get("Link"))}, nil
+}
+
+// lastPage returns the number of last page from the Link header,
+// 0 is returned if there are no further pages
+func lastPage(link string) int {
+	for _, l := range strings.Split(link, ",") {
+		parts := strings.Split(l, ";")
+		if len(parts) < 2 || strings.TrimSpace(parts[1]) != `rel="last"` {
+			continue
+		}
+		u, err := url.Parse(strings.Trim(strings.TrimSpace(parts[0]), "<>"))
+		if err != nil {
+			return 0
+		}
+		page, err := strconv.Atoi(u.Query().Get("page"))
+		if err != nil {
+			return 0
+		}
+		return page
+	}
+	return 0
+}
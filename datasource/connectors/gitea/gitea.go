@@ -0,0 +1,123 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package gitea implements the connector for Gitea and Forgejo instances
+package gitea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+
+	"github.com/urfave/cli"
+)
+
+// AccessTokenEnvVar contains the name of environment variable
+// which sets the authentication access token
+const AccessTokenEnvVar = "CHAGEN_GITEA_TOKEN" // nolint: gosec
+
+const (
+	// pageRoutines defines how many pages are fetched in parallel
+	pageRoutines = 10
+)
+
+// Connector implements the Gitea/Forgejo connector
+type Connector struct {
+	context    context.Context
+	client     *http.Client
+	BaseURL    string
+	Token      string
+	Owner      string
+	Repo       string
+	ProjectURL string
+	FetchFiles bool
+}
+
+// RepositoryExists checks if referenced repository is present
+func (c *Connector) RepositoryExists() (bool, error) {
+	var repo struct{}
+	resp, err := c.get(c.context, c.repoPath(""), nil, &repo)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound { // not found isn't an error
+			return false, nil
+		}
+		return false, formatErrorCode("RepositoryExists", err)
+	}
+	return true, nil
+}
+
+// New returns a new initialized Connector or error if any
+func New(ctx *cli.Context) (connectors.Connector, error) {
+	baseURL := strings.TrimSuffix(ctx.String("gitea-url"), "/")
+	if baseURL == "" {
+		return nil, errors.New("option --gitea-url is required")
+	}
+	owner := ctx.String("gitea-owner")
+	if owner == "" {
+		return nil, errors.New("option --gitea-owner is required")
+	}
+	repo := ctx.String("gitea-repo")
+	if repo == "" {
+		return nil, errors.New("option --gitea-repo is required")
+	}
+	tagDateSource, err := connectors.GetTagDateSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Gitea API provides only the commit dates of tags
+	if tagDateSource != connectors.TagDateDefault && tagDateSource != connectors.TagDateCommit {
+		return nil, fmt.Errorf("tag date source %v is not supported by Gitea", tagDateSource)
+	}
+
+	return &Connector{
+		context:    context.Background(),
+		client:     http.DefaultClient,
+		BaseURL:    baseURL,
+		Token:      os.Getenv(AccessTokenEnvVar),
+		Owner:      owner,
+		Repo:       repo,
+		ProjectURL: fmt.Sprintf("%s/%s/%s", baseURL, owner, repo),
+		FetchFiles: connectors.FetchMRFiles(ctx),
+	}, nil
+}
+
+// CLIFlags returns the possible CLI flags for this connector
+func CLIFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "gitea-url",
+			Usage: "Base URL of the Gitea/Forgejo instance, e.g. https://codeberg.org",
+		},
+		cli.StringFlag{
+			Name:  "gitea-owner",
+			Usage: "Owner/organisation where repository belongs to",
+		},
+		cli.StringFlag{
+			Name:  "gitea-repo",
+			Usage: "Name of repository",
+		},
+	}
+}
+
+func init() { // nolint: gochecknoinits
+	connectors.RegisterConnector("gitea", "Gitea/Forgejo", New, CLIFlags)
+	connectors.RegisterConnectorDetails("gitea", []string{AccessTokenEnvVar}, &Connector{})
+}
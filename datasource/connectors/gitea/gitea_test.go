@@ -0,0 +1,213 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gitea_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/gitea"
+	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+)
+
+const testToken = "testtoken"
+
+// recorded returns the path of recorded API response
+func recorded(name string) string {
+	return filepath.Join("testdata", name+".json")
+}
+
+// newTestServer returns the server, which replays the recorded API responses of
+// testowner/testrepo from testdata. The file name is derived from the API path
+// and the page, e.g. /api/v1/repos/testowner/testrepo/tags?page=2 is served
+// from tags_page2.json. The Link header is set, if further pages are recorded
+func newTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token "+testToken {
+			http.Error(w, `{"message":"token is required"}`, http.StatusUnauthorized)
+			return
+		}
+
+		prefix := "/api/v1/repos/testowner/testrepo"
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			http.NotFound(w, r)
+			return
+		}
+		name := "repo"
+		if resource := strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/"); resource != "" {
+			name = strings.Replace(resource, "/", "_", -1)
+		}
+
+		if page, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
+			lastPage := 1
+			for {
+				if _, err := os.Stat(recorded(fmt.Sprintf("%s_page%d", name, lastPage+1))); err != nil {
+					break
+				}
+				lastPage++
+			}
+			if page < lastPage {
+				w.Header().Set("Link", fmt.Sprintf(`<%s?page=%d>; rel="next",<%s?page=%d>; rel="last"`,
+					r.URL.Path, page+1, r.URL.Path, lastPage))
+			}
+			name = fmt.Sprintf("%s_page%d", name, page)
+		}
+
+		content, err := ioutil.ReadFile(recorded(name))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content) // nolint: errcheck, gosec
+	}))
+}
+
+// newTestConnector returns the connector configured for the test server
+func newTestConnector(url, repo string, flags map[string]string) (connectors.Connector, error) {
+	os.Setenv(gitea.AccessTokenEnvVar, testToken) // nolint: errcheck, gosec
+	defer os.Unsetenv(gitea.AccessTokenEnvVar)    // nolint: errcheck
+
+	cliFlags := map[string]string{
+		"gitea-url":   url,
+		"gitea-owner": "testowner",
+		"gitea-repo":  repo,
+	}
+	for k, v := range flags {
+		cliFlags[k] = v
+	}
+	return gitea.New(tcli.TestContext(
+		append(gitea.CLIFlags(), connectors.CommonCLIFlags()...),
+		cliFlags,
+	))
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		want    *gitea.Connector
+		wantErr error
+	}{
+		{
+			name:  "All options",
+			flags: map[string]string{"gitea-url": "https://codeberg.org/"},
+			want: &gitea.Connector{
+				BaseURL:    "https://codeberg.org",
+				Token:      testToken,
+				Owner:      "testowner",
+				Repo:       "testrepo",
+				ProjectURL: "https://codeberg.org/testowner/testrepo",
+			},
+		},
+		{
+			name:    "Missing URL",
+			flags:   map[string]string{"gitea-url": ""},
+			wantErr: errors.New("option --gitea-url is required"),
+		},
+		{
+			name:    "Missing owner",
+			flags:   map[string]string{"gitea-owner": ""},
+			wantErr: errors.New("option --gitea-owner is required"),
+		},
+		{
+			name:    "Missing repo",
+			flags:   map[string]string{"gitea-repo": ""},
+			wantErr: errors.New("option --gitea-repo is required"),
+		},
+		{
+			name:    "Unsupported tag date source",
+			flags:   map[string]string{"tag-date-source": "tag"},
+			wantErr: errors.New("tag date source tag is not supported by Gitea"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTestConnector("https://gitea.example.com", "testrepo", tt.flags)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			c := got.(*gitea.Connector)
+			if c.BaseURL != tt.want.BaseURL || c.Token != tt.want.Token ||
+				c.Owner != tt.want.Owner || c.Repo != tt.want.Repo ||
+				c.ProjectURL != tt.want.ProjectURL {
+				t.Errorf("New() = %+v, want %+v", c, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnector_RepositoryExists(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		repo    string
+		token   string
+		want    bool
+		wantErr error
+	}{
+		{
+			name: "Existing repository",
+			repo: "testrepo",
+			want: true,
+		},
+		{
+			name: "Missing repository",
+			repo: "missingrepo",
+		},
+		{
+			name:    "Wrong token",
+			repo:    "testrepo",
+			token:   "wrongtoken",
+			wantErr: errors.New("Gitea query 'RepositoryExists' failed: GET /repos/testowner/testrepo: 401 Unauthorized"), // nolint: lll
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := newTestConnector(srv.URL, tt.repo, nil)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if tt.token != "" {
+				conn.(*gitea.Connector).Token = tt.token
+			}
+
+			got, err := conn.RepositoryExists()
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("RepositoryExists() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RepositoryExists() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
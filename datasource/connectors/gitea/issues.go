@@ -0,0 +1,114 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// IssuesPerPage defined how many issues are fetched per page
+var IssuesPerPage = 50 // nolint: gochecknoglobals
+
+// Issues returns the closed issues via channels.
+// Returns possible errors via given cerr channel
+// cissues returns issues
+// cissuescounter returns the channel, which ticks when an issue is proceeded
+// cmaxissues returns the max available amount of issues
+func (c *Connector) Issues(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	cissues <-chan data.Issue,
+	cissuescounter <-chan bool,
+	cmaxissues <-chan int,
+) {
+	issues := make(chan data.Issue)
+	maxissues := make(chan int, 1)
+	issuescounter := make(chan bool, 100)
+
+	go func() {
+		defer close(issues)
+		defer close(issuescounter)
+		defer close(maxissues)
+
+		query := url.Values{"state": {"closed"}, "type": {"issues"}}
+		helpers.FetchPages(ctx, cerr, maxissues, pageRoutines, IssuesPerPage,
+			func(ctx context.Context, page int) (helpers.Page, error) {
+				var rissues []apiIssue
+				p, err := c.list(ctx, c.repoPath("/issues"), page, IssuesPerPage, copyValues(query), &rissues)
+				if err != nil {
+					return p, formatErrorCode("Issues", err)
+				}
+
+				for _, i := range rissues {
+					issuescounter <- true
+					// ensure we have an issue and not PR
+					if i.PullRequest != nil {
+						continue
+					}
+
+					select {
+					case <-ctx.Done():
+						return p, ctx.Err()
+					case issues <- c.issue(i):
+					}
+				}
+				p.Items = len(rissues)
+				return p, nil
+			})
+	}()
+
+	return issues, issuescounter, maxissues
+}
+
+// issue converts the Gitea issue to our data structure
+func (c *Connector) issue(i apiIssue) data.Issue {
+	ret := data.Issue{
+		ID:     i.Number,
+		Name:   i.Title,
+		URL:    i.HTMLURL,
+		Labels: labels(i.Labels),
+	}
+	if i.ClosedAt != nil {
+		ret.ClosedDate = i.ClosedAt.UTC()
+	}
+	return ret
+}
+
+// labels returns the names of given labels
+func labels(ls []apiLabel) []string {
+	var ret []string
+	for _, l := range ls {
+		ret = append(ret, l.Name)
+	}
+	return ret
+}
+
+// copyValues returns a copy of given query values,
+// so they can be modified for every page
+func copyValues(v url.Values) url.Values {
+	ret := url.Values{}
+	for k, vs := range v {
+		ret[k] = append([]string(nil), vs...)
+	}
+	return ret
+}
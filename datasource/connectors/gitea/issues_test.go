@@ -0,0 +1,76 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gitea_test
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/internal/testing/helpers"
+)
+
+func TestConnector_Issues(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	c, err := newTestConnector(srv.URL, "testrepo", nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	cerr := make(chan error, 1)
+
+	cgot, _, cmaxissues := c.Issues(context.Background(), cerr)
+
+	var got data.Issues
+	for i := range cgot {
+		got = append(got, i)
+	}
+	gotmaxissues := helpers.GetChannelValuesInt(cmaxissues)
+	sort.Sort(&got)
+
+	select {
+	case err := <-cerr:
+		t.Fatalf("Connector.Issues() error = %v", err)
+	default:
+	}
+
+	want := data.Issues{
+		{
+			ID:         12,
+			Name:       "Crash on empty config",
+			ClosedDate: time.Date(2019, 3, 18, 8, 0, 0, 0, time.UTC),
+			URL:        "https://gitea.example.com/testowner/testrepo/issues/12",
+			Labels:     []string{"bug"},
+		},
+		{
+			ID:         11,
+			Name:       "Document the options",
+			ClosedDate: time.Date(2019, 3, 16, 8, 0, 0, 0, time.UTC),
+			URL:        "https://gitea.example.com/testowner/testrepo/issues/11",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Connector.Issues() = %+v, want %+v", got, want)
+	}
+	if !reflect.DeepEqual(gotmaxissues, []int{2}) {
+		t.Errorf("Connector.Issues() maxissues = %v, want %v", gotmaxissues, []int{2})
+	}
+}
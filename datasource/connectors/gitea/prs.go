@@ -0,0 +1,139 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// PRsPerPage defined how many PRs are fetched per page
+var PRsPerPage = 50 // nolint: gochecknoglobals
+
+// PRFilesPerPage defined how many changed files of a PR are fetched per page
+var PRFilesPerPage = 100 // nolint: gochecknoglobals
+
+// MRs returns the merged pull requests via channels.
+// Returns possible errors via given cerr channel
+// cmrs returns PRs
+// cmrscounter returns the channel, which ticks when a PR is proceeded
+// cmaxmrs returns the max available amount of PRs
+func (c *Connector) MRs(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	cmrs <-chan data.MR,
+	cmrscounter <-chan bool,
+	cmaxmrs <-chan int,
+) {
+	mrs := make(chan data.MR)
+	maxmrs := make(chan int, 1)
+	mrscounter := make(chan bool, 100)
+
+	go func() {
+		defer close(mrs)
+		defer close(mrscounter)
+		defer close(maxmrs)
+
+		query := url.Values{"state": {"closed"}}
+		helpers.FetchPages(ctx, cerr, maxmrs, pageRoutines, PRsPerPage,
+			func(ctx context.Context, page int) (helpers.Page, error) {
+				var rprs []apiPullRequest
+				p, err := c.list(ctx, c.repoPath("/pulls"), page, PRsPerPage, copyValues(query), &rprs)
+				if err != nil {
+					return p, formatErrorCode("MRs", err)
+				}
+
+				for _, pr := range rprs {
+					mrscounter <- true
+					// closed, but not merged PRs are skipped
+					if !pr.Merged {
+						continue
+					}
+
+					mr, err := c.mr(ctx, pr)
+					if err != nil {
+						return p, err
+					}
+					select {
+					case <-ctx.Done():
+						return p, ctx.Err()
+					case mrs <- mr:
+					}
+				}
+				p.Items = len(rprs)
+				return p, nil
+			})
+	}()
+
+	return mrs, mrscounter, maxmrs
+}
+
+// mr converts the Gitea pull request to our data structure
+func (c *Connector) mr(ctx context.Context, pr apiPullRequest) (data.MR, error) {
+	ret := data.MR{
+		ID:          pr.Number,
+		Name:        pr.Title,
+		URL:         pr.HTMLURL,
+		Author:      pr.User.Login,
+		AuthorURL:   pr.User.HTMLURL,
+		Labels:      labels(pr.Labels),
+		Description: pr.Body,
+		Closes:      data.ParseClosingReferences(pr.Body),
+		MergeCommit: pr.MergeCommitSHA,
+		IssueKeys:   data.ParseIssueKeys(pr.Title, pr.Body, pr.Head.Ref),
+	}
+	// older Gitea versions do not provide the profile URL
+	if ret.AuthorURL == "" {
+		ret.AuthorURL = fmt.Sprintf("%s/%s", c.BaseURL, pr.User.Login)
+	}
+	if pr.MergedAt != nil {
+		ret.MergedDate = pr.MergedAt.UTC()
+	}
+
+	if c.FetchFiles {
+		files, err := c.prFiles(ctx, pr.Number)
+		if err != nil {
+			return data.MR{}, err
+		}
+		ret.Files = files
+	}
+	return ret, nil
+}
+
+// prFiles returns the files changed by given PR
+func (c *Connector) prFiles(ctx context.Context, number int) ([]string, error) {
+	var ret []string
+	for page := 1; ; page++ {
+		var files []apiChangedFile
+		p, err := c.list(ctx, c.repoPath(fmt.Sprintf("/pulls/%d/files", number)), page, PRFilesPerPage, nil, &files)
+		if err != nil {
+			return nil, formatErrorCode("PRFiles", err)
+		}
+		for _, f := range files {
+			ret = append(ret, f.Filename)
+		}
+		if p.LastPage <= page {
+			return ret, nil
+		}
+	}
+}
@@ -0,0 +1,110 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gitea_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+func TestConnector_MRs(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name  string
+		flags map[string]string
+		want  data.MRs
+	}{
+		{
+			name: "Merged PRs",
+			want: data.MRs{
+				{
+					ID:          13,
+					Name:        "Fix crash on empty config",
+					URL:         "https://gitea.example.com/testowner/testrepo/pulls/13",
+					Author:      "contributor",
+					AuthorURL:   "https://gitea.example.com/contributor",
+					MergedDate:  time.Date(2019, 3, 18, 7, 30, 0, 0, time.UTC),
+					Labels:      []string{"bugfix"},
+					Description: "Fixes #12",
+					Closes:      []int{12},
+					MergeCommit: "8f2b0e4c6a1d3f5b7c9e0a2d4f6b8c0e1a3d5f7b",
+					IssueKeys:   []string{"CONF-3"},
+				},
+			},
+		},
+		{
+			name:  "Merged PRs with changed files",
+			flags: map[string]string{"path": "config"},
+			want: data.MRs{
+				{
+					ID:          13,
+					Name:        "Fix crash on empty config",
+					URL:         "https://gitea.example.com/testowner/testrepo/pulls/13",
+					Author:      "contributor",
+					AuthorURL:   "https://gitea.example.com/contributor",
+					MergedDate:  time.Date(2019, 3, 18, 7, 30, 0, 0, time.UTC),
+					Labels:      []string{"bugfix"},
+					Description: "Fixes #12",
+					Closes:      []int{12},
+					Files:       []string{"config/config.go", "config/config_test.go"},
+					MergeCommit: "8f2b0e4c6a1d3f5b7c9e0a2d4f6b8c0e1a3d5f7b",
+					IssueKeys:   []string{"CONF-3"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newTestConnector(srv.URL, "testrepo", tt.flags)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			cerr := make(chan error, 1)
+
+			cgot, cmrscounter, _ := c.MRs(context.Background(), cerr)
+
+			var got data.MRs
+			for m := range cgot {
+				got = append(got, m)
+			}
+			processed := 0
+			for range cmrscounter {
+				processed++
+			}
+
+			select {
+			case err := <-cerr:
+				t.Fatalf("Connector.MRs() error = %v", err)
+			default:
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Connector.MRs() = %+v, want %+v", got, tt.want)
+			}
+			// the closed, but not merged PRs are processed too
+			if processed != 2 {
+				t.Errorf("Connector.MRs() processed = %v, want %v", processed, 2)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gitea
+
+import (
+	"context"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// TagsPerPage defined how many tags are fetched per page
+var TagsPerPage = 50 // nolint: gochecknoglobals
+
+// Tags returns the tags via channels.
+// Returns possible errors via given cerr channel
+// ctags returns tags
+// ctagscounter returns the channel, which ticks when a tag is proceeded
+// cmaxtags returns the max available amount of tags
+func (c *Connector) Tags(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	ctags <-chan data.Tag,
+	ctagscounter <-chan bool,
+	cmaxtags <-chan int,
+) {
+	tags := make(chan data.Tag)
+	maxtags := make(chan int, 1)
+	tagscounter := make(chan bool, 100)
+
+	go func() {
+		defer close(tags)
+		defer close(tagscounter)
+		defer close(maxtags)
+
+		helpers.FetchPages(ctx, cerr, maxtags, pageRoutines, TagsPerPage,
+			func(ctx context.Context, page int) (helpers.Page, error) {
+				var rtags []apiTag
+				p, err := c.list(ctx, c.repoPath("/tags"), page, TagsPerPage, nil, &rtags)
+				if err != nil {
+					return p, formatErrorCode("Tags", err)
+				}
+
+				for _, t := range rtags {
+					select {
+					case <-ctx.Done():
+						return p, ctx.Err()
+					case tags <- c.tag(t):
+						tagscounter <- true
+					}
+				}
+				p.Items = len(rtags)
+				return p, nil
+			})
+	}()
+
+	return tags, tagscounter, maxtags
+}
+
+// tag converts the Gitea tag to our data structure,
+// Gitea provides only the date of tagged commit
+func (c *Connector) tag(t apiTag) data.Tag {
+	return data.Tag{
+		Name:   t.Name,
+		Commit: t.Commit.SHA,
+		Date:   t.Commit.Created.UTC(),
+		URL:    c.tagURL(t.Name),
+	}
+}
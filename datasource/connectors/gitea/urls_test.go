@@ -0,0 +1,39 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gitea_test
+
+import (
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+)
+
+func TestConnector_URLs(t *testing.T) {
+	conn, err := newTestConnector("https://gitea.example.com", "testrepo", nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if got, _ := conn.GetNewTagURL("v1.0.0"); got != "https://gitea.example.com/testowner/testrepo/releases/tag/v1.0.0" {
+		t.Errorf("GetNewTagURL() = %v", got)
+	}
+
+	got, _ := conn.(connectors.CompareURLProvider).CompareURL("v0.1.0", "v1.0.0")
+	if got != "https://gitea.example.com/testowner/testrepo/compare/v0.1.0...v1.0.0" {
+		t.Errorf("CompareURL() = %v", got)
+	}
+}
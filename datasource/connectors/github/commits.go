@@ -0,0 +1,175 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+	"sort"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+
+	"github.com/google/go-github/github"
+)
+
+// CommitsPerPage defined how many commits are fetched per page
+var CommitsPerPage = 100 // nolint: gochecknoglobals
+
+// Commits returns the commits of the default branch via channels.
+// The history is queried between the adjacent tags via the compare API,
+// only the commits before the oldest and after the newest tag are listed.
+// Returns possible errors via given cerr channel
+// ccommits returns commits
+// ccommitscounter returns the channel, which ticks when a commit is proceeded
+// cmaxcommits returns the max available amount of commits
+func (c *Connector) Commits(
+	ctx context.Context,
+	cerr chan<- error,
+	tags data.Tags,
+) (
+	ccommits <-chan data.Commit,
+	ccommitscounter <-chan bool,
+	cmaxcommits <-chan int,
+) {
+	commits := make(chan data.Commit)
+	// the ranges are processed sequentially and the amount of data
+	// is known after the last range
+	maxcommits := make(chan int, 1)
+	commitscounter := make(chan bool, 100)
+
+	go func() {
+		defer close(commits)
+		defer close(commitscounter)
+		defer close(maxcommits)
+
+		// the same commit might be part of several ranges
+		seen := map[string]bool{}
+		send := func(rc *github.RepositoryCommit) bool {
+			if seen[rc.GetSHA()] {
+				return true
+			}
+			seen[rc.GetSHA()] = true
+			select {
+			case <-ctx.Done():
+				return false
+			case commits <- c.commit(rc):
+				commitscounter <- true
+				return true
+			}
+		}
+
+		for _, r := range commitRanges(tags) {
+			if err := c.rangeCommits(ctx, r, send); err != nil {
+				helpers.NonBlockingErrSend(ctx, cerr, formatErrorCode("Commits", err))
+				return
+			}
+		}
+		maxcommits <- len(seen)
+	}()
+
+	return commits, commitscounter, maxcommits
+}
+
+// commitRange describes the commits after base tag up to the head tag.
+// Empty base means the beginning of history, empty head the default branch
+type commitRange struct {
+	base, head data.Tag
+}
+
+// commitRanges returns the ranges between the adjacent tags from oldest to newest
+func commitRanges(tags data.Tags) []commitRange {
+	tags = append(data.Tags(nil), tags...)
+	sort.Sort(&tags)
+
+	ret := []commitRange{}
+	base := data.Tag{}
+	for i := len(tags) - 1; i >= 0; i-- {
+		ret = append(ret, commitRange{base: base, head: tags[i]})
+		base = tags[i]
+	}
+	return append(ret, commitRange{base: base})
+}
+
+// rangeCommits passes the commits of given range to send
+// until send returns false
+func (c *Connector) rangeCommits(
+	ctx context.Context,
+	r commitRange,
+	send func(*github.RepositoryCommit) bool,
+) error {
+	if r.base.Name != "" && r.head.Name != "" {
+		comp, _, err := c.client.Repositories.CompareCommits(
+			ctx, c.Owner, c.Repo, r.base.Name, r.head.Name)
+		if err != nil {
+			return err
+		}
+		// the compare API delivers a limited amount of commits,
+		// the complete range is listed if they are truncated
+		if comp.GetTotalCommits() <= len(comp.Commits) {
+			for i := range comp.Commits {
+				if !send(&comp.Commits[i]) {
+					return nil
+				}
+			}
+			return nil
+		}
+	}
+
+	opts := &github.CommitsListOptions{
+		SHA:         r.head.Name,
+		Since:       r.base.Date,
+		ListOptions: github.ListOptions{Page: 1, PerPage: CommitsPerPage},
+	}
+	for {
+		rcommits, resp, err := c.client.Repositories.ListCommits(ctx, c.Owner, c.Repo, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, rc := range rcommits {
+			if !send(rc) {
+				return nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// commit converts the GitHub commit to our data structure
+func (c *Connector) commit(rc *github.RepositoryCommit) data.Commit {
+	ret := data.Commit{
+		SHA:     rc.GetSHA(),
+		Message: rc.GetCommit().GetMessage(),
+		URL:     rc.GetHTMLURL(),
+		Author:  rc.GetCommit().GetAuthor().GetName(),
+		Date:    rc.GetCommit().GetCommitter().GetDate().UTC(),
+	}
+	// commits of unknown users are not linked to a GitHub account
+	if rc.GetAuthor().GetLogin() != "" {
+		ret.Author = rc.GetAuthor().GetLogin()
+		ret.AuthorURL = rc.GetAuthor().GetHTMLURL()
+	}
+	for _, p := range rc.Parents {
+		ret.Parents = append(ret.Parents, p.GetSHA())
+	}
+	return ret
+}
@@ -0,0 +1,137 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package github_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/github"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/github/internal/testclient"
+	"github.com/artem-sidorenko/chagen/internal/testing/helpers"
+	"github.com/artem-sidorenko/chagen/internal/testing/testdata"
+)
+
+func TestConnector_Commits(t *testing.T) {
+	tests := []struct {
+		name        string
+		returnValue testclient.ReturnValueStr
+		tags        data.Tags
+		wantFirst   data.Commit
+		wantMax     int
+		wantErr     error
+	}{
+		{
+			name: "API returns proper data",
+			wantFirst: data.Commit{
+				SHA:       "f0e1d2c3b4a5968778695a4b3c2d1e0f9a8b7c6d",
+				Message:   "Update dependencies",
+				URL:       "https://github.com/testowner/testrepo/commit/f0e1d2c3b4a5968778695a4b3c2d1e0f9a8b7c6d",
+				Author:    "test-user",
+				AuthorURL: "https://example.com/users/test-user",
+				Date:      helpers.Time(1048450030),
+				Parents:   []string{"9618c791ab1f643aeffb7c5e1abe5877223aaa91"},
+			},
+			wantMax: len(testdata.Commits()),
+		},
+		{
+			name: "History is compared between tags",
+			tags: testdata.DataTags(),
+			wantFirst: data.Commit{
+				SHA:       "7d84cdb2f7c2d4619cda4b8adeb1897097b5c8fc",
+				Message:   "Release v0.0.1",
+				URL:       "https://github.com/testowner/testrepo/commit/7d84cdb2f7c2d4619cda4b8adeb1897097b5c8fc",
+				Author:    "test-user",
+				AuthorURL: "https://example.com/users/test-user",
+				Date:      helpers.Time(1047083677),
+			},
+			wantMax: len(testdata.Commits()),
+		},
+		{
+			name: "Truncated comparisons are listed",
+			returnValue: testclient.ReturnValueStr{
+				RepoServiceCompareCommitsMax: 1,
+			},
+			tags: testdata.DataTags(),
+			wantFirst: data.Commit{
+				SHA:       "7d84cdb2f7c2d4619cda4b8adeb1897097b5c8fc",
+				Message:   "Release v0.0.1",
+				URL:       "https://github.com/testowner/testrepo/commit/7d84cdb2f7c2d4619cda4b8adeb1897097b5c8fc",
+				Author:    "test-user",
+				AuthorURL: "https://example.com/users/test-user",
+				Date:      helpers.Time(1047083677),
+			},
+			wantMax: len(testdata.Commits()),
+		},
+		{
+			name: "ListCommits call fails",
+			returnValue: testclient.ReturnValueStr{
+				RepoServiceListCommitsErr: true,
+			},
+			wantErr: errors.New("GitHub query 'Commits' failed: can't fetch the commits"),
+		},
+		{
+			name: "CompareCommits call fails",
+			returnValue: testclient.ReturnValueStr{
+				RepoServiceCompareCommitsErr: true,
+			},
+			tags:    testdata.DataTags(),
+			wantErr: errors.New("GitHub query 'Commits' failed: can't compare the commits"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			github.CommitsPerPage = 5
+			c := setupTestConnector(tt.returnValue, false).(connectors.CommitProvider)
+			cerr := make(chan error, 1)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cgot, _, cmaxcommits := c.Commits(ctx, cerr, tt.tags)
+
+			var got data.Commits
+			for commit := range cgot {
+				got = append(got, commit)
+			}
+			max := helpers.GetChannelValuesInt(cmaxcommits)
+
+			var err error
+			select {
+			case err = <-cerr:
+			default:
+			}
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Connector.Commits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(max, []int{tt.wantMax}) || len(got) != tt.wantMax {
+				t.Errorf("Connector.Commits() got %v commits, max %v, want %v", len(got), max, tt.wantMax)
+			}
+			if !reflect.DeepEqual(got[0], tt.wantFirst) {
+				t.Errorf("Connector.Commits() first = %+v, want %+v", got[0], tt.wantFirst)
+			}
+		})
+	}
+}
@@ -35,12 +35,17 @@ const AccessTokenEnvVar = "CHAGEN_GITHUB_TOKEN" // nolint: gosec
 
 // Connector implements the GitHub connector
 type Connector struct {
-	context             context.Context
-	client              *client.Client
-	Owner               string
-	Repo                string
-	ProjectURL          string
-	NewTagUseReleaseURL bool
+	context              context.Context
+	client               *client.Client
+	Owner                string
+	Repo                 string
+	ProjectURL           string
+	NewTagUseReleaseURL  bool
+	TagDateSource        connectors.TagDateSource
+	IncludeDraftReleases bool
+	FetchFiles           bool
+	FetchDescriptions    bool
+	LinkedIssues         bool // the linked issues of PRs are queried, requires the access token
 }
 
 // NewClient links to the constructor, which is used to create Connector.client
@@ -77,14 +82,25 @@ func New(ctx *cli.Context) (connectors.Connector, error) {
 		return nil, errors.New("option --github-repo is required")
 	}
 	newTagUseReleaseURL := ctx.Bool("github-release-url")
+	tagDateSource, err := connectors.GetTagDateSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token := os.Getenv(AccessTokenEnvVar)
 
 	return &Connector{
-		context:             context.Background(),
-		client:              NewClient(context.Background(), os.Getenv(AccessTokenEnvVar)),
-		Owner:               owner,
-		Repo:                repo,
-		NewTagUseReleaseURL: newTagUseReleaseURL,
-		ProjectURL:          fmt.Sprintf("https://github.com/%s/%s", owner, repo),
+		context:              context.Background(),
+		client:               NewClient(context.Background(), token),
+		Owner:                owner,
+		Repo:                 repo,
+		NewTagUseReleaseURL:  newTagUseReleaseURL,
+		TagDateSource:        tagDateSource,
+		IncludeDraftReleases: ctx.Bool("include-draft-releases"),
+		FetchFiles:           connectors.FetchMRFiles(ctx),
+		FetchDescriptions:    connectors.FetchTagDescriptions(ctx),
+		LinkedIssues:         token != "",
+		ProjectURL:           fmt.Sprintf("https://github.com/%s/%s", owner, repo),
 	}, nil
 }
 
@@ -108,4 +124,5 @@ func CLIFlags() []cli.Flag {
 
 func init() { // nolint: gochecknoinits
 	connectors.RegisterConnector("github", "GitHub", New, CLIFlags)
+	connectors.RegisterConnectorDetails("github", []string{AccessTokenEnvVar}, &Connector{})
 }
@@ -26,22 +26,44 @@ import (
 	"github.com/artem-sidorenko/chagen/datasource/connectors/github"
 	"github.com/artem-sidorenko/chagen/datasource/connectors/github/internal/testclient"
 	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+	"github.com/urfave/cli"
 )
 
 func setupTestConnector(
 	returnValue testclient.ReturnValueStr, newTagUseReleaseURL bool,
 ) connectors.Connector {
 
+	cliFlags := map[string]string{}
+	if newTagUseReleaseURL {
+		cliFlags["github-release-url"] = "true"
+	}
+
+	return setupTestConnectorWithFlags(returnValue, cliFlags)
+}
+
+// setupTestConnectorWithFlags returns the test connector configured
+// with the given additional CLI flags
+func setupTestConnectorWithFlags(
+	returnValue testclient.ReturnValueStr, flags map[string]string,
+) connectors.Connector {
+
 	github.NewClient = testclient.New
 	cliFlags := map[string]string{
 		"github-owner": "testowner",
 		"github-repo":  "testrepo",
 	}
-	if newTagUseReleaseURL {
-		cliFlags["github-release-url"] = "true"
+	for k, v := range flags {
+		cliFlags[k] = v
 	}
 
-	ctx := tcli.TestContext(github.CLIFlags(), cliFlags)
+	ctx := tcli.TestContext(
+		append(
+			append(github.CLIFlags(), connectors.CommonCLIFlags()...),
+			// the flag is provided by the generate command
+			cli.BoolFlag{Name: "release-descriptions"},
+		),
+		cliFlags,
+	)
 
 	// initialize error values
 	testclient.ReturnValue = returnValue
@@ -111,8 +133,9 @@ func TestConnector_RepositoryExists(t *testing.T) {
 
 func TestNew(t *testing.T) {
 	type args struct {
-		githubOwner bool
-		githubRepo  bool
+		githubOwner   bool
+		githubRepo    bool
+		tagDateSource string
 	}
 	tests := []struct {
 		name          string
@@ -144,6 +167,15 @@ func TestNew(t *testing.T) {
 			},
 			wantErr: errors.New("option --github-repo is required"),
 		},
+		{
+			name: "Unsupported tag date source",
+			args: args{
+				githubOwner:   true,
+				githubRepo:    true,
+				tagDateSource: "wrong",
+			},
+			wantErr: errors.New("unsupported tag date source: wrong"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -156,7 +188,14 @@ func TestNew(t *testing.T) {
 				cliFlags["github-repo"] = "testrepo"
 			}
 
-			ctx := tcli.TestContext(github.CLIFlags(), cliFlags)
+			if tt.args.tagDateSource != "" {
+				cliFlags["tag-date-source"] = tt.args.tagDateSource
+			}
+
+			ctx := tcli.TestContext(
+				append(github.CLIFlags(), connectors.CommonCLIFlags()...),
+				cliFlags,
+			)
 
 			github.NewClient = testclient.New
 			testclient.ReturnValue = tt.retErrControl
@@ -42,7 +42,8 @@ func New(ctx context.Context, AccessToken string) *Client {
 
 	return &Client{
 		Repositories: client.Repositories,
-		Issues:       client.Issues,
-		PullRequests: client.PullRequests,
+		Issues:       &issuesService{client: client},
+		PullRequests: &pullRequestsService{PullRequestsService: client.PullRequests, client: client},
+		Git:          client.Git,
 	}
 }
@@ -32,15 +32,36 @@ type RepoService interface {
 		ctx context.Context,
 		owner, repo string,
 		opt *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error)
+	ListReleases(
+		ctx context.Context,
+		owner, repo string,
+		opt *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error)
 	GetCommit(
 		ctx context.Context,
 		owner, repo, sha string) (*github.RepositoryCommit, *github.Response, error)
+	ListCommits(
+		ctx context.Context,
+		owner, repo string,
+		opt *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error)
+	CompareCommits(
+		ctx context.Context,
+		owner, repo string,
+		base, head string) (*github.CommitsComparison, *github.Response, error)
 	GetReleaseByTag(
 		ctx context.Context,
 		owner, repo, tag string) (*github.RepositoryRelease, *github.Response, error)
 	Get(
 		ctx context.Context,
 		owner, repo string) (*github.Repository, *github.Response, error)
+	CreateRelease(
+		ctx context.Context,
+		owner, repo string,
+		release *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error)
+	EditRelease(
+		ctx context.Context,
+		owner, repo string,
+		id int64,
+		release *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error)
 }
 
 // IssuesService describes the methods we use from
@@ -49,7 +70,11 @@ type IssuesService interface {
 	ListByRepo(
 		ctx context.Context,
 		owner string, repo string,
-		opt *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error)
+		opt *github.IssueListByRepoOptions) ([]*Issue, *github.Response, error)
+	ListMilestones(
+		ctx context.Context,
+		owner string, repo string,
+		opt *github.MilestoneListOptions) ([]*github.Milestone, *github.Response, error)
 }
 
 // PullRequestsService describes the methods we use from
@@ -58,6 +83,23 @@ type PullRequestsService interface {
 	List(
 		ctx context.Context, owner string, repo string,
 		opt *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error)
+	ListFiles(
+		ctx context.Context, owner string, repo string, number int,
+		opt *github.ListOptions) ([]*github.CommitFile, *github.Response, error)
+	ListClosingIssues(
+		ctx context.Context, owner string, repo string,
+		numbers []int) (map[int][]int, *github.Response, error)
+}
+
+// GitService describes the methods we use from
+// github.GitService
+type GitService interface {
+	GetRef(
+		ctx context.Context,
+		owner string, repo string, ref string) (*github.Reference, *github.Response, error)
+	GetTag(
+		ctx context.Context,
+		owner string, repo string, sha string) (*github.Tag, *github.Response, error)
 }
 
 // Client wraps the github.Client with interfaces we are using
@@ -65,4 +107,5 @@ type Client struct {
 	Repositories RepoService
 	Issues       IssuesService
 	PullRequests PullRequestsService
+	Git          GitService
 }
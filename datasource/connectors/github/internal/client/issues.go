@@ -0,0 +1,80 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/github"
+	"github.com/google/go-querystring/query"
+)
+
+// Issue represents a GitHub issue.
+// The state_reason isn't covered by the used go-github version,
+// so we extend the github.Issue on our own
+type Issue struct {
+	*github.Issue
+	StateReason string `json:"state_reason"`
+}
+
+// issuesService implements the IssuesService using the github.Client
+type issuesService struct {
+	client *github.Client
+}
+
+// ListByRepo lists the issues for the specified repository
+//
+// GitHub API docs: https://developer.github.com/v3/issues/#list-issues-for-a-repository
+func (s *issuesService) ListByRepo(
+	ctx context.Context,
+	owner string, repo string,
+	opt *github.IssueListByRepoOptions,
+) ([]*Issue, *github.Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/issues", owner, repo)
+	if opt != nil {
+		v, err := query.Values(opt)
+		if err != nil {
+			return nil, nil, err
+		}
+		u += "?" + v.Encode()
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var issues []*Issue
+	resp, err := s.client.Do(ctx, req, &issues)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return issues, resp, nil
+}
+
+// ListMilestones lists the milestones for the specified repository
+//
+// GitHub API docs: https://developer.github.com/v3/issues/milestones/#list-milestones-for-a-repository
+func (s *issuesService) ListMilestones(
+	ctx context.Context,
+	owner string, repo string,
+	opt *github.MilestoneListOptions,
+) ([]*github.Milestone, *github.Response, error) {
+	return s.client.Issues.ListMilestones(ctx, owner, repo, opt)
+}
@@ -0,0 +1,99 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// ClosingIssuesLimit defines how many linked issues are fetched per PR
+const ClosingIssuesLimit = 100
+
+// pullRequestsService implements the PullRequestsService using the github.Client
+type pullRequestsService struct {
+	*github.PullRequestsService
+	client *github.Client
+}
+
+// ListClosingIssues returns the numbers of issues, which are linked to the given PRs
+// and closed by them. This covers the closing keywords in the PR body and the
+// issues linked manually. All PRs are queried with one request via GraphQL API,
+// which requires the authentication
+//
+// GitHub API docs: https://docs.github.com/en/graphql/reference/objects#pullrequest
+func (s *pullRequestsService) ListClosingIssues(
+	ctx context.Context,
+	owner string, repo string,
+	numbers []int,
+) (map[int][]int, *github.Response, error) {
+	var q strings.Builder
+	q.WriteString("query($owner: String!, $repo: String!) {repository(owner: $owner, name: $repo) {")
+	for _, n := range numbers {
+		fmt.Fprintf(&q,
+			" pr%d: pullRequest(number: %d) {closingIssuesReferences(first: %d) {nodes {number}}}",
+			n, n, ClosingIssuesLimit)
+	}
+	q.WriteString("}}")
+
+	req, err := s.client.NewRequest("POST", "graphql", map[string]interface{}{
+		"query":     q.String(),
+		"variables": map[string]string{"owner": owner, "repo": repo},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result struct {
+		Data struct {
+			Repository map[string]*struct {
+				ClosingIssuesReferences struct {
+					Nodes []struct {
+						Number int `json:"number"`
+					} `json:"nodes"`
+				} `json:"closingIssuesReferences"`
+			} `json:"repository"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	resp, err := s.client.Do(ctx, req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+	// GraphQL API reports the errors within the successful response
+	if len(result.Errors) > 0 {
+		return nil, resp, fmt.Errorf("GraphQL query failed: %v", result.Errors[0].Message)
+	}
+
+	ret := map[int][]int{}
+	for _, n := range numbers {
+		pr := result.Data.Repository[fmt.Sprintf("pr%d", n)]
+		if pr == nil {
+			continue
+		}
+		for _, issue := range pr.ClosingIssuesReferences.Nodes {
+			ret[n] = append(ret[n], issue.Number)
+		}
+	}
+
+	return ret, resp, nil
+}
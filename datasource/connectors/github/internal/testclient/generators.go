@@ -30,19 +30,40 @@ func genCommitAuthor(commitDate time.Time) *github.CommitAuthor {
 	}
 }
 
-func genCommit(sha string, commitDate time.Time) *github.Commit {
+func genCommit(sha string, authorDate, commitDate time.Time) *github.Commit {
 	return &github.Commit{
 		SHA:       helpers.StringPtr(sha),
+		Author:    genCommitAuthor(authorDate),
 		Committer: genCommitAuthor(commitDate),
 	}
 }
 
-func genRepositoryCommit(sha string, commitDate time.Time) *github.RepositoryCommit {
+func genRepositoryCommit(sha string, authorDate, commitDate time.Time) *github.RepositoryCommit {
 	return &github.RepositoryCommit{
-		Commit: genCommit(sha, commitDate),
+		Commit: genCommit(sha, authorDate, commitDate),
 	}
 }
 
+func genHistoryCommit(
+	sha, message string,
+	authorDate, commitDate time.Time,
+	parents []string,
+) *github.RepositoryCommit {
+	commit := genRepositoryCommit(sha, authorDate, commitDate)
+	commit.SHA = helpers.StringPtr(sha)
+	commit.HTMLURL = helpers.StringPtr("https://github.com/testowner/testrepo/commit/" + sha)
+	commit.Commit.Message = helpers.StringPtr(message)
+	commit.Commit.Author.Name = helpers.StringPtr("Test User")
+	commit.Author = &github.User{
+		Login:   helpers.StringPtr("test-user"),
+		HTMLURL: helpers.StringPtr("https://example.com/users/test-user"),
+	}
+	for _, p := range parents {
+		commit.Parents = append(commit.Parents, github.Commit{SHA: helpers.StringPtr(p)})
+	}
+	return commit
+}
+
 func genRepositoryTag(name string, commit *github.Commit) *github.RepositoryTag {
 	return &github.RepositoryTag{
 		Name:   helpers.StringPtr(name),
@@ -51,10 +72,38 @@ func genRepositoryTag(name string, commit *github.Commit) *github.RepositoryTag
 }
 
 // nolint: unparam
-func genRepositoryRelease(tagName, htmlURL string) *github.RepositoryRelease {
+func genRepositoryRelease(tagName, htmlURL string, publishedAt time.Time) *github.RepositoryRelease {
 	return &github.RepositoryRelease{
-		TagName: helpers.StringPtr(tagName),
-		HTMLURL: helpers.StringPtr(htmlURL),
+		TagName:     helpers.StringPtr(tagName),
+		HTMLURL:     helpers.StringPtr(htmlURL),
+		PublishedAt: &github.Timestamp{Time: publishedAt},
+	}
+}
+
+func genDraftRepositoryRelease(tagName, htmlURL, target string) *github.RepositoryRelease {
+	return &github.RepositoryRelease{
+		TagName:         helpers.StringPtr(tagName),
+		HTMLURL:         helpers.StringPtr(htmlURL),
+		TargetCommitish: helpers.StringPtr(target),
+	}
+}
+
+func genReference(ref, objectType, sha string) *github.Reference {
+	return &github.Reference{
+		Ref: helpers.StringPtr(ref),
+		Object: &github.GitObject{
+			Type: helpers.StringPtr(objectType),
+			SHA:  helpers.StringPtr(sha),
+		},
+	}
+}
+
+func genTag(name, sha string, taggerDate time.Time) *github.Tag {
+	return &github.Tag{
+		Tag:     helpers.StringPtr(name),
+		SHA:     helpers.StringPtr(sha),
+		Message: helpers.StringPtr("Tag message of " + name),
+		Tagger:  genCommitAuthor(taggerDate),
 	}
 }
 
@@ -103,7 +152,7 @@ func genIssuePR(
 func genPR(
 	number int,
 	title, htmlURL, userLogin, userHTMLURL string,
-	mergedAt time.Time, labels []string,
+	mergedAt time.Time, mergeCommitSHA string, labels []string, body string,
 ) *github.PullRequest {
 
 	var lbs []*github.Label
@@ -118,6 +167,7 @@ func genPR(
 		Number:  getIntPtr(number),
 		Title:   helpers.StringPtr(title),
 		HTMLURL: helpers.StringPtr(htmlURL),
+		Body:    helpers.StringPtr(body),
 		User: &github.User{
 			Login:   helpers.StringPtr(userLogin),
 			HTMLURL: helpers.StringPtr(userHTMLURL),
@@ -127,6 +177,7 @@ func genPR(
 
 	if (mergedAt != time.Time{}) {
 		pr.MergedAt = helpers.TimePtr(mergedAt)
+		pr.MergeCommitSHA = helpers.StringPtr(mergeCommitSHA)
 	}
 
 	return pr
@@ -21,8 +21,10 @@ package testclient
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/artem-sidorenko/chagen/datasource/connectors/github/internal/client"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
 	"github.com/artem-sidorenko/chagen/internal/testing/testdata"
 
 	"github.com/google/go-github/github"
@@ -31,12 +33,22 @@ import (
 // ReturnValueStr represents the possible error controlling of API calls for testing
 // if a field is set to true - return error, otherwise not
 type ReturnValueStr struct {
-	RepoServiceListTagsErr    bool
-	RepoServiceGetCommitsErr  bool
-	IssueServiceListByRepoErr bool
-	PullRequestsListErr       bool
-	RepoServiceGetErr         bool
-	RepoServiceGetRespCode    int
+	RepoServiceListTagsErr           bool
+	RepoServiceListReleasesErr       bool
+	RepoServiceGetCommitsErr         bool
+	RepoServiceListCommitsErr        bool
+	RepoServiceCompareCommitsErr     bool
+	RepoServiceCompareCommitsMax     int // max amount of commits in comparisons, unlimited if 0
+	IssueServiceListByRepoErr        bool
+	IssueServiceListMilestonesErr    bool
+	PullRequestsListErr              bool
+	PullRequestsListFilesErr         bool
+	PullRequestsListClosingIssuesErr bool
+	RepoServiceGetErr                bool
+	RepoServiceGetRespCode           int
+	GitServiceGetRefErr              bool
+	RepoServiceCreateReleaseErr      bool
+	RepoServiceEditReleaseErr        bool
 }
 
 // ReturnValue controls the error return values of API calls
@@ -48,6 +60,8 @@ type RepoService struct {
 	RepositoryTags     []*github.RepositoryTag
 	RepositoryCommits  map[string]*github.RepositoryCommit
 	RepositoryReleases map[string]*github.RepositoryRelease
+	ReleasesList       []*github.RepositoryRelease
+	History            []*github.RepositoryCommit
 	ReturnValue        ReturnValueStr
 }
 
@@ -67,6 +81,22 @@ func (g *RepoService) ListTags(
 	return g.RepositoryTags[start:end], resp, nil
 }
 
+// ListReleases simulates the (github.RepositoriesService) ListReleases call
+func (g *RepoService) ListReleases(
+	ctx context.Context,
+	owner, repo string,
+	opt *github.ListOptions,
+) ([]*github.RepositoryRelease, *github.Response, error) {
+
+	if g.ReturnValue.RepoServiceListReleasesErr {
+		return nil, nil, fmt.Errorf("can't fetch the releases")
+	}
+
+	resp, start, end := calcPaging(opt.Page, opt.PerPage, len(g.ReleasesList))
+
+	return g.ReleasesList[start:end], resp, nil
+}
+
 // GetCommit simulates the (github.RepositoriesService) GetCommit call
 func (g *RepoService) GetCommit(
 	ctx context.Context,
@@ -83,6 +113,77 @@ func (g *RepoService) GetCommit(
 	return nil, nil, fmt.Errorf("commit %v is not present", sha)
 }
 
+// ListCommits simulates the (github.RepositoriesService) ListCommits call
+func (g *RepoService) ListCommits(
+	ctx context.Context,
+	owner, repo string,
+	opt *github.CommitsListOptions,
+) ([]*github.RepositoryCommit, *github.Response, error) {
+
+	if g.ReturnValue.RepoServiceListCommitsErr {
+		return nil, nil, fmt.Errorf("can't fetch the commits")
+	}
+
+	var head time.Time
+	if opt.SHA != "" {
+		cm, ok := g.RepositoryCommits[opt.SHA]
+		if !ok {
+			return nil, nil, fmt.Errorf("commit %v is not present", opt.SHA)
+		}
+		head = cm.GetCommit().GetCommitter().GetDate()
+	}
+
+	history := []*github.RepositoryCommit{}
+	for _, cm := range g.History {
+		date := cm.GetCommit().GetCommitter().GetDate()
+		if (head.IsZero() || !date.After(head)) && !date.Before(opt.Since) {
+			history = append(history, cm)
+		}
+	}
+
+	resp, start, end := calcPaging(opt.Page, opt.PerPage, len(history))
+
+	return history[start:end], resp, nil
+}
+
+// CompareCommits simulates the (github.RepositoriesService) CompareCommits call
+func (g *RepoService) CompareCommits(
+	ctx context.Context,
+	owner, repo string,
+	base, head string,
+) (*github.CommitsComparison, *github.Response, error) {
+
+	if g.ReturnValue.RepoServiceCompareCommitsErr {
+		return nil, nil, fmt.Errorf("can't compare the commits")
+	}
+
+	basecm, ok := g.RepositoryCommits[base]
+	if !ok {
+		return nil, nil, fmt.Errorf("commit %v is not present", base)
+	}
+	headcm, ok := g.RepositoryCommits[head]
+	if !ok {
+		return nil, nil, fmt.Errorf("commit %v is not present", head)
+	}
+	from := basecm.GetCommit().GetCommitter().GetDate()
+	to := headcm.GetCommit().GetCommitter().GetDate()
+
+	ret := &github.CommitsComparison{}
+	// the compare API returns the commits from oldest to newest
+	for i := len(g.History) - 1; i >= 0; i-- {
+		date := g.History[i].GetCommit().GetCommitter().GetDate()
+		if date.After(from) && !date.After(to) {
+			ret.Commits = append(ret.Commits, *g.History[i])
+		}
+	}
+	ret.TotalCommits = github.Int(len(ret.Commits))
+	if max := g.ReturnValue.RepoServiceCompareCommitsMax; max != 0 && len(ret.Commits) > max {
+		ret.Commits = ret.Commits[:max]
+	}
+
+	return ret, nil, nil
+}
+
 // GetReleaseByTag simulates the (github.RepositoriesService) GetCommit call
 func (g *RepoService) GetReleaseByTag(
 	ctx context.Context,
@@ -95,6 +196,56 @@ func (g *RepoService) GetReleaseByTag(
 	return nil, genResponse(404), nil
 }
 
+// CreateRelease simulates the (github.RepositoriesService) CreateRelease call
+func (g *RepoService) CreateRelease(
+	ctx context.Context,
+	owner, repo string,
+	release *github.RepositoryRelease,
+) (*github.RepositoryRelease, *github.Response, error) {
+	if g.ReturnValue.RepoServiceCreateReleaseErr {
+		return nil, nil, fmt.Errorf("can't create the release")
+	}
+
+	release.ID = github.Int64(int64(len(g.ReleasesList) + 100))
+	// drafts are not available via GetReleaseByTag, only listed
+	if !release.GetDraft() {
+		g.RepositoryReleases[release.GetTagName()] = release
+	}
+	g.ReleasesList = append(g.ReleasesList, release)
+
+	return release, genResponse(201), nil
+}
+
+// EditRelease simulates the (github.RepositoriesService) EditRelease call
+func (g *RepoService) EditRelease(
+	ctx context.Context,
+	owner, repo string,
+	id int64,
+	release *github.RepositoryRelease,
+) (*github.RepositoryRelease, *github.Response, error) {
+	if g.ReturnValue.RepoServiceEditReleaseErr {
+		return nil, nil, fmt.Errorf("can't edit the release")
+	}
+
+	// the fields, which are not given, are kept
+	for _, re := range g.ReleasesList {
+		if re.GetID() == id {
+			if release.Body != nil {
+				re.Body = release.Body
+			}
+			if release.Draft != nil {
+				re.Draft = release.Draft
+			}
+			if release.Prerelease != nil {
+				re.Prerelease = release.Prerelease
+			}
+			return re, genResponse(200), nil
+		}
+	}
+
+	return nil, genResponse(404), fmt.Errorf("release %v is not present", id)
+}
+
 // Get simulates the (github.RepositoriesService) Get call
 func (g *RepoService) Get(
 	ctx context.Context,
@@ -115,9 +266,45 @@ func (g *RepoService) Get(
 	return nil, response, nil
 }
 
+// GitService simulates the github.GitService
+type GitService struct {
+	References  map[string]*github.Reference
+	Tags        map[string]*github.Tag
+	ReturnValue ReturnValueStr
+}
+
+// GetRef simulates the (github.GitService) GetRef call
+func (g *GitService) GetRef(
+	ctx context.Context,
+	owner string, repo string, ref string,
+) (*github.Reference, *github.Response, error) {
+
+	if g.ReturnValue.GitServiceGetRefErr {
+		return nil, nil, fmt.Errorf("can't fetch the reference")
+	}
+
+	if r, ok := g.References[ref]; ok {
+		return r, genResponse(200), nil
+	}
+	return nil, genResponse(404), fmt.Errorf("reference %v is not present", ref)
+}
+
+// GetTag simulates the (github.GitService) GetTag call
+func (g *GitService) GetTag(
+	ctx context.Context,
+	owner string, repo string, sha string,
+) (*github.Tag, *github.Response, error) {
+
+	if t, ok := g.Tags[sha]; ok {
+		return t, genResponse(200), nil
+	}
+	return nil, genResponse(404), fmt.Errorf("tag object %v is not present", sha)
+}
+
 // IssueService simulates the github.IssuesService
 type IssueService struct {
-	Issues      []*github.Issue
+	Issues      []*client.Issue
+	Milestones  []*github.Milestone
 	ReturnValue ReturnValueStr
 }
 
@@ -126,7 +313,7 @@ func (g *IssueService) ListByRepo(
 	ctx context.Context,
 	owner string, repo string,
 	opt *github.IssueListByRepoOptions,
-) ([]*github.Issue, *github.Response, error) {
+) ([]*client.Issue, *github.Response, error) {
 
 	if g.ReturnValue.IssueServiceListByRepoErr {
 		return nil, nil, fmt.Errorf("can't fetch the issues")
@@ -137,10 +324,28 @@ func (g *IssueService) ListByRepo(
 	return g.Issues[start:end], resp, nil
 }
 
+// ListMilestones simulates the (github.IssuesService) ListMilestones call
+func (g *IssueService) ListMilestones(
+	ctx context.Context,
+	owner string, repo string,
+	opt *github.MilestoneListOptions,
+) ([]*github.Milestone, *github.Response, error) {
+
+	if g.ReturnValue.IssueServiceListMilestonesErr {
+		return nil, nil, fmt.Errorf("can't fetch the milestones")
+	}
+
+	resp, start, end := calcPaging(opt.Page, opt.PerPage, len(g.Milestones))
+
+	return g.Milestones[start:end], resp, nil
+}
+
 // PullRequestsService simulates the github.PullRequestsService
 type PullRequestsService struct {
-	PRs         []*github.PullRequest
-	ReturnValue ReturnValueStr
+	PRs           []*github.PullRequest
+	Files         map[int][]*github.CommitFile
+	ClosingIssues map[int][]int
+	ReturnValue   ReturnValueStr
 }
 
 // List simulates the (github.PullRequestsService) ListByRepo call
@@ -158,38 +363,133 @@ func (g *PullRequestsService) List(
 	return g.PRs[start:end], resp, nil
 }
 
+// ListFiles simulates the (github.PullRequestsService) ListFiles call
+func (g *PullRequestsService) ListFiles(
+	ctx context.Context, owner string, repo string, number int,
+	opt *github.ListOptions,
+) ([]*github.CommitFile, *github.Response, error) {
+
+	if g.ReturnValue.PullRequestsListFilesErr {
+		return nil, nil, fmt.Errorf("can't fetch the PR files")
+	}
+
+	files := g.Files[number]
+	resp, start, end := calcPaging(opt.Page, opt.PerPage, len(files))
+
+	return files[start:end], resp, nil
+}
+
+// ListClosingIssues simulates the (client.PullRequestsService) ListClosingIssues call
+func (g *PullRequestsService) ListClosingIssues(
+	ctx context.Context, owner string, repo string,
+	numbers []int,
+) (map[int][]int, *github.Response, error) {
+
+	if g.ReturnValue.PullRequestsListClosingIssuesErr {
+		return nil, nil, fmt.Errorf("can't fetch the closing issues")
+	}
+
+	ret := map[int][]int{}
+	for _, n := range numbers {
+		if issues, ok := g.ClosingIssues[n]; ok {
+			ret[n] = issues
+		}
+	}
+
+	return ret, nil, nil
+}
+
 // newGitHubRepoService returns initialized instance of GitHubRepoService
 func newGitHubRepoService() *RepoService {
 	rtags := []*github.RepositoryTag{}
 	rcommits := map[string]*github.RepositoryCommit{}
 	rreleases := map[string]*github.RepositoryRelease{}
+	rreleaseslist := []*github.RepositoryRelease{}
+	rhistory := []*github.RepositoryCommit{}
+	parents := testdata.CommitParents()
 
 	for _, v := range testdata.Commits() {
-		rcommits[v.SHA] = genRepositoryCommit(v.SHA, v.AuthoredDate)
+		rcommits[v.SHA] = genRepositoryCommit(v.SHA, v.AuthoredDate, v.CommittedDate)
+	}
+
+	for _, v := range testdata.History() {
+		rhistory = append(rhistory, genHistoryCommit(
+			v.SHA, v.Title, v.AuthoredDate, v.CommittedDate, parents[v.SHA],
+		))
 	}
 
 	for _, v := range testdata.Tags() {
 		rtags = append(rtags, genRepositoryTag(v.Tag, rcommits[v.Commit].Commit))
+		// GitHub resolves the tag names as commit references too
+		rcommits[v.Tag] = rcommits[v.Commit]
 
 		if v.ReleaseTime != nil {
 			rreleases[v.Tag] = genRepositoryRelease(
 				v.Tag,
 				fmt.Sprintf("https://github.com/testowner/testrepo/releases/%v", v.Tag),
+				*v.ReleaseTime,
 			)
+			rreleases[v.Tag].ID = github.Int64(int64(len(rreleases)))
 		}
 	}
 
+	for _, v := range testdata.Releases() {
+		re, ok := rreleases[v.Tag]
+		if !ok {
+			// drafts are not available via GetReleaseByTag, only listed
+			re = genDraftRepositoryRelease(
+				v.Tag,
+				fmt.Sprintf("https://github.com/testowner/testrepo/releases/%v", v.Tag),
+				v.Commit,
+			)
+			re.ID = github.Int64(int64(1000 + len(rreleaseslist)))
+		}
+		re.Name = helpers.StringPtr(v.Name)
+		re.Body = helpers.StringPtr(v.Description)
+		re.Prerelease = github.Bool(v.Prerelease)
+		re.Draft = github.Bool(v.Draft)
+		rreleaseslist = append(rreleaseslist, re)
+	}
+
 	return &RepoService{
 		ReturnValue:        ReturnValue,
 		RepositoryTags:     rtags,
 		RepositoryCommits:  rcommits,
 		RepositoryReleases: rreleases,
+		ReleasesList:       rreleaseslist,
+		History:            rhistory,
+	}
+}
+
+// newGitHubGitService returns initialized instance of GitService
+// annotated tags get an own tag object, lightweight tags reference the commits
+func newGitHubGitService() *GitService {
+	rrefs := map[string]*github.Reference{}
+	rtags := map[string]*github.Tag{}
+
+	for _, v := range testdata.Tags() {
+		ref := "tags/" + v.Tag
+		if v.TagTime != nil {
+			sha := fmt.Sprintf("tag-%v", v.Commit)
+			rrefs[ref] = genReference("refs/"+ref, "tag", sha)
+			rtags[sha] = genTag(v.Tag, sha, *v.TagTime)
+		} else {
+			rrefs[ref] = genReference("refs/"+ref, "commit", v.Commit)
+		}
+	}
+
+	return &GitService{
+		ReturnValue: ReturnValue,
+		References:  rrefs,
+		Tags:        rtags,
 	}
 }
 
 // newGitHubIssueService returns initialized instance of GitHubIssueService
 func newGitHubIssueService() *IssueService {
-	rissues := []*github.Issue{}
+	rissues := []*client.Issue{}
+	rmilestones := []*github.Milestone{}
+	reasons := testdata.IssueCloseReasons()
 
 	for _, v := range testdata.Issues() {
 		var i *github.Issue
@@ -204,12 +504,26 @@ func newGitHubIssueService() *IssueService {
 				v.Labels,
 			)
 		}
-		rissues = append(rissues, i)
+		ci := &client.Issue{Issue: i}
+		if !v.PR {
+			ci.StateReason = reasons[v.ID]
+		}
+		rissues = append(rissues, ci)
+	}
+
+	for _, v := range testdata.Milestones() {
+		rmilestones = append(rmilestones, &github.Milestone{
+			Number:      getIntPtr(v.ID),
+			Title:       helpers.StringPtr(v.Title),
+			Description: helpers.StringPtr(v.Description),
+			HTMLURL:     helpers.StringPtr(fmt.Sprintf("https://github.com/testowner/testrepo/milestone/%v", v.ID)),
+		})
 	}
 
 	return &IssueService{
 		ReturnValue: ReturnValue,
 		Issues:      rissues,
+		Milestones:  rmilestones,
 	}
 }
 
@@ -217,19 +531,35 @@ func newGitHubIssueService() *IssueService {
 // completely filled with provided testdata
 func newGitHubPullRequestsService() *PullRequestsService {
 	rprs := []*github.PullRequest{}
+	rfiles := map[int][]*github.CommitFile{}
+	descriptions := testdata.MRDescriptions()
+
+	for id, files := range testdata.MRFiles() {
+		for _, f := range files {
+			rfiles[id] = append(rfiles[id], &github.CommitFile{Filename: helpers.StringPtr(f)})
+		}
+	}
 
 	for _, v := range testdata.MRs() {
 		rprs = append(rprs, genPR(
 			v.ID, v.Title,
 			fmt.Sprintf("https://example.com/pulls/%v", v.ID),
 			v.Username, fmt.Sprintf("https://example.com/users/%v", v.Username),
-			v.MergedAt, v.Labels,
+			v.MergedAt, v.MergeCommitSHA, v.Labels, descriptions[v.ID],
 		))
 	}
 
+	rclosing := testdata.MRClosedIssues()
+	// PR 14 is linked to the issue manually, without closing keywords
+	rclosing[2344] = []int{1284}
+	// the closing keywords of PR 2224 are not known to the API, e.g. the body was edited later
+	delete(rclosing, 2224)
+
 	return &PullRequestsService{
-		ReturnValue: ReturnValue,
-		PRs:         rprs,
+		ReturnValue:   ReturnValue,
+		PRs:           rprs,
+		Files:         rfiles,
+		ClosingIssues: rclosing,
 	}
 }
 
@@ -239,5 +569,6 @@ func New(_ context.Context, _ string) *client.Client {
 		Repositories: newGitHubRepoService(),
 		Issues:       newGitHubIssueService(),
 		PullRequests: newGitHubPullRequestsService(),
+		Git:          newGitHubGitService(),
 	}
 }
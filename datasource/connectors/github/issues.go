@@ -20,6 +20,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/artem-sidorenko/chagen/datasource/connectors/github/internal/client"
 	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
 
 	"github.com/artem-sidorenko/chagen/data"
@@ -48,7 +49,7 @@ func (c *Connector) Issues(
 	cmaxissues <-chan int,
 ) {
 	// for detailed comments, please see the tags.go
-	issues := make(chan []*github.Issue)
+	issues := make(chan []*client.Issue)
 	maxissues := make(chan int)
 	issuescounter := make(chan bool, 100)
 
@@ -120,7 +121,7 @@ func (c *Connector) Issues(
 func (c *Connector) processIssuesPage(
 	ctx context.Context,
 	page int,
-	ret chan<- []*github.Issue,
+	ret chan<- []*client.Issue,
 ) (
 	resp *github.Response,
 	issuesCount int,
@@ -155,7 +156,7 @@ func (c *Connector) processIssuesPages(
 	ctx context.Context,
 	cerr chan<- error,
 	cmaxissues chan<- int,
-	issues chan<- []*github.Issue,
+	issues chan<- []*client.Issue,
 	wg *sync.WaitGroup,
 	lastPage int,
 ) (cpages chan<- int) {
@@ -187,7 +188,7 @@ func (c *Connector) processIssuesPages(
 func (c *Connector) processIssues(
 	ctx context.Context,
 	_ chan<- error,
-	cissues <-chan []*github.Issue,
+	cissues <-chan []*client.Issue,
 	cissuescounter chan<- bool,
 	wg *sync.WaitGroup,
 ) <-chan data.Issue {
@@ -215,11 +216,12 @@ func (c *Connector) processIssues(
 					}
 
 					issue := data.Issue{
-						ID:         issue.GetNumber(),
-						Name:       issue.GetTitle(),
-						ClosedDate: issue.GetClosedAt().UTC(),
-						URL:        issue.GetHTMLURL(),
-						Labels:     lbs,
+						ID:          issue.GetNumber(),
+						Name:        issue.GetTitle(),
+						ClosedDate:  issue.GetClosedAt().UTC(),
+						URL:         issue.GetHTMLURL(),
+						Labels:      lbs,
+						CloseReason: issue.StateReason,
 					}
 
 					select {
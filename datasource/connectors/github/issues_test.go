@@ -42,11 +42,12 @@ func TestConnector_Issues(t *testing.T) {
 			name: "API returns proper data",
 			want: data.Issues{
 				data.Issue{
-					ID:         1234,
-					Name:       "Test issue title 13",
-					ClosedDate: helpers.Time(1048293647),
-					URL:        "http://example.com/issues/1234",
-					Labels:     []string{"enhancement"},
+					ID:          1234,
+					Name:        "Test issue title 13",
+					ClosedDate:  helpers.Time(1048293647),
+					URL:         "http://example.com/issues/1234",
+					CloseReason: data.CloseReasonCompleted,
+					Labels:      []string{"enhancement"},
 				},
 				data.Issue{
 					ID:         1224,
@@ -56,18 +57,20 @@ func TestConnector_Issues(t *testing.T) {
 					Labels:     []string{"issue12"},
 				},
 				data.Issue{
-					ID:         1304,
-					Name:       "Test issue title 10",
-					ClosedDate: helpers.Time(1047993647),
-					URL:        "http://example.com/issues/1304",
-					Labels:     []string{"wontfix"},
+					ID:          1304,
+					Name:        "Test issue title 10",
+					ClosedDate:  helpers.Time(1047993647),
+					URL:         "http://example.com/issues/1304",
+					CloseReason: data.CloseReasonNotPlanned,
+					Labels:      []string{"wontfix"},
 				},
 				data.Issue{
-					ID:         1294,
-					Name:       "Test issue title 9",
-					ClosedDate: helpers.Time(1047893647),
-					URL:        "http://example.com/issues/1294",
-					Labels:     []string(nil),
+					ID:          1294,
+					Name:        "Test issue title 9",
+					ClosedDate:  helpers.Time(1047893647),
+					URL:         "http://example.com/issues/1294",
+					CloseReason: data.CloseReasonCompleted,
+					Labels:      []string(nil),
 				},
 				data.Issue{
 					ID:         1274,
@@ -84,11 +87,12 @@ func TestConnector_Issues(t *testing.T) {
 					Labels:     []string{"invalid"},
 				},
 				data.Issue{
-					ID:         1244,
-					Name:       "Test issue title 4",
-					ClosedDate: helpers.Time(1047393647),
-					URL:        "http://example.com/issues/1244",
-					Labels:     []string(nil),
+					ID:          1244,
+					Name:        "Test issue title 4",
+					ClosedDate:  helpers.Time(1047393647),
+					URL:         "http://example.com/issues/1244",
+					CloseReason: data.CloseReasonNotPlanned,
+					Labels:      []string(nil),
 				},
 				data.Issue{
 					ID:         1227,
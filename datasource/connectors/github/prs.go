@@ -31,6 +31,9 @@ import (
 // PRsPerPage defined how many PRs are fetched per page
 var PRsPerPage = 30 // nolint: gochecknoglobals
 
+// PRFilesPerPage defined how many changed files of a PR are fetched per page
+var PRFilesPerPage = 100 // nolint: gochecknoglobals
+
 const (
 	prsProcessingRoutines = 10
 )
@@ -104,7 +107,9 @@ func (c *Connector) MRs(
 		}()
 	}()
 
-	dmrs := c.processPRs(ctx, cerr, mrs, mrscounter, &wgT)
+	// the errors of PR processing are sent directly and not via scerr,
+	// so they are delivered before the MR channel is closed
+	dmrs := c.processPRs(sctx, cerr, mrs, mrscounter, &wgT)
 
 	go func() {
 		wgTP.Wait()
@@ -190,7 +195,7 @@ func (c *Connector) processPRPages(
 // possible errors are returned via given cerr channel
 func (c *Connector) processPRs(
 	ctx context.Context,
-	_ chan<- error,
+	cerr chan<- error,
 	cprs <-chan []*github.PullRequest,
 	cmrscounter chan<- bool,
 	wg *sync.WaitGroup,
@@ -204,6 +209,12 @@ func (c *Connector) processPRs(
 			defer wg.Done()
 
 			for prs := range cprs {
+				closes, err := c.closingIssues(ctx, prs)
+				if err != nil {
+					helpers.NonBlockingErrSend(ctx, cerr, err)
+					return
+				}
+
 				for _, pr := range prs {
 					cmrscounter <- true
 					// we need only merged PRs, skip everything else
@@ -219,13 +230,27 @@ func (c *Connector) processPRs(
 					}
 
 					pr := data.MR{
-						ID:         pr.GetNumber(),
-						Name:       pr.GetTitle(),
-						MergedDate: pr.GetMergedAt().UTC(),
-						URL:        pr.GetHTMLURL(),
-						Author:     pr.User.GetLogin(),
-						AuthorURL:  pr.User.GetHTMLURL(),
-						Labels:     lbs,
+						ID:          pr.GetNumber(),
+						Name:        pr.GetTitle(),
+						MergedDate:  pr.GetMergedAt().UTC(),
+						URL:         pr.GetHTMLURL(),
+						Author:      pr.User.GetLogin(),
+						AuthorURL:   pr.User.GetHTMLURL(),
+						Labels:      lbs,
+						Description: pr.GetBody(),
+						Closes:      closes[pr.GetNumber()],
+						MergeCommit: pr.GetMergeCommitSHA(),
+						IssueKeys: data.ParseIssueKeys(
+							pr.GetTitle(), pr.GetBody(), pr.GetHead().GetRef()),
+					}
+
+					if c.FetchFiles {
+						files, err := c.prFiles(ctx, pr.ID)
+						if err != nil {
+							helpers.NonBlockingErrSend(ctx, cerr, err)
+							return
+						}
+						pr.Files = files
 					}
 
 					select {
@@ -245,3 +270,68 @@ func (c *Connector) processPRs(
 
 	return ret
 }
+
+// closingIssues returns the numbers of issues, which are closed by the given merged PRs.
+// The closing keywords in the body are parsed, the linked issues are queried
+// via GraphQL API additionally if the access token is given
+func (c *Connector) closingIssues(
+	ctx context.Context,
+	prs []*github.PullRequest,
+) (map[int][]int, error) {
+	var numbers []int
+	ret := map[int][]int{}
+	for _, pr := range prs {
+		if pr.GetMergedAt() == (time.Time{}) {
+			continue
+		}
+		numbers = append(numbers, pr.GetNumber())
+		ret[pr.GetNumber()] = data.ParseClosingReferences(pr.GetBody())
+	}
+	if !c.LinkedIssues || len(numbers) == 0 {
+		return ret, nil
+	}
+
+	linked, _, err := c.client.PullRequests.ListClosingIssues(ctx, c.Owner, c.Repo, numbers)
+	if err != nil {
+		return nil, formatErrorCode("closingIssues", err)
+	}
+	// the issues might be linked manually or referenced in the body only
+	for number, issues := range linked {
+		for _, issue := range issues {
+			if !containsInt(ret[number], issue) {
+				ret[number] = append(ret[number], issue)
+			}
+		}
+	}
+	return ret, nil
+}
+
+// containsInt returns true if the slice contains the given value
+func containsInt(slice []int, v int) bool {
+	for _, s := range slice {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// prFiles returns the names of files changed by the given PR
+func (c *Connector) prFiles(ctx context.Context, number int) ([]string, error) {
+	var ret []string
+	opts := &github.ListOptions{Page: 1, PerPage: PRFilesPerPage}
+	for {
+		files, resp, err := c.client.PullRequests.ListFiles(ctx, c.Owner, c.Repo, number, opts)
+		if err != nil {
+			return nil, formatErrorCode("prFiles", err)
+		}
+		for _, f := range files {
+			ret = append(ret, f.GetFilename())
+		}
+
+		if resp.NextPage == 0 {
+			return ret, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
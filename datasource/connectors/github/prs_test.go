@@ -19,6 +19,7 @@ package github_test
 import (
 	"context"
 	"errors"
+	"os"
 	"reflect"
 	"sort"
 	"testing"
@@ -42,112 +43,132 @@ func TestConnector_MRs(t *testing.T) {
 			name: "API returns proper data",
 			want: data.MRs{
 				data.MR{
-					ID:         2344,
-					Name:       "Test PR title 14",
-					URL:        "https://example.com/pulls/2344",
-					Author:     "te77st-user",
-					AuthorURL:  "https://example.com/users/te77st-user",
-					MergedDate: helpers.Time(1048394647),
-					Labels:     []string{"bugfix"},
+					ID:          2344,
+					Name:        "Test PR title 14",
+					URL:         "https://example.com/pulls/2344",
+					Author:      "te77st-user",
+					AuthorURL:   "https://example.com/users/te77st-user",
+					MergedDate:  helpers.Time(1048394647),
+					MergeCommit: "9618c791ab1f643aeffb7c5e1abe5877223aaa91",
+					Labels:      []string{"bugfix"},
 				},
 				data.MR{
-					ID:         2334,
-					Name:       "Test PR title 13",
-					URL:        "https://example.com/pulls/2334",
-					Author:     "test-user",
-					AuthorURL:  "https://example.com/users/test-user",
-					MergedDate: helpers.Time(1048294647),
-					Labels:     []string{"bugfix"},
+					ID:          2334,
+					Name:        "Test PR title 13",
+					URL:         "https://example.com/pulls/2334",
+					Author:      "test-user",
+					AuthorURL:   "https://example.com/users/test-user",
+					MergedDate:  helpers.Time(1048294647),
+					MergeCommit: "c31af03759e2262d99b2c4a7571a8e0115f37d68",
+					Labels:      []string{"bugfix"},
+					Description: "Closes #1234 and #1224",
+					Closes:      []int{1234, 1224},
 				},
 				data.MR{
-					ID:         2314,
-					Name:       "Test PR title 11",
-					URL:        "https://example.com/pulls/2314",
-					Author:     "test-user8",
-					AuthorURL:  "https://example.com/users/test-user8",
-					MergedDate: helpers.Time(1048094647),
-					Labels:     []string{"no changelog"},
+					ID:          2314,
+					Name:        "Test PR title 11",
+					URL:         "https://example.com/pulls/2314",
+					Author:      "test-user8",
+					AuthorURL:   "https://example.com/users/test-user8",
+					MergedDate:  helpers.Time(1048094647),
+					MergeCommit: "627b94d1e87e938ea140c592f3ebd115d5a98929",
+					Labels:      []string{"no changelog"},
 				},
 				data.MR{
-					ID:         2304,
-					Name:       "Test PR title 10",
-					URL:        "https://example.com/pulls/2304",
-					Author:     "test-user",
-					AuthorURL:  "https://example.com/users/test-user",
-					MergedDate: helpers.Time(1047994647),
-					Labels:     []string{"bugfix"},
+					ID:          2304,
+					Name:        "Test PR title 10",
+					URL:         "https://example.com/pulls/2304",
+					Author:      "test-user",
+					AuthorURL:   "https://example.com/users/test-user",
+					MergedDate:  helpers.Time(1047994647),
+					MergeCommit: "9772a06643b77ec1a16646df4bb909c771c09fba",
+					Labels:      []string{"bugfix"},
 				},
 				data.MR{
-					ID:         2294,
-					Name:       "Test PR title 9",
-					URL:        "https://example.com/pulls/2294",
-					Author:     "test-user",
-					AuthorURL:  "https://example.com/users/test-user",
-					MergedDate: helpers.Time(1047894647),
-					Labels:     []string{"bugfix"},
+					ID:          2294,
+					Name:        "Test PR title 9",
+					URL:         "https://example.com/pulls/2294",
+					Author:      "test-user",
+					AuthorURL:   "https://example.com/users/test-user",
+					MergedDate:  helpers.Time(1047894647),
+					MergeCommit: "cc1cf9b1441962bdd6b98a4e09363dffb2037835",
+					Labels:      []string{"bugfix"},
+					Description: "Resolves #1294\n\nSee also #1284",
+					Closes:      []int{1294},
 				},
 				data.MR{
-					ID:         2284,
-					Name:       "Test PR title 8",
-					URL:        "https://example.com/pulls/2284",
-					Author:     "test-user",
-					AuthorURL:  "https://example.com/users/test-user",
-					MergedDate: helpers.Time(1047794647),
-					Labels:     []string{"invalid"},
+					ID:          2284,
+					Name:        "Test PR title 8",
+					URL:         "https://example.com/pulls/2284",
+					Author:      "test-user",
+					AuthorURL:   "https://example.com/users/test-user",
+					MergedDate:  helpers.Time(1047794647),
+					MergeCommit: "fd81ac08493e550604dd04fa39b9c2eb1907cea6",
+					Labels:      []string{"invalid"},
 				},
 				data.MR{
-					ID:         2274,
-					Name:       "Test PR title 7",
-					URL:        "https://example.com/pulls/2274",
-					Author:     "test5-user",
-					AuthorURL:  "https://example.com/users/test5-user",
-					MergedDate: helpers.Time(1047694647),
-					Labels:     []string{"bugfix"},
+					ID:          2274,
+					Name:        "Test PR title 7",
+					URL:         "https://example.com/pulls/2274",
+					Author:      "test5-user",
+					AuthorURL:   "https://example.com/users/test5-user",
+					MergedDate:  helpers.Time(1047694647),
+					MergeCommit: "d4c421f840e35fb15ae99683df23caf451db7377",
+					Labels:      []string{"bugfix"},
 				},
 				data.MR{
-					ID:         2264,
-					Name:       "Test PR title 6",
-					URL:        "https://example.com/pulls/2264",
-					Author:     "test-user",
-					AuthorURL:  "https://example.com/users/test-user",
-					MergedDate: helpers.Time(1047594647),
-					Labels:     []string{"enhancement"},
+					ID:          2264,
+					Name:        "Test PR title 6",
+					URL:         "https://example.com/pulls/2264",
+					Author:      "test-user",
+					AuthorURL:   "https://example.com/users/test-user",
+					MergedDate:  helpers.Time(1047594647),
+					MergeCommit: "e5bc67e0c5d2ed17639a6499d1d0c05d4073dc80",
+					Labels:      []string{"enhancement"},
 				},
 				data.MR{
-					ID:         2254,
-					Name:       "Test PR title 5",
-					URL:        "https://example.com/pulls/2254",
-					Author:     "test-user",
-					AuthorURL:  "https://example.com/users/test-user",
-					MergedDate: helpers.Time(1047494647),
-					Labels:     []string{"bugfix"},
+					ID:          2254,
+					Name:        "Test PR title 5",
+					URL:         "https://example.com/pulls/2254",
+					Author:      "test-user",
+					AuthorURL:   "https://example.com/users/test-user",
+					MergedDate:  helpers.Time(1047494647),
+					MergeCommit: "433a7f849f0a5c21a0f24886ff72a91e1e74888e",
+					Labels:      []string{"bugfix"},
 				},
 				data.MR{
-					ID:         2234,
-					Name:       "Test PR title 3",
-					URL:        "https://example.com/pulls/2234",
-					Author:     "test-user",
-					AuthorURL:  "https://example.com/users/test-user",
-					MergedDate: helpers.Time(1047294647),
-					Labels:     []string{"enhancement", "bugfix"},
+					ID:          2234,
+					Name:        "Test PR title 3",
+					URL:         "https://example.com/pulls/2234",
+					Author:      "test-user",
+					AuthorURL:   "https://example.com/users/test-user",
+					MergedDate:  helpers.Time(1047294647),
+					MergeCommit: "d72866aa0a25e58b7fb0365fba0fd6791d627451",
+					Labels:      []string{"enhancement", "bugfix"},
 				},
 				data.MR{
-					ID:         2224,
-					Name:       "Test PR title 2",
-					URL:        "https://example.com/pulls/2224",
-					Author:     "test-user2",
-					AuthorURL:  "https://example.com/users/test-user2",
-					MergedDate: helpers.Time(1047194647),
-					Labels:     []string(nil),
+					ID:          2224,
+					Name:        "Test PR title 2",
+					URL:         "https://example.com/pulls/2224",
+					Author:      "test-user2",
+					AuthorURL:   "https://example.com/users/test-user2",
+					MergedDate:  helpers.Time(1047194647),
+					MergeCommit: "1080a10971e4a887ae8a827bb16e0b04801f630b",
+					Labels:      []string(nil),
+					Description: "Some cleanup, closes #1227",
+					Closes:      []int{1227},
 				},
 				data.MR{
-					ID:         2214,
-					Name:       "Test PR title 1",
-					URL:        "https://example.com/pulls/2214",
-					Author:     "test-user",
-					AuthorURL:  "https://example.com/users/test-user",
-					MergedDate: helpers.Time(1047094647),
-					Labels:     []string{"bugfix"},
+					ID:          2214,
+					Name:        "Test PR title 1",
+					URL:         "https://example.com/pulls/2214",
+					Author:      "test-user",
+					AuthorURL:   "https://example.com/users/test-user",
+					MergedDate:  helpers.Time(1047094647),
+					MergeCommit: "041152be02b2d69141d3a8d2278460f4777474f7",
+					Labels:      []string{"bugfix"},
+					Description: "Fixes #1214",
+					Closes:      []int{1214},
 				},
 			},
 			// wantMaxMRs > len(want), as we sorting out the closed non-merged PRs
@@ -203,3 +224,167 @@ func TestConnector_MRs(t *testing.T) {
 		})
 	}
 }
+
+func TestConnector_MRsFiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		flags       map[string]string
+		returnValue testclient.ReturnValueStr
+		want        map[int][]string
+		wantErr     error
+	}{
+		{
+			name:  "Files are fetched with path filter",
+			flags: map[string]string{"path": "docs"},
+			want: map[int][]string{
+				2214: {"README.md"},
+				2224: {"cli/cli.go", "cli/commands/commands.go", "docs/cli.md"},
+				2294: {"data/tags.go"},
+				2334: {"docs/usage.md"},
+				2344: {"data/releases.go", "data/releases_test.go"},
+			},
+		},
+		{
+			name: "Files are not fetched without path filter",
+			returnValue: testclient.ReturnValueStr{
+				PullRequestsListFilesErr: true,
+			},
+			want: map[int][]string{},
+		},
+		{
+			name:  "ListFiles call fails",
+			flags: map[string]string{"path": "docs"},
+			returnValue: testclient.ReturnValueStr{
+				PullRequestsListFilesErr: true,
+			},
+			wantErr: errors.New("GitHub query 'prFiles' failed: can't fetch the PR files"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			github.PRsPerPage = 5
+			github.PRFilesPerPage = 2
+			c := setupTestConnectorWithFlags(tt.returnValue, tt.flags)
+			cerr := make(chan error, 1)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cgot, _, cmaxmrs := c.MRs(ctx, cerr)
+			helpers.GetChannelValuesInt(cmaxmrs)
+
+			got := map[int][]string{}
+			var err error
+		loop:
+			for {
+				select {
+				case mr, ok := <-cgot:
+					if !ok {
+						break loop
+					}
+					if mr.Files != nil {
+						got[mr.ID] = mr.Files
+					}
+				case err = <-cerr:
+					break loop
+				}
+			}
+			// the error is sent before the MR channel is closed
+			if err == nil {
+				select {
+				case err = <-cerr:
+				default:
+				}
+			}
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Connector.MRs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Connector.MRs() files = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnector_MRsClosingIssues(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		returnValue testclient.ReturnValueStr
+		want        map[int][]int
+		wantErr     error
+	}{
+		{
+			name: "Closing keywords are parsed without access token",
+			returnValue: testclient.ReturnValueStr{
+				PullRequestsListClosingIssuesErr: true,
+			},
+			want: map[int][]int{
+				2214: {1214},
+				2224: {1227},
+				2294: {1294},
+				2334: {1234, 1224},
+			},
+		},
+		{
+			name:  "Linked issues are merged with the closing keywords with access token",
+			token: "testtoken",
+			want: map[int][]int{
+				2214: {1214},
+				2224: {1227},
+				2294: {1294},
+				2334: {1234, 1224},
+				2344: {1284},
+			},
+		},
+		{
+			name:  "ListClosingIssues call fails",
+			token: "testtoken",
+			returnValue: testclient.ReturnValueStr{
+				PullRequestsListClosingIssuesErr: true,
+			},
+			wantErr: errors.New("GitHub query 'closingIssues' failed: can't fetch the closing issues"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(github.AccessTokenEnvVar, tt.token) // nolint: errcheck
+			defer os.Unsetenv(github.AccessTokenEnvVar)   // nolint: errcheck
+
+			github.PRsPerPage = 5
+			c := setupTestConnector(tt.returnValue, false)
+			cerr := make(chan error, 1)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cgot, _, cmaxmrs := c.MRs(ctx, cerr)
+			go helpers.GetChannelValuesInt(cmaxmrs)
+
+			got := map[int][]int{}
+			var err error
+		loop:
+			for {
+				select {
+				case mr, ok := <-cgot:
+					if !ok {
+						break loop
+					}
+					if mr.Closes != nil {
+						got[mr.ID] = mr.Closes
+					}
+				case err = <-cerr:
+					break loop
+				}
+			}
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Connector.MRs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Connector.MRs() closes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package github
+
+import (
+	"context"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+
+	"github.com/google/go-github/github"
+)
+
+// PublishRelease creates or updates the GitHub release for the given tag.
+// The draft and prerelease states of existing releases are changed only if given
+func (c *Connector) PublishRelease(
+	opts connectors.PublishOptions,
+) (connectors.PublishStatus, error) {
+	release, err := c.findRelease(c.context, opts.Tag)
+	if err != nil {
+		return "", err
+	}
+
+	if release == nil {
+		_, _, err = c.client.Repositories.CreateRelease(c.context, c.Owner, c.Repo,
+			&github.RepositoryRelease{
+				TagName:    github.String(opts.Tag),
+				Name:       github.String(opts.Tag),
+				Body:       github.String(opts.Body),
+				Draft:      github.Bool(opts.Draft != nil && *opts.Draft),
+				Prerelease: github.Bool(opts.Prerelease != nil && *opts.Prerelease),
+			})
+		if err != nil {
+			return "", formatErrorCode("PublishRelease", err)
+		}
+		return connectors.PublishCreated, nil
+	}
+
+	if opts.OnlyIfChanged &&
+		release.GetBody() == opts.Body &&
+		(opts.Draft == nil || release.GetDraft() == *opts.Draft) &&
+		(opts.Prerelease == nil || release.GetPrerelease() == *opts.Prerelease) {
+		return connectors.PublishUnchanged, nil
+	}
+
+	_, _, err = c.client.Repositories.EditRelease(c.context, c.Owner, c.Repo, release.GetID(),
+		&github.RepositoryRelease{
+			Body:       github.String(opts.Body),
+			Draft:      opts.Draft,
+			Prerelease: opts.Prerelease,
+		})
+	if err != nil {
+		return "", formatErrorCode("PublishRelease", err)
+	}
+	return connectors.PublishUpdated, nil
+}
+
+// findRelease returns the release of given tag or nil, if it does not exist.
+// The releases are listed, as the draft releases can't be found by tag
+func (c *Connector) findRelease(
+	ctx context.Context,
+	tagName string,
+) (*github.RepositoryRelease, error) {
+	opt := &github.ListOptions{Page: 1, PerPage: ReleasesPerPage}
+	for {
+		releases, resp, err := c.client.Repositories.ListReleases(ctx, c.Owner, c.Repo, opt)
+		if err != nil {
+			return nil, formatErrorCode("PublishRelease", err)
+		}
+		for _, release := range releases {
+			if release.GetTagName() == tagName {
+				return release, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
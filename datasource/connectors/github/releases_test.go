@@ -0,0 +1,166 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package github_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/github"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/github/internal/testclient"
+	"github.com/artem-sidorenko/chagen/internal/testing/helpers"
+)
+
+func TestConnector_Releases(t *testing.T) {
+	releases := data.Tags{
+		{
+			Name:        "v0.1.2",
+			Commit:      "d8351413f688c96c2c5d6fe58ebf5ac17f545bc0",
+			Date:        helpers.Time(1048183657),
+			URL:         "https://github.com/testowner/testrepo/releases/v0.1.2",
+			Title:       "Release v0.1.2",
+			Description: "Highlights of v0.1.2",
+		},
+		{
+			Name:   "v0.1.1",
+			Commit: "fc5d68ff1cf691e09f6ead044813274953c9b843",
+			Date:   helpers.Time(1048083657),
+			URL:    "https://github.com/testowner/testrepo/releases/v0.1.1",
+			Title:  "Release v0.1.1",
+		},
+		{
+			Name:        "v0.1.0",
+			Commit:      "dbbf36ffaae700a2ce03ef849d6f944031f34b95",
+			Date:        helpers.Time(1047983657),
+			URL:         "https://github.com/testowner/testrepo/releases/v0.1.0",
+			Title:       "Preview of v0.1",
+			Description: "Try the new features",
+			Prerelease:  true,
+		},
+		{
+			Name:        "v0.0.7",
+			Commit:      "d21438494dd0722c1d13dc496ae1f60fb85084c1",
+			Date:        helpers.Time(1047683657),
+			URL:         "https://github.com/testowner/testrepo/releases/v0.0.7",
+			Title:       "Release v0.0.7",
+			Description: "Some bugfixes",
+		},
+		{
+			Name:   "v0.0.3",
+			Commit: "52f214dc3bf6c0e2a87eae6eab363a317c5a665f",
+			Date:   helpers.Time(1047283657),
+			URL:    "https://github.com/testowner/testrepo/releases/v0.0.3",
+			Title:  "Release v0.0.3",
+		},
+		{
+			Name:        "v0.0.1",
+			Commit:      "7d84cdb2f7c2d4619cda4b8adeb1897097b5c8fc",
+			Date:        helpers.Time(1047083657),
+			URL:         "https://github.com/testowner/testrepo/releases/v0.0.1",
+			Title:       "First release",
+			Description: "The very first release",
+		},
+	}
+	draft := data.Tag{
+		Name:        "v0.2.0",
+		Commit:      "9618c791ab1f643aeffb7c5e1abe5877223aaa91",
+		Date:        helpers.Time(1048394677),
+		URL:         "https://github.com/testowner/testrepo/releases/v0.2.0",
+		Title:       "Upcoming v0.2.0",
+		Description: "Work in progress",
+		Draft:       true,
+	}
+
+	tests := []struct {
+		name        string
+		flags       map[string]string
+		returnValue testclient.ReturnValueStr
+		want        data.Tags
+		wantErr     error
+		wantMaxtags []int
+	}{
+		{
+			name:        "Releases without drafts",
+			want:        releases,
+			wantMaxtags: []int{7},
+		},
+		{
+			name:        "Releases with drafts",
+			flags:       map[string]string{"include-draft-releases": "true"},
+			want:        append(data.Tags{draft}, releases...),
+			wantMaxtags: []int{7},
+		},
+		{
+			name: "ListReleases call fails",
+			returnValue: testclient.ReturnValueStr{
+				RepoServiceListReleasesErr: true,
+			},
+			wantErr: errors.New("can't fetch the releases"),
+		},
+		{
+			name: "GetCommit call fails",
+			returnValue: testclient.ReturnValueStr{
+				RepoServiceGetCommitsErr: true,
+			},
+			wantErr: errors.New("can't fetch the commit"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			github.ReleasesPerPage = 3
+			c := setupTestConnectorWithFlags(tt.returnValue, tt.flags)
+			cerr := make(chan error, 1)
+
+			cgot, _, cmaxtags := c.(*github.Connector).Releases(context.Background(), cerr)
+			gotmaxtags := helpers.GetChannelValuesInt(cmaxtags)
+
+			var got data.Tags
+			for t := range cgot {
+				got = append(got, t)
+			}
+			// sort the tags to have the stable order
+			sort.Sort(&got)
+
+			// sleep and allow the possible error to be delivered to the channel
+			time.Sleep(time.Millisecond * 200)
+			var err error
+			select {
+			case err = <-cerr:
+			default:
+			}
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Connector.Releases() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err == nil {
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Connector.Releases() = %+v, want %+v", got, tt.want)
+				}
+				if !reflect.DeepEqual(gotmaxtags, tt.wantMaxtags) {
+					t.Errorf("Connector.Releases() maxtags = %v, want %v", gotmaxtags, tt.wantMaxtags)
+				}
+			}
+		})
+	}
+}
@@ -19,7 +19,9 @@ package github
 import (
 	"context"
 	"sync"
+	"time"
 
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
 	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
 
 	"github.com/artem-sidorenko/chagen/data"
@@ -237,17 +239,32 @@ func (c *Connector) processTags(
 						return
 					}
 
-					tagURL, err := c.getTagURL(tagName, false)
+					release, err := c.getRelease(ctx, tagName)
+					if err != nil {
+						helpers.NonBlockingErrSend(ctx, cerr, err)
+						return
+					}
+
+					tagURL, err := c.buildTagURL(tagName, release, false)
+					if err != nil {
+						helpers.NonBlockingErrSend(ctx, cerr, err)
+						return
+					}
+
+					// the annotated tag is resolved once for the date and the description
+					needsDescription := c.FetchDescriptions && release.GetBody() == ""
+					annotated, err := c.annotatedTag(ctx, tagName, needsDescription)
 					if err != nil {
 						helpers.NonBlockingErrSend(ctx, cerr, err)
 						return
 					}
 
 					tag := data.Tag{
-						Name:   tagName,
-						Commit: commit.Commit.GetSHA(),
-						Date:   commit.Commit.Committer.GetDate().UTC(),
-						URL:    tagURL,
+						Name:        tagName,
+						Commit:      commit.Commit.GetSHA(),
+						Date:        c.tagDate(commit, annotated, release),
+						URL:         tagURL,
+						Description: c.tagDescription(annotated, release),
 					}
 
 					select {
@@ -267,3 +284,81 @@ func (c *Connector) processTags(
 
 	return ret
 }
+
+// tagDate returns the date of given tag according to the configured TagDateSource.
+// The committer date is used if nothing else is configured or if the configured
+// source isn't available for this tag (lightweight tag or no release)
+func (c *Connector) tagDate(
+	commit *github.RepositoryCommit,
+	annotated *github.Tag,
+	release *github.RepositoryRelease,
+) time.Time {
+	switch c.TagDateSource {
+	case connectors.TagDateAuthor:
+		return commit.Commit.Author.GetDate().UTC()
+	case connectors.TagDateTag:
+		if annotated != nil {
+			return annotated.Tagger.GetDate().UTC()
+		}
+	case connectors.TagDateRelease:
+		if release != nil && release.PublishedAt != nil {
+			return release.PublishedAt.UTC()
+		}
+	}
+
+	return commit.Commit.Committer.GetDate().UTC()
+}
+
+// tagDescription returns the description of given tag: the body of the release
+// or the message of the annotated tag if there is no release body.
+// Nothing is returned if the descriptions aren't fetched
+func (c *Connector) tagDescription(
+	annotated *github.Tag,
+	release *github.RepositoryRelease,
+) string {
+	if !c.FetchDescriptions {
+		return ""
+	}
+	if release.GetBody() != "" {
+		return release.GetBody()
+	}
+
+	return annotated.GetMessage()
+}
+
+// annotatedTag returns the git tag object of given tag, if it is needed for
+// the configured TagDateSource or for the description. The API is queried only
+// in this case, nil is returned if it isn't needed or for lightweight tags
+func (c *Connector) annotatedTag(
+	ctx context.Context,
+	tagName string,
+	needsDescription bool,
+) (*github.Tag, error) {
+	if c.TagDateSource != connectors.TagDateTag && !needsDescription {
+		return nil, nil
+	}
+
+	tag, err := c.getAnnotatedTag(ctx, tagName)
+	if err != nil {
+		return nil, formatErrorCode("annotatedTag", err)
+	}
+	return tag, nil
+}
+
+// getAnnotatedTag returns the git tag object of given tag.
+// Returns nil for lightweight tags, as only annotated tags have own objects
+func (c *Connector) getAnnotatedTag(ctx context.Context, tagName string) (*github.Tag, error) {
+	ref, _, err := c.client.Git.GetRef(ctx, c.Owner, c.Repo, "tags/"+tagName)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Object.GetType() != "tag" {
+		return nil, nil
+	}
+
+	tag, _, err := c.client.Git.GetTag(ctx, c.Owner, c.Repo, ref.Object.GetSHA())
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
@@ -42,76 +42,81 @@ func TestConnector_Tags(t *testing.T) {
 			name: "API returns proper data",
 			want: data.Tags{
 				{
-					Name:   "v0.1.2",
-					Commit: "d8351413f688c96c2c5d6fe58ebf5ac17f545bc0",
-					Date:   helpers.Time(1048183647),
-					URL:    "https://github.com/testowner/testrepo/releases/v0.1.2",
+					Name:        "v0.1.2",
+					Commit:      "d8351413f688c96c2c5d6fe58ebf5ac17f545bc0",
+					Date:        helpers.Time(1048183677),
+					URL:         "https://github.com/testowner/testrepo/releases/v0.1.2",
+					Description: "Highlights of v0.1.2",
 				},
 				{
 					Name:   "v0.1.1",
 					Commit: "fc5d68ff1cf691e09f6ead044813274953c9b843",
-					Date:   helpers.Time(1048083647),
+					Date:   helpers.Time(1048083677),
 					URL:    "https://github.com/testowner/testrepo/releases/v0.1.1",
 				},
 				{
-					Name:   "v0.1.0",
-					Commit: "dbbf36ffaae700a2ce03ef849d6f944031f34b95",
-					Date:   helpers.Time(1047983647),
-					URL:    "https://github.com/testowner/testrepo/releases/v0.1.0",
+					Name:        "v0.1.0",
+					Commit:      "dbbf36ffaae700a2ce03ef849d6f944031f34b95",
+					Date:        helpers.Time(1047983677),
+					URL:         "https://github.com/testowner/testrepo/releases/v0.1.0",
+					Description: "Try the new features",
 				},
 				{
 					Name:   "v0.0.9",
 					Commit: "fc9f16ecc043e3fe422834cd127311d11d423668",
-					Date:   helpers.Time(1047883647),
+					Date:   helpers.Time(1047883677),
 					URL:    "https://github.com/testowner/testrepo/tree/v0.0.9",
 				},
 				{
 					Name:   "v0.0.8",
 					Commit: "8d8d817a530bc1c3f792d9508c187b5769c434c5",
-					Date:   helpers.Time(1047783647),
+					Date:   helpers.Time(1047783677),
 					URL:    "https://github.com/testowner/testrepo/tree/v0.0.8",
 				},
 				{
-					Name:   "v0.0.7",
-					Commit: "d21438494dd0722c1d13dc496ae1f60fb85084c1",
-					Date:   helpers.Time(1047683647),
-					URL:    "https://github.com/testowner/testrepo/releases/v0.0.7",
+					Name:        "v0.0.7",
+					Commit:      "d21438494dd0722c1d13dc496ae1f60fb85084c1",
+					Date:        helpers.Time(1047683677),
+					URL:         "https://github.com/testowner/testrepo/releases/v0.0.7",
+					Description: "Some bugfixes",
 				},
 				{
 					Name:   "v0.0.6",
 					Commit: "ddde800c451bae606713ae0f8418badcf31db120",
-					Date:   helpers.Time(1047583647),
+					Date:   helpers.Time(1047583677),
 					URL:    "https://github.com/testowner/testrepo/tree/v0.0.6",
 				},
 				{
-					Name:   "v0.0.5",
-					Commit: "746e45ea014e257bcb7caa2c100ed1e5f63ed234",
-					Date:   helpers.Time(1047483647),
-					URL:    "https://github.com/testowner/testrepo/tree/v0.0.5",
+					Name:        "v0.0.5",
+					Commit:      "746e45ea014e257bcb7caa2c100ed1e5f63ed234",
+					Date:        helpers.Time(1047483677),
+					URL:         "https://github.com/testowner/testrepo/tree/v0.0.5",
+					Description: "Tag message of v0.0.5",
 				},
 				{
 					Name:   "v0.0.4",
 					Commit: "d4ff341587bc80a9c897c28340df9fe8f9fc6309",
-					Date:   helpers.Time(1047383647),
+					Date:   helpers.Time(1047383677),
 					URL:    "https://github.com/testowner/testrepo/tree/v0.0.4",
 				},
 				{
 					Name:   "v0.0.3",
 					Commit: "52f214dc3bf6c0e2a87eae6eab363a317c5a665f",
-					Date:   helpers.Time(1047283647),
+					Date:   helpers.Time(1047283677),
 					URL:    "https://github.com/testowner/testrepo/releases/v0.0.3",
 				},
 				{
 					Name:   "v0.0.2",
 					Commit: "b3622b516b8ad70ce5dc3fa422fb90c3b58fa9da",
-					Date:   helpers.Time(1047183647),
+					Date:   helpers.Time(1047183677),
 					URL:    "https://github.com/testowner/testrepo/tree/v0.0.2",
 				},
 				{
-					Name:   "v0.0.1",
-					Commit: "7d84cdb2f7c2d4619cda4b8adeb1897097b5c8fc",
-					Date:   helpers.Time(1047083647),
-					URL:    "https://github.com/testowner/testrepo/releases/v0.0.1",
+					Name:        "v0.0.1",
+					Commit:      "7d84cdb2f7c2d4619cda4b8adeb1897097b5c8fc",
+					Date:        helpers.Time(1047083677),
+					URL:         "https://github.com/testowner/testrepo/releases/v0.0.1",
+					Description: "The very first release",
 				},
 			},
 			wantMaxtags: []int{12},
@@ -130,11 +135,21 @@ func TestConnector_Tags(t *testing.T) {
 			},
 			wantErr: errors.New("can't fetch the commit"),
 		},
+		{
+			name: "GetRef call fails",
+			returnValue: testclient.ReturnValueStr{
+				GitServiceGetRefErr: true,
+			},
+			wantErr: errors.New("GitHub query 'annotatedTag' failed: can't fetch the reference"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			github.TagsPerPage = 5
-			c := setupTestConnector(tt.returnValue, false)
+			c := setupTestConnectorWithFlags(
+				tt.returnValue,
+				map[string]string{"release-descriptions": "true"},
+			)
 			cerr := make(chan error, 1)
 
 			cgot, _, cmaxtags := c.Tags(context.Background(), cerr)
@@ -160,11 +175,10 @@ func TestConnector_Tags(t *testing.T) {
 				return
 			}
 
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("Connector.Tags() = %+v, want %+v", got, tt.want)
-			}
-
 			if err == nil { // compare the processed tags only in non-error situation
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Connector.Tags() = %+v, want %+v", got, tt.want)
+				}
 				if !reflect.DeepEqual(gotmaxtags, tt.wantMaxtags) {
 					t.Errorf("Connector.Tags() maxtags = %v, want %v", gotmaxtags, tt.wantMaxtags)
 				}
@@ -172,3 +186,119 @@ func TestConnector_Tags(t *testing.T) {
 		})
 	}
 }
+
+func TestConnector_TagsWithoutDescriptions(t *testing.T) {
+	github.TagsPerPage = 5
+	// the annotated tags should not be queried at all
+	c := setupTestConnector(testclient.ReturnValueStr{GitServiceGetRefErr: true}, false)
+	cerr := make(chan error, 1)
+
+	cgot, _, cmaxtags := c.Tags(context.Background(), cerr)
+	helpers.GetChannelValuesInt(cmaxtags)
+
+	for tag := range cgot {
+		if tag.Description != "" {
+			t.Errorf("Connector.Tags() description of %v = %v, want empty", tag.Name, tag.Description)
+		}
+	}
+
+	// sleep and allow the possible error to be delivered to the channel
+	time.Sleep(time.Millisecond * 200)
+	select {
+	case err := <-cerr:
+		t.Errorf("Connector.Tags() error = %v, want nil", err)
+	default:
+	}
+}
+
+func TestConnector_TagsDateSource(t *testing.T) {
+	tests := []struct {
+		name          string
+		tagDateSource string
+		returnValue   testclient.ReturnValueStr
+		want          map[string]time.Time
+		wantErr       error
+	}{
+		{
+			name:          "Committer date",
+			tagDateSource: "commit",
+			want: map[string]time.Time{
+				"v0.0.1": helpers.Time(1047083677),
+				"v0.0.2": helpers.Time(1047183677),
+				"v0.1.2": helpers.Time(1048183677),
+			},
+		},
+		{
+			name:          "Author date",
+			tagDateSource: "author",
+			want: map[string]time.Time{
+				"v0.0.1": helpers.Time(1047083647),
+				"v0.0.2": helpers.Time(1047183647),
+				"v0.1.2": helpers.Time(1048183647),
+			},
+		},
+		{
+			name:          "Tagger date with fallback for lightweight tags",
+			tagDateSource: "tag",
+			want: map[string]time.Time{
+				"v0.0.1": helpers.Time(1047083697),
+				"v0.0.2": helpers.Time(1047183677),
+				"v0.1.2": helpers.Time(1048183697),
+			},
+		},
+		{
+			name:          "Release date with fallback for tags without release",
+			tagDateSource: "release",
+			want: map[string]time.Time{
+				"v0.0.1": helpers.Time(1047083657),
+				"v0.0.2": helpers.Time(1047183677),
+				"v0.1.2": helpers.Time(1048183657),
+			},
+		},
+		{
+			name:          "GetRef call fails",
+			tagDateSource: "tag",
+			returnValue: testclient.ReturnValueStr{
+				GitServiceGetRefErr: true,
+			},
+			wantErr: errors.New("GitHub query 'annotatedTag' failed: can't fetch the reference"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			github.TagsPerPage = 5
+			c := setupTestConnectorWithFlags(
+				tt.returnValue,
+				map[string]string{"tag-date-source": tt.tagDateSource},
+			)
+			cerr := make(chan error, 1)
+
+			cgot, _, cmaxtags := c.Tags(context.Background(), cerr)
+			helpers.GetChannelValuesInt(cmaxtags)
+
+			got := map[string]time.Time{}
+			for t := range cgot {
+				if _, ok := tt.want[t.Name]; ok {
+					got[t.Name] = t.Date
+				}
+			}
+
+			// sleep and allow the possible error to be delivered to the channel
+			time.Sleep(time.Millisecond * 200)
+			var err error
+			select {
+			case err = <-cerr:
+			default:
+			}
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Connector.Tags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Connector.Tags() dates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,44 +17,82 @@
 package github
 
 import (
+	"context"
 	"net/url"
 	"path"
+
+	"github.com/google/go-github/github"
 )
 
-// getTagURL returns the URL for a given tag.
-// If alwaysUseReleaseURL is true: URL is provided for release page,
-// even if it does not exist yet
-func (c *Connector) getTagURL(tagName string, alwaysUseReleaseURL bool) (string, error) {
-	release, resp, err := c.client.Repositories.GetReleaseByTag(c.context, c.Owner, c.Repo, tagName)
+// getRelease returns the GitHub release for a given tag.
+// Returns nil if there is no release for this tag
+func (c *Connector) getRelease(
+	ctx context.Context,
+	tagName string,
+) (*github.RepositoryRelease, error) {
+	release, resp, err := c.client.Repositories.GetReleaseByTag(ctx, c.Owner, c.Repo, tagName)
 	if err != nil {
 		// no release was found for this tag, this is no error for us
-		if resp.StatusCode != 404 {
-			return "", formatErrorCode("getTagURL", err)
+		if resp != nil && resp.StatusCode == 404 {
+			return nil, nil
 		}
+		return nil, formatErrorCode("getRelease", err)
 	}
+	return release, nil
+}
 
+// buildTagURL returns the URL for a given tag and its release.
+// If release is nil and alwaysUseReleaseURL is true: URL is provided
+// for release page, even if it does not exist yet
+func (c *Connector) buildTagURL(
+	tagName string,
+	release *github.RepositoryRelease,
+	alwaysUseReleaseURL bool,
+) (string, error) {
 	// if GitHub release for this tag was found -> use it
 	// generate otherwise a link to the git tag view in the file tree
-	var tagURL string
 	if release != nil { // we got real release URL, use it
-		tagURL = release.GetHTMLURL()
-	} else { // build own URL
-		u, err := url.Parse(c.ProjectURL)
-		if err != nil {
-			return "", err
-		}
+		return release.GetHTMLURL(), nil
+	}
 
-		if alwaysUseReleaseURL { // try to build own release url
-			u.Path = path.Join(u.Path, "/releases/"+tagName)
-		} else { // build tag url
-			u.Path = path.Join(u.Path, "/tree/"+tagName)
-		}
-		tagURL = u.String()
+	// build own URL
+	u, err := url.Parse(c.ProjectURL)
+	if err != nil {
+		return "", err
+	}
+
+	if alwaysUseReleaseURL { // try to build own release url
+		u.Path = path.Join(u.Path, "/releases/"+tagName)
+	} else { // build tag url
+		u.Path = path.Join(u.Path, "/tree/"+tagName)
+	}
+	return u.String(), nil
+}
+
+// getTagURL returns the URL for a given tag.
+// If alwaysUseReleaseURL is true: URL is provided for release page,
+// even if it does not exist yet
+func (c *Connector) getTagURL(tagName string, alwaysUseReleaseURL bool) (string, error) {
+	release, err := c.getRelease(c.context, tagName)
+	if err != nil {
+		return "", err
 	}
-	return tagURL, nil
+
+	return c.buildTagURL(tagName, release, alwaysUseReleaseURL)
 }
 
 // GetNewTagURL returns the URL for a new tag, which does not exist yet
 func (c *Connector) GetNewTagURL(TagName string) (string, error) {
 	return c.getTagURL(TagName, c.NewTagUseReleaseURL)
 }
+
+// CompareURL returns the URL with the changes between given tags
+func (c *Connector) CompareURL(from, to string) (string, error) {
+	u, err := url.Parse(c.ProjectURL)
+	if err != nil {
+		return "", err
+	}
+
+	u.Path = path.Join(u.Path, "/compare/"+from+"..."+to)
+	return u.String(), nil
+}
@@ -19,6 +19,7 @@ package github_test
 import (
 	"testing"
 
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
 	"github.com/artem-sidorenko/chagen/datasource/connectors/github/internal/testclient"
 )
 
@@ -92,3 +93,15 @@ func TestGetNewTagURL(t *testing.T) {
 		})
 	}
 }
+
+func TestConnector_CompareURL(t *testing.T) {
+	c := setupTestConnector(testclient.ReturnValueStr{}, false).(connectors.CompareURLProvider)
+
+	got, err := c.CompareURL("v0.0.1", "v0.0.2")
+	if err != nil {
+		t.Fatalf("Connector.CompareURL() error = %v", err)
+	}
+	if want := "https://github.com/testowner/testrepo/compare/v0.0.1...v0.0.2"; got != want {
+		t.Errorf("Connector.CompareURL() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	gitlab "github.com/xanzy/go-gitlab"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// CommitsPerPage defined how many commits are fetched per page
+var CommitsPerPage = 100 // nolint: gochecknoglobals
+
+// Commits returns the commits of the default branch via channels.
+// The whole history is listed, the given tags are not used.
+// Returns possible errors via given cerr channel
+// ccommits returns commits
+// ccommitscounter returns the channel, which ticks when a commit is proceeded
+// cmaxcommits returns the max available amount of commits
+func (c *Connector) Commits(
+	ctx context.Context,
+	cerr chan<- error,
+	_ data.Tags,
+) (
+	ccommits <-chan data.Commit,
+	ccommitscounter <-chan bool,
+	cmaxcommits <-chan int,
+) {
+	commits := make(chan data.Commit)
+	// the list API delivers all needed data, so we process the pages sequentially
+	maxcommits := make(chan int, 1)
+	commitscounter := make(chan bool, 100)
+
+	go func() {
+		defer close(commits)
+		defer close(commitscounter)
+		defer close(maxcommits)
+
+		opts := &gitlab.ListCommitsOptions{
+			ListOptions: gitlab.ListOptions{Page: 1, PerPage: CommitsPerPage},
+		}
+		for {
+			rcommits, resp, err := c.client.Commits.ListCommits(c.ProjectID(), opts)
+			if err != nil {
+				helpers.NonBlockingErrSend(ctx, cerr, formatErrorCode("Commits", err))
+				return
+			}
+
+			for _, rc := range rcommits {
+				select {
+				case <-ctx.Done():
+					return
+				case commits <- c.commit(rc):
+					commitscounter <- true
+				}
+			}
+
+			if resp.NextPage == 0 {
+				maxcommits <- len(rcommits) + (opts.Page-1)*CommitsPerPage
+				return
+			}
+			opts.Page = resp.NextPage
+		}
+	}()
+
+	return commits, commitscounter, maxcommits
+}
+
+// commit converts the GitLab commit to our data structure.
+// GitLab provides only the name of commit author, so it is not linked
+func (c *Connector) commit(rc *gitlab.Commit) data.Commit {
+	ret := data.Commit{
+		SHA:     rc.ID,
+		Message: rc.Message,
+		URL:     fmt.Sprintf("%s/commit/%s", c.ProjectURL, rc.ID),
+		Author:  rc.AuthorName,
+		Parents: rc.ParentIDs,
+	}
+	if rc.AuthoredDate != nil {
+		ret.Date = rc.AuthoredDate.UTC()
+	}
+	return ret
+}
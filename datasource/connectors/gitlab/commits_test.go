@@ -0,0 +1,98 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gitlab_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/gitlab"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/gitlab/internal/testclient"
+	"github.com/artem-sidorenko/chagen/internal/testing/helpers"
+	"github.com/artem-sidorenko/chagen/internal/testing/testdata"
+)
+
+func TestConnector_Commits(t *testing.T) {
+	tests := []struct {
+		name        string
+		returnValue testclient.ReturnValueStr
+		wantFirst   data.Commit
+		wantMax     int
+		wantErr     error
+	}{
+		{
+			name: "API returns proper data",
+			wantFirst: data.Commit{
+				SHA:     "f0e1d2c3b4a5968778695a4b3c2d1e0f9a8b7c6d",
+				Message: "Update dependencies",
+				URL:     "https://gitlab.com/testowner/testrepo/commit/f0e1d2c3b4a5968778695a4b3c2d1e0f9a8b7c6d",
+				Author:  "Test User",
+				Date:    helpers.Time(1048450000),
+				Parents: []string{"9618c791ab1f643aeffb7c5e1abe5877223aaa91"},
+			},
+			wantMax: len(testdata.Commits()),
+		},
+		{
+			name: "ListCommits call fails",
+			returnValue: testclient.ReturnValueStr{
+				CommitsServiceListCommitsErr: true,
+			},
+			wantErr: errors.New("GitLab query 'Commits' failed: can't fetch the commits"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitlab.CommitsPerPage = 5
+			c := setupTestConnector(tt.returnValue).(connectors.CommitProvider)
+			cerr := make(chan error, 1)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cgot, _, cmaxcommits := c.Commits(ctx, cerr, nil)
+
+			var got data.Commits
+			for commit := range cgot {
+				got = append(got, commit)
+			}
+			max := helpers.GetChannelValuesInt(cmaxcommits)
+
+			var err error
+			select {
+			case err = <-cerr:
+			default:
+			}
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Connector.Commits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(max, []int{tt.wantMax}) || len(got) != tt.wantMax {
+				t.Errorf("Connector.Commits() got %v commits, max %v, want %v", len(got), max, tt.wantMax)
+			}
+			if !reflect.DeepEqual(got[0], tt.wantFirst) {
+				t.Errorf("Connector.Commits() first = %+v, want %+v", got[0], tt.wantFirst)
+			}
+		})
+	}
+}
@@ -31,17 +31,31 @@ import (
 
 // AccessTokenEnvVar contains the name of environment variable
 // which sets the authentication access token
-const AccessTokenEnvVar = "CHAGEN_GITHUB_TOKEN" // nolint: gosec
+const AccessTokenEnvVar = "CHAGEN_GITLAB_TOKEN" // nolint: gosec
 
 // Connector implements the GitHub connector
 type Connector struct {
-	context    context.Context
-	client     *client.Client
-	Owner      string
-	Repo       string
-	ProjectURL string
+	context              context.Context
+	client               *client.Client
+	Owner                string
+	Repo                 string
+	ProjectURL           string
+	TagDateSource        connectors.TagDateSource
+	IncludeDraftReleases bool
+	FetchFiles           bool
+	FetchClosingRefs     bool
+	closingRefs          chan struct{} // limits the concurrent queries of closing references
 }
 
+// ClosingRefsPerPage defined how many closing references of MRs
+// are fetched per page
+var ClosingRefsPerPage = 100 // nolint: gochecknoglobals
+
+const (
+	// the closing references are queried for every MR
+	closingRefsRoutines = 5
+)
+
 // NewClient links to the constructor, which is used to create Connector.client
 var NewClient = client.New // nolint: gochecknoglobals
 
@@ -80,13 +94,22 @@ func New(ctx *cli.Context) (connectors.Connector, error) {
 	if repo == "" {
 		return nil, errors.New("option --gitlab-repo is required")
 	}
+	tagDateSource, err := connectors.GetTagDateSource(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	return &Connector{
-		context:    context.Background(),
-		client:     NewClient(context.Background(), os.Getenv(AccessTokenEnvVar)),
-		Owner:      owner,
-		Repo:       repo,
-		ProjectURL: fmt.Sprintf("https://gitlab.com/%s/%s", owner, repo),
+		context:              context.Background(),
+		client:               NewClient(context.Background(), os.Getenv(AccessTokenEnvVar)),
+		Owner:                owner,
+		Repo:                 repo,
+		ProjectURL:           fmt.Sprintf("https://gitlab.com/%s/%s", owner, repo),
+		TagDateSource:        tagDateSource,
+		IncludeDraftReleases: ctx.Bool("include-draft-releases"),
+		FetchFiles:           connectors.FetchMRFiles(ctx),
+		FetchClosingRefs:     connectors.FetchClosingReferences(ctx),
+		closingRefs:          make(chan struct{}, closingRefsRoutines),
 	}, nil
 }
 
@@ -106,4 +129,5 @@ func CLIFlags() []cli.Flag {
 
 func init() { // nolint: gochecknoinits
 	connectors.RegisterConnector("gitlab", "GitLab", New, CLIFlags)
+	connectors.RegisterConnectorDetails("gitlab", []string{AccessTokenEnvVar}, &Connector{})
 }
@@ -26,19 +26,39 @@ import (
 	"github.com/artem-sidorenko/chagen/datasource/connectors/gitlab"
 	"github.com/artem-sidorenko/chagen/datasource/connectors/gitlab/internal/testclient"
 	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+	"github.com/urfave/cli"
 )
 
 func setupTestConnector(
 	returnValue testclient.ReturnValueStr,
 ) connectors.Connector {
+	return setupTestConnectorWithFlags(returnValue, nil)
+}
+
+// setupTestConnectorWithFlags returns the test connector configured
+// with the given additional CLI flags
+func setupTestConnectorWithFlags(
+	returnValue testclient.ReturnValueStr, flags map[string]string,
+) connectors.Connector {
 
 	gitlab.NewClient = testclient.New
 	cliFlags := map[string]string{
 		"gitlab-owner": "testowner",
 		"gitlab-repo":  "testrepo",
 	}
+	for k, v := range flags {
+		cliFlags[k] = v
+	}
 
-	ctx := tcli.TestContext(gitlab.CLIFlags(), cliFlags)
+	ctx := tcli.TestContext(
+		append(
+			append(gitlab.CLIFlags(), connectors.CommonCLIFlags()...),
+			// the flags are provided by the generate command
+			cli.StringFlag{Name: "linked-issues"},
+			cli.BoolFlag{Name: "only-completed-issues"},
+		),
+		cliFlags,
+	)
 
 	testclient.ReturnValue = returnValue
 
@@ -106,8 +126,9 @@ func TestConnector_RepositoryExists(t *testing.T) {
 
 func TestNew(t *testing.T) {
 	type args struct {
-		gitlabOwner bool
-		gitlabRepo  bool
+		gitlabOwner   bool
+		gitlabRepo    bool
+		tagDateSource string
 	}
 	tests := []struct {
 		name          string
@@ -139,6 +160,15 @@ func TestNew(t *testing.T) {
 			},
 			wantErr: errors.New("option --gitlab-repo is required"),
 		},
+		{
+			name: "Unsupported tag date source",
+			args: args{
+				gitlabOwner:   true,
+				gitlabRepo:    true,
+				tagDateSource: "wrong",
+			},
+			wantErr: errors.New("unsupported tag date source: wrong"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -151,7 +181,14 @@ func TestNew(t *testing.T) {
 				cliFlags["gitlab-repo"] = "testrepo"
 			}
 
-			ctx := tcli.TestContext(gitlab.CLIFlags(), cliFlags)
+			if tt.args.tagDateSource != "" {
+				cliFlags["tag-date-source"] = tt.args.tagDateSource
+			}
+
+			ctx := tcli.TestContext(
+				append(gitlab.CLIFlags(), connectors.CommonCLIFlags()...),
+				cliFlags,
+			)
 
 			gitlab.NewClient = testclient.New
 			testclient.ReturnValue = tt.retErrControl
@@ -29,9 +29,11 @@ func New(ctx context.Context, AccessToken string) *Client {
 
 	return &Client{
 		Projects:      client.Projects,
-		Tags:          client.Tags,
+		Tags:          &tagsService{client: client},
 		MergeRequests: client.MergeRequests,
 		Commits:       client.Commits,
 		Issues:        client.Issues,
+		Releases:      &releasesService{client: client},
+		Milestones:    client.Milestones,
 	}
 }
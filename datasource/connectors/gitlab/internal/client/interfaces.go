@@ -29,14 +29,18 @@ type ProjectsService interface {
 	) (*gitlab.Project, *gitlab.Response, error)
 }
 
-// TagsService describes the methods we use from
-// gitlab.TagsService
+// TagsService describes the methods we use from the GitLab tags API
 type TagsService interface {
 	ListTags(
-		pid interface{},
+		pid string,
 		opt *gitlab.ListTagsOptions,
 		options ...gitlab.OptionFunc,
-	) ([]*gitlab.Tag, *gitlab.Response, error)
+	) ([]*Tag, *gitlab.Response, error)
+	GetTag(
+		pid string,
+		tagName string,
+		options ...gitlab.OptionFunc,
+	) (*Tag, *gitlab.Response, error)
 }
 
 // MergeRequestsService describes the methods we use from gitlab.MergeRequestsService
@@ -46,6 +50,17 @@ type MergeRequestsService interface {
 		opt *gitlab.ListProjectMergeRequestsOptions,
 		options ...gitlab.OptionFunc,
 	) ([]*gitlab.MergeRequest, *gitlab.Response, error)
+	GetIssuesClosedOnMerge(
+		pid interface{},
+		mergeRequest int,
+		opt *gitlab.GetIssuesClosedOnMergeOptions,
+		options ...gitlab.OptionFunc,
+	) ([]*gitlab.Issue, *gitlab.Response, error)
+	GetMergeRequestChanges(
+		pid interface{},
+		mergeRequest int,
+		options ...gitlab.OptionFunc,
+	) (*gitlab.MergeRequest, *gitlab.Response, error)
 }
 
 // CommitsService describes the methods we use from gitlab.CommitsService
@@ -55,6 +70,20 @@ type CommitsService interface {
 		sha string,
 		options ...gitlab.OptionFunc,
 	) (*gitlab.Commit, *gitlab.Response, error)
+	ListCommits(
+		pid interface{},
+		opt *gitlab.ListCommitsOptions,
+		options ...gitlab.OptionFunc,
+	) ([]*gitlab.Commit, *gitlab.Response, error)
+}
+
+// MilestonesService describes the methods we use from gitlab.MilestonesService
+type MilestonesService interface {
+	ListMilestones(
+		pid interface{},
+		opt *gitlab.ListMilestonesOptions,
+		options ...gitlab.OptionFunc,
+	) ([]*gitlab.Milestone, *gitlab.Response, error)
 }
 
 // IssuesService describes the methods we use from gitlab.IssuesService
@@ -66,6 +95,31 @@ type IssuesService interface {
 	) ([]*gitlab.Issue, *gitlab.Response, error)
 }
 
+// ReleasesService describes the methods we use from the GitLab releases API
+type ReleasesService interface {
+	ListReleases(
+		pid string,
+		opt *gitlab.ListOptions,
+		options ...gitlab.OptionFunc,
+	) ([]*Release, *gitlab.Response, error)
+	GetRelease(
+		pid string,
+		tagName string,
+		options ...gitlab.OptionFunc,
+	) (*Release, *gitlab.Response, error)
+	CreateRelease(
+		pid string,
+		opt *ReleaseOptions,
+		options ...gitlab.OptionFunc,
+	) (*Release, *gitlab.Response, error)
+	UpdateRelease(
+		pid string,
+		tagName string,
+		opt *ReleaseOptions,
+		options ...gitlab.OptionFunc,
+	) (*Release, *gitlab.Response, error)
+}
+
 // Client wraps the gitlab.Client with interfaces we are using
 type Client struct {
 	Projects      ProjectsService
@@ -73,4 +127,6 @@ type Client struct {
 	MergeRequests MergeRequestsService
 	Commits       CommitsService
 	Issues        IssuesService
+	Releases      ReleasesService
+	Milestones    MilestonesService
 }
@@ -0,0 +1,147 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// Release represents a GitLab release.
+// The releases API isn't covered by the used go-gitlab version,
+// so we implement the needed parts on our own
+type Release struct {
+	TagName         string         `json:"tag_name"`
+	Name            string         `json:"name"`
+	Description     string         `json:"description"`
+	CreatedAt       *time.Time     `json:"created_at"`
+	ReleasedAt      *time.Time     `json:"released_at"`
+	UpcomingRelease bool           `json:"upcoming_release"`
+	Commit          *gitlab.Commit `json:"commit"`
+}
+
+// ReleaseOptions represents the options for creating or updating a release
+type ReleaseOptions struct {
+	TagName     *string `json:"tag_name,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// releasesService implements the ReleasesService using the gitlab.Client
+type releasesService struct {
+	client *gitlab.Client
+}
+
+// ListReleases gets a paginated list of releases
+//
+// GitLab API docs: https://docs.gitlab.com/ce/api/releases/#list-releases
+func (s *releasesService) ListReleases(
+	pid string,
+	opt *gitlab.ListOptions,
+	options ...gitlab.OptionFunc,
+) ([]*Release, *gitlab.Response, error) {
+	u := fmt.Sprintf("projects/%s/releases", url.QueryEscape(pid))
+
+	req, err := s.client.NewRequest("GET", u, opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var r []*Release
+	resp, err := s.client.Do(req, &r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetRelease gets a release for the given tag
+//
+// GitLab API docs: https://docs.gitlab.com/ce/api/releases/#get-a-release-by-a-tag-name
+func (s *releasesService) GetRelease(
+	pid string,
+	tagName string,
+	options ...gitlab.OptionFunc,
+) (*Release, *gitlab.Response, error) {
+	u := fmt.Sprintf("projects/%s/releases/%s", url.QueryEscape(pid), url.QueryEscape(tagName))
+
+	req, err := s.client.NewRequest("GET", u, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var r *Release
+	resp, err := s.client.Do(req, &r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CreateRelease creates a release for an existing tag
+//
+// GitLab API docs: https://docs.gitlab.com/ce/api/releases/#create-a-release
+func (s *releasesService) CreateRelease(
+	pid string,
+	opt *ReleaseOptions,
+	options ...gitlab.OptionFunc,
+) (*Release, *gitlab.Response, error) {
+	u := fmt.Sprintf("projects/%s/releases", url.QueryEscape(pid))
+
+	req, err := s.client.NewRequest("POST", u, opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var r *Release
+	resp, err := s.client.Do(req, &r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// UpdateRelease updates the release for the given tag
+//
+// GitLab API docs: https://docs.gitlab.com/ce/api/releases/#update-a-release
+func (s *releasesService) UpdateRelease(
+	pid string,
+	tagName string,
+	opt *ReleaseOptions,
+	options ...gitlab.OptionFunc,
+) (*Release, *gitlab.Response, error) {
+	u := fmt.Sprintf("projects/%s/releases/%s", url.QueryEscape(pid), url.QueryEscape(tagName))
+
+	req, err := s.client.NewRequest("PUT", u, opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var r *Release
+	resp, err := s.client.Do(req, &r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
@@ -0,0 +1,88 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// Tag represents a GitLab tag.
+// The used go-gitlab version doesn't provide the creation date
+// of annotated tags, so we extend the tag on our own
+type Tag struct {
+	gitlab.Tag
+	CreatedAt *time.Time `json:"created_at"` // nil for lightweight tags
+}
+
+// tagsService implements the TagsService using the gitlab.Client
+type tagsService struct {
+	client *gitlab.Client
+}
+
+// ListTags gets a paginated list of repository tags
+//
+// GitLab API docs: https://docs.gitlab.com/ce/api/tags.html#list-project-repository-tags
+func (s *tagsService) ListTags(
+	pid string,
+	opt *gitlab.ListTagsOptions,
+	options ...gitlab.OptionFunc,
+) ([]*Tag, *gitlab.Response, error) {
+	u := fmt.Sprintf("projects/%s/repository/tags", url.QueryEscape(pid))
+
+	req, err := s.client.NewRequest("GET", u, opt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var t []*Tag
+	resp, err := s.client.Do(req, &t)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return t, resp, nil
+}
+
+// GetTag gets a repository tag with the given name
+//
+// GitLab API docs: https://docs.gitlab.com/ce/api/tags.html#get-a-single-repository-tag
+func (s *tagsService) GetTag(
+	pid string,
+	tagName string,
+	options ...gitlab.OptionFunc,
+) (*Tag, *gitlab.Response, error) {
+	u := fmt.Sprintf(
+		"projects/%s/repository/tags/%s", url.QueryEscape(pid), url.QueryEscape(tagName),
+	)
+
+	req, err := s.client.NewRequest("GET", u, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var t *Tag
+	resp, err := s.client.Do(req, &t)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return t, resp, nil
+}
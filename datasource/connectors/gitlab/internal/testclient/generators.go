@@ -17,9 +17,12 @@
 package testclient
 
 import (
+	"encoding/json"
 	"net/http"
 	"time"
 
+	"github.com/artem-sidorenko/chagen/datasource/connectors/gitlab/internal/client"
+
 	gitlab "github.com/xanzy/go-gitlab"
 )
 
@@ -27,10 +30,12 @@ func genMR(
 	number int,
 	title, webURL, userLogin string,
 	mergedAt time.Time, mergeCommitSHA string, labels []string,
+	description string,
 ) *gitlab.MergeRequest {
 	mr := &gitlab.MergeRequest{
 		IID:            number,
 		Title:          title,
+		Description:    description,
 		Labels:         labels,
 		WebURL:         webURL,
 		MergeCommitSHA: mergeCommitSHA,
@@ -44,17 +49,83 @@ func genMR(
 	return mr
 }
 
-func genTag(name string, commit *gitlab.Commit) *gitlab.Tag {
-	return &gitlab.Tag{
-		Name:   name,
-		Commit: commit,
+// genMRChanges returns the MR with the changes of given files,
+// JSON is used as the changes are an anonymous struct
+func genMRChanges(number int, files []string) *gitlab.MergeRequest {
+	type change struct {
+		NewPath string `json:"new_path"`
+	}
+	var changes struct {
+		Changes []change `json:"changes"`
+	}
+	for _, f := range files {
+		changes.Changes = append(changes.Changes, change{NewPath: f})
+	}
+
+	b, err := json.Marshal(changes)
+	if err != nil {
+		panic(err)
 	}
+	mr := &gitlab.MergeRequest{}
+	if err = json.Unmarshal(b, mr); err != nil {
+		panic(err)
+	}
+	mr.IID = number
+
+	return mr
 }
 
-func genCommit(sha string, commitDate time.Time) *gitlab.Commit {
+func genTag(
+	name, message string,
+	createdAt *time.Time,
+	releaseDescription string,
+	commit *gitlab.Commit,
+) *client.Tag {
+	tag := &client.Tag{
+		Tag: gitlab.Tag{
+			Name:    name,
+			Message: message,
+			Commit:  commit,
+		},
+		CreatedAt: createdAt,
+	}
+
+	if releaseDescription != "" {
+		tag.Release = &gitlab.Release{
+			TagName:     name,
+			Description: releaseDescription,
+		}
+	}
+
+	return tag
+}
+
+func genCommit(sha string, authorDate, commitDate time.Time) *gitlab.Commit {
 	return &gitlab.Commit{
-		ID:           sha,
-		AuthoredDate: &commitDate,
+		ID:            sha,
+		AuthoredDate:  &authorDate,
+		CommittedDate: &commitDate,
+	}
+}
+
+func genHistoryCommit(
+	sha, message string,
+	authorDate, commitDate time.Time,
+	parents []string,
+) *gitlab.Commit {
+	commit := genCommit(sha, authorDate, commitDate)
+	commit.Title = message
+	commit.Message = message
+	commit.AuthorName = "Test User"
+	commit.ParentIDs = parents
+	return commit
+}
+
+func genRelease(tagName string, releasedAt time.Time) *client.Release {
+	return &client.Release{
+		TagName:    tagName,
+		Name:       tagName,
+		ReleasedAt: &releasedAt,
 	}
 }
 
@@ -34,9 +34,18 @@ type ReturnValueStr struct {
 	CommitsServiceGetCommitRespCode                 int
 	ProjectsServiceGetProjectErr                    bool
 	TagsServiceListTagsErr                          bool
+	TagsServiceGetTagErr                            bool
 	MergeRequestsServiceListProjectMergeRequestsErr bool
 	CommitsServiceGetCommitErr                      bool
+	CommitsServiceListCommitsErr                    bool
 	IssuesServiceListProjectIssuesErr               bool
+	ReleasesServiceGetReleaseErr                    bool
+	ReleasesServiceListReleasesErr                  bool
+	MergeRequestsServiceGetIssuesClosedOnMergeErr   bool
+	MergeRequestsServiceGetMergeRequestChangesErr   bool
+	ReleasesServiceCreateReleaseErr                 bool
+	ReleasesServiceUpdateReleaseErr                 bool
+	MilestonesServiceListMilestonesErr              bool
 }
 
 // ReturnValue controls the error return values of API for testclient instances
@@ -67,18 +76,18 @@ func (p *ProjectsService) GetProject(
 	return nil, response, nil
 }
 
-// TagsService sumulates the gitlab.TagsService
+// TagsService sumulates the client.TagsService
 type TagsService struct {
-	Tags        []*gitlab.Tag
+	Tags        []*client.Tag
 	ReturnValue ReturnValueStr
 }
 
-// ListTags simulates the (gitlab.TagsService).ListTags call
+// ListTags simulates the (client.TagsService).ListTags call
 func (t *TagsService) ListTags(
-	_ interface{},
+	_ string,
 	opt *gitlab.ListTagsOptions,
 	_ ...gitlab.OptionFunc,
-) ([]*gitlab.Tag, *gitlab.Response, error) {
+) ([]*client.Tag, *gitlab.Response, error) {
 
 	if t.ReturnValue.TagsServiceListTagsErr {
 		return nil, nil, fmt.Errorf("can't fetch the tags")
@@ -89,10 +98,31 @@ func (t *TagsService) ListTags(
 	return t.Tags[start:end], resp, nil
 }
 
+// GetTag simulates the (client.TagsService).GetTag call
+func (t *TagsService) GetTag(
+	_ string,
+	tagName string,
+	_ ...gitlab.OptionFunc,
+) (*client.Tag, *gitlab.Response, error) {
+	if t.ReturnValue.TagsServiceGetTagErr {
+		return nil, genResponse(500), fmt.Errorf("can't fetch the tag")
+	}
+
+	for _, tag := range t.Tags {
+		if tag.Name == tagName {
+			return tag, genResponse(200), nil
+		}
+	}
+
+	return nil, genResponse(404), fmt.Errorf("tag %v is not present", tagName)
+}
+
 // MergeRequestsService sumulates the gitlab.MergeRequestsService
 type MergeRequestsService struct {
-	MRs         []*gitlab.MergeRequest
-	ReturnValue ReturnValueStr
+	MRs          []*gitlab.MergeRequest
+	ClosedIssues map[int][]*gitlab.Issue
+	Changes      map[int]*gitlab.MergeRequest
+	ReturnValue  ReturnValueStr
 }
 
 // ListProjectMergeRequests simulates the (gitlab.MergeRequestsService).ListProjectMergeRequests
@@ -111,9 +141,46 @@ func (m *MergeRequestsService) ListProjectMergeRequests(
 	return m.MRs[start:end], resp, nil
 }
 
+// GetIssuesClosedOnMerge simulates the (gitlab.MergeRequestsService).GetIssuesClosedOnMerge
+func (m *MergeRequestsService) GetIssuesClosedOnMerge(
+	_ interface{},
+	mergeRequest int,
+	opt *gitlab.GetIssuesClosedOnMergeOptions,
+	_ ...gitlab.OptionFunc,
+) ([]*gitlab.Issue, *gitlab.Response, error) {
+
+	if m.ReturnValue.MergeRequestsServiceGetIssuesClosedOnMergeErr {
+		return nil, nil, fmt.Errorf("can't fetch the closed issues")
+	}
+
+	issues := m.ClosedIssues[mergeRequest]
+	resp, start, end := calcPaging(opt.Page, opt.PerPage, len(issues))
+
+	return issues[start:end], resp, nil
+}
+
+// GetMergeRequestChanges simulates the (gitlab.MergeRequestsService).GetMergeRequestChanges
+func (m *MergeRequestsService) GetMergeRequestChanges(
+	_ interface{},
+	mergeRequest int,
+	_ ...gitlab.OptionFunc,
+) (*gitlab.MergeRequest, *gitlab.Response, error) {
+
+	if m.ReturnValue.MergeRequestsServiceGetMergeRequestChangesErr {
+		return nil, nil, fmt.Errorf("can't fetch the MR changes")
+	}
+
+	mr, ok := m.Changes[mergeRequest]
+	if !ok {
+		mr = &gitlab.MergeRequest{IID: mergeRequest}
+	}
+	return mr, genResponse(200), nil
+}
+
 // CommitsService simulates the gitlab.CommitsService
 type CommitsService struct {
 	Commits     map[string]*gitlab.Commit
+	History     []*gitlab.Commit
 	ReturnValue ReturnValueStr
 }
 
@@ -143,6 +210,21 @@ func (c *CommitsService) GetCommit(
 	return nil, response, fmt.Errorf("commit %v is not present", sha)
 }
 
+// ListCommits simulates the (gitlab.CommitsService).ListCommits
+func (c *CommitsService) ListCommits(
+	_ interface{},
+	opt *gitlab.ListCommitsOptions,
+	_ ...gitlab.OptionFunc,
+) ([]*gitlab.Commit, *gitlab.Response, error) {
+	if c.ReturnValue.CommitsServiceListCommitsErr {
+		return nil, nil, fmt.Errorf("can't fetch the commits")
+	}
+
+	resp, start, end := calcPaging(opt.Page, opt.PerPage, len(c.History))
+
+	return c.History[start:end], resp, nil
+}
+
 // IssuesService simulates the gitlab.IssuesService
 type IssuesService struct {
 	Issues      []*gitlab.Issue
@@ -164,6 +246,107 @@ func (i *IssuesService) ListProjectIssues(
 	return i.Issues[start:end], resp, nil
 }
 
+// ReleasesService simulates the client.ReleasesService
+type ReleasesService struct {
+	Releases     map[string]*client.Release
+	ReleasesList []*client.Release
+	ReturnValue  ReturnValueStr
+}
+
+// ListReleases simulates the (client.ReleasesService).ListReleases
+func (r *ReleasesService) ListReleases(
+	_ string,
+	opt *gitlab.ListOptions,
+	_ ...gitlab.OptionFunc,
+) ([]*client.Release, *gitlab.Response, error) {
+	if r.ReturnValue.ReleasesServiceListReleasesErr {
+		return nil, nil, fmt.Errorf("can't fetch the releases")
+	}
+
+	resp, start, end := calcPaging(opt.Page, opt.PerPage, len(r.ReleasesList))
+
+	return r.ReleasesList[start:end], resp, nil
+}
+
+// GetRelease simulates the (client.ReleasesService).GetRelease
+func (r *ReleasesService) GetRelease(
+	_ string,
+	tagName string,
+	_ ...gitlab.OptionFunc,
+) (*client.Release, *gitlab.Response, error) {
+	if r.ReturnValue.ReleasesServiceGetReleaseErr {
+		return nil, genResponse(500), fmt.Errorf("can't fetch the release")
+	}
+
+	if re, ok := r.Releases[tagName]; ok {
+		return re, genResponse(200), nil
+	}
+
+	return nil, genResponse(404), fmt.Errorf("release %v is not present", tagName)
+}
+
+// CreateRelease simulates the (client.ReleasesService).CreateRelease
+func (r *ReleasesService) CreateRelease(
+	_ string,
+	opt *client.ReleaseOptions,
+	_ ...gitlab.OptionFunc,
+) (*client.Release, *gitlab.Response, error) {
+	if r.ReturnValue.ReleasesServiceCreateReleaseErr {
+		return nil, genResponse(500), fmt.Errorf("can't create the release")
+	}
+
+	re := &client.Release{
+		TagName:     *opt.TagName,
+		Name:        *opt.Name,
+		Description: *opt.Description,
+	}
+	r.Releases[re.TagName] = re
+	r.ReleasesList = append(r.ReleasesList, re)
+
+	return re, genResponse(201), nil
+}
+
+// UpdateRelease simulates the (client.ReleasesService).UpdateRelease
+func (r *ReleasesService) UpdateRelease(
+	_ string,
+	tagName string,
+	opt *client.ReleaseOptions,
+	_ ...gitlab.OptionFunc,
+) (*client.Release, *gitlab.Response, error) {
+	if r.ReturnValue.ReleasesServiceUpdateReleaseErr {
+		return nil, genResponse(500), fmt.Errorf("can't update the release")
+	}
+
+	re, ok := r.Releases[tagName]
+	if !ok {
+		return nil, genResponse(404), fmt.Errorf("release %v is not present", tagName)
+	}
+	re.Description = *opt.Description
+
+	return re, genResponse(200), nil
+}
+
+// MilestonesService simulates the gitlab.MilestonesService
+type MilestonesService struct {
+	Milestones  []*gitlab.Milestone
+	ReturnValue ReturnValueStr
+}
+
+// ListMilestones simulates the (gitlab.MilestonesService).ListMilestones
+func (m *MilestonesService) ListMilestones(
+	_ interface{},
+	opt *gitlab.ListMilestonesOptions,
+	_ ...gitlab.OptionFunc,
+) ([]*gitlab.Milestone, *gitlab.Response, error) {
+	if m.ReturnValue.MilestonesServiceListMilestonesErr {
+		return nil, nil, fmt.Errorf("can't fetch the milestones")
+	}
+
+	resp, start, end := calcPaging(opt.Page, opt.PerPage, len(m.Milestones))
+
+	return m.Milestones[start:end], resp, nil
+}
+
 func newProjectService() *ProjectsService {
 	return &ProjectsService{
 		ReturnValue: ReturnValue,
@@ -171,15 +354,24 @@ func newProjectService() *ProjectsService {
 }
 
 func newTagsService() *TagsService {
-	rtags := []*gitlab.Tag{}
+	rtags := []*client.Tag{}
 
 	commits := testdata.CommitsBySHA()
+	releases := testdata.ReleasesByTag()
 
 	for _, tag := range testdata.Tags() {
 		commit := commits[tag.Commit]
+		// only annotated tags have a message
+		message := ""
+		if tag.TagTime != nil {
+			message = "Tag message of " + tag.Tag
+		}
 		rtags = append(rtags, genTag(
 			tag.Tag,
-			genCommit(commit.SHA, commit.AuthoredDate),
+			message,
+			tag.TagTime,
+			releases[tag.Tag].Description,
+			genCommit(commit.SHA, commit.AuthoredDate, commit.CommittedDate),
 		))
 	}
 
@@ -191,6 +383,19 @@ func newTagsService() *TagsService {
 
 func newMergeRequestsService() *MergeRequestsService {
 	ret := []*gitlab.MergeRequest{}
+	descriptions := testdata.MRDescriptions()
+	closedIssues := map[int][]*gitlab.Issue{}
+	changes := map[int]*gitlab.MergeRequest{}
+
+	for id, files := range testdata.MRFiles() {
+		changes[id] = genMRChanges(id, files)
+	}
+
+	for id, issues := range testdata.MRClosedIssues() {
+		for _, issue := range issues {
+			closedIssues[id] = append(closedIssues[id], &gitlab.Issue{IID: issue})
+		}
+	}
 
 	for _, mr := range testdata.MRs() {
 		// return only merged MRs, because of filter sent to API in the request
@@ -199,7 +404,7 @@ func newMergeRequestsService() *MergeRequestsService {
 				mr.ID, mr.Title,
 				fmt.Sprintf("https://example.com/pulls/%v", mr.ID),
 				mr.Username, mr.MergedAt, mr.MergeCommitSHA,
-				mr.Labels,
+				mr.Labels, descriptions[mr.ID],
 			))
 		}
 	}
@@ -213,8 +418,10 @@ func newMergeRequestsService() *MergeRequestsService {
 	}
 
 	return &MergeRequestsService{
-		ReturnValue: ReturnValue,
-		MRs:         ret,
+		ReturnValue:  ReturnValue,
+		MRs:          ret,
+		ClosedIssues: closedIssues,
+		Changes:      changes,
 	}
 }
 
@@ -222,12 +429,21 @@ func newCommitsService() *CommitsService {
 	ret := map[string]*gitlab.Commit{}
 
 	for _, commit := range testdata.Commits() {
-		ret[commit.SHA] = genCommit(commit.SHA, commit.AuthoredDate)
+		ret[commit.SHA] = genCommit(commit.SHA, commit.AuthoredDate, commit.CommittedDate)
+	}
+
+	history := []*gitlab.Commit{}
+	parents := testdata.CommitParents()
+	for _, commit := range testdata.History() {
+		history = append(history, genHistoryCommit(
+			commit.SHA, commit.Title, commit.AuthoredDate, commit.CommittedDate, parents[commit.SHA],
+		))
 	}
 
 	return &CommitsService{
 		ReturnValue: ReturnValue,
 		Commits:     ret,
+		History:     history,
 	}
 }
 
@@ -253,6 +469,59 @@ func newIssuesService() *IssuesService {
 	}
 }
 
+func newReleasesService() *ReleasesService {
+	ret := map[string]*client.Release{}
+	list := []*client.Release{}
+	commits := testdata.CommitsBySHA()
+	tags := map[string]testdata.Tag{}
+
+	for _, tag := range testdata.Tags() {
+		tags[tag.Tag] = tag
+		if tag.ReleaseTime != nil {
+			ret[tag.Tag] = genRelease(tag.Tag, *tag.ReleaseTime)
+		}
+	}
+
+	for _, v := range testdata.Releases() {
+		re, ok := ret[v.Tag]
+		sha := tags[v.Tag].Commit
+		if !ok {
+			// upcoming releases are not available via GetRelease, only listed
+			re = &client.Release{TagName: v.Tag, UpcomingRelease: v.Draft}
+			sha = v.Commit
+		}
+		commit := commits[sha]
+		re.Name = v.Name
+		re.Description = v.Description
+		re.Commit = genCommit(commit.SHA, commit.AuthoredDate, commit.CommittedDate)
+		list = append(list, re)
+	}
+
+	return &ReleasesService{
+		ReturnValue:  ReturnValue,
+		Releases:     ret,
+		ReleasesList: list,
+	}
+}
+
+func newMilestonesService() *MilestonesService {
+	ret := []*gitlab.Milestone{}
+
+	for _, m := range testdata.Milestones() {
+		ret = append(ret, &gitlab.Milestone{
+			ID:          m.ID + 1000,
+			IID:         m.ID,
+			Title:       m.Title,
+			Description: m.Description,
+		})
+	}
+
+	return &MilestonesService{
+		ReturnValue: ReturnValue,
+		Milestones:  ret,
+	}
+}
+
 // New returns the configured simulated gitlab API client
 func New(_ context.Context, _ string) *client.Client {
 	return &client.Client{
@@ -261,5 +530,7 @@ func New(_ context.Context, _ string) *client.Client {
 		MergeRequests: newMergeRequestsService(),
 		Commits:       newCommitsService(),
 		Issues:        newIssuesService(),
+		Releases:      newReleasesService(),
+		Milestones:    newMilestonesService(),
 	}
 }
@@ -104,7 +104,7 @@ func (c *Connector) Issues(
 		}()
 	}()
 
-	dissues := c.processIssues(ctx, cerr, issues, issuescounter, &wgT)
+	dissues := c.processIssues(sctx, scerr, issues, issuescounter, &wgT)
 
 	go func() {
 		wgTP.Wait()
@@ -184,7 +184,7 @@ func (c *Connector) processIssuesPages(
 
 func (c *Connector) processIssues(
 	ctx context.Context,
-	_ chan<- error,
+	cerr chan<- error,
 	cissues <-chan []*gitlab.Issue,
 	cissuescounter chan<- bool,
 	wg *sync.WaitGroup,
@@ -211,12 +211,15 @@ func (c *Connector) processIssues(
 						continue
 					}
 
+					// GitLab provides no close reason, the MRs closing the issue
+					// are linked later using the closing references of MRs
 					issue := data.Issue{
-						ID:         issue.IID,
-						Name:       issue.Title,
-						ClosedDate: (*issue.ClosedAt).UTC(),
-						URL:        issue.WebURL,
-						Labels:     issue.Labels,
+						ID:          issue.IID,
+						Name:        issue.Title,
+						ClosedDate:  (*issue.ClosedAt).UTC(),
+						URL:         issue.WebURL,
+						Labels:      issue.Labels,
+						CloseReason: data.CloseReasonUnknown,
 					}
 
 					select {
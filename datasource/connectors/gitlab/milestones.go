@@ -0,0 +1,54 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"fmt"
+
+	gitlab "github.com/xanzy/go-gitlab"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// MilestonesPerPage defined how many milestones are fetched per page
+var MilestonesPerPage = 100 // nolint: gochecknoglobals
+
+// Milestones returns all milestones of the project
+func (c *Connector) Milestones() (data.Milestones, error) {
+	var ret data.Milestones
+	opts := &gitlab.ListMilestonesOptions{
+		ListOptions: gitlab.ListOptions{Page: 1, PerPage: MilestonesPerPage},
+	}
+	for {
+		milestones, resp, err := c.client.Milestones.ListMilestones(c.ProjectID(), opts)
+		if err != nil {
+			return nil, formatErrorCode("Milestones", err)
+		}
+		for _, m := range milestones {
+			ret = append(ret, data.Milestone{
+				Title:       m.Title,
+				URL:         fmt.Sprintf("%s/milestones/%d", c.ProjectURL, m.IID),
+				Description: m.Description,
+			})
+		}
+
+		if resp.NextPage == 0 {
+			return ret, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
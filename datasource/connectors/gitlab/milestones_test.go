@@ -0,0 +1,78 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gitlab_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/gitlab"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/gitlab/internal/testclient"
+)
+
+func TestConnector_Milestones(t *testing.T) {
+	tests := []struct {
+		name        string
+		returnValue testclient.ReturnValueStr
+		want        data.Milestones
+		wantErr     error
+	}{
+		{
+			name: "API returns proper data",
+			want: data.Milestones{
+				{
+					Title:       "0.1.0",
+					URL:         "https://gitlab.com/testowner/testrepo/milestones/1",
+					Description: "First minor release",
+				},
+				{
+					Title: "v0.1.2",
+					URL:   "https://gitlab.com/testowner/testrepo/milestones/2",
+				},
+				{
+					Title:       "Backlog",
+					URL:         "https://gitlab.com/testowner/testrepo/milestones/3",
+					Description: "Ideas for later",
+				},
+			},
+		},
+		{
+			name: "ListMilestones call fails",
+			returnValue: testclient.ReturnValueStr{
+				MilestonesServiceListMilestonesErr: true,
+			},
+			wantErr: errors.New("GitLab query 'Milestones' failed: can't fetch the milestones"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitlab.MilestonesPerPage = 2
+			c := setupTestConnector(tt.returnValue).(connectors.MilestoneProvider)
+
+			got, err := c.Milestones()
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Connector.Milestones() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Connector.Milestones() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -216,14 +216,34 @@ func (c *Connector) processMRs(
 						return
 					}
 
+					var closes []int
+					if c.FetchClosingRefs {
+						if closes, err = c.closedIssues(ctx, mr.IID); err != nil {
+							helpers.NonBlockingErrSend(ctx, cerr, err)
+							return
+						}
+					}
+
 					rmr := data.MR{
-						ID:         mr.IID,
-						Name:       mr.Title,
-						URL:        mr.WebURL,
-						MergedDate: (*commit.AuthoredDate).UTC(),
-						Author:     mr.Author.Username,
-						AuthorURL:  authorURL,
-						Labels:     mr.Labels,
+						ID:          mr.IID,
+						Name:        mr.Title,
+						URL:         mr.WebURL,
+						MergedDate:  (*commit.AuthoredDate).UTC(),
+						Author:      mr.Author.Username,
+						AuthorURL:   authorURL,
+						Labels:      mr.Labels,
+						Description: mr.Description,
+						Closes:      closes,
+						MergeCommit: mr.MergeCommitSHA,
+						IssueKeys: data.ParseIssueKeys(
+							mr.Title, mr.Description, mr.SourceBranch),
+					}
+
+					if c.FetchFiles {
+						if rmr.Files, err = c.mrFiles(mr.IID); err != nil {
+							helpers.NonBlockingErrSend(ctx, cerr, err)
+							return
+						}
 					}
 
 					select {
@@ -243,3 +263,50 @@ func (c *Connector) processMRs(
 
 	return ret
 }
+
+// closedIssues returns the IDs of issues, which are closed by the given MR.
+// The issues are linked in both directions via data.LinkIssues,
+// so the MRs closing the issues aren't queried separately
+func (c *Connector) closedIssues(ctx context.Context, mrID int) ([]int, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case c.closingRefs <- struct{}{}:
+	}
+	defer func() { <-c.closingRefs }()
+
+	var ret []int
+	opts := &gitlab.GetIssuesClosedOnMergeOptions{Page: 1, PerPage: ClosingRefsPerPage}
+	for {
+		issues, resp, err := c.client.MergeRequests.GetIssuesClosedOnMerge(c.ProjectID(), mrID, opts)
+		if err != nil {
+			return nil, formatErrorCode("closedIssues", err)
+		}
+
+		for _, issue := range issues {
+			ret = append(ret, issue.IID)
+		}
+
+		if resp.NextPage == 0 {
+			return ret, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// mrFiles returns the names of files changed by the given MR
+func (c *Connector) mrFiles(mrID int) ([]string, error) {
+	mr, _, err := c.client.MergeRequests.GetMergeRequestChanges(c.ProjectID(), mrID)
+	if err != nil {
+		return nil, formatErrorCode("mrFiles", err)
+	}
+
+	var ret []string
+	for _, change := range mr.Changes {
+		ret = append(ret, change.NewPath)
+		if change.RenamedFile && change.OldPath != change.NewPath {
+			ret = append(ret, change.OldPath)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,69 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"errors"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/gitlab/internal/client"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// PublishRelease creates or updates the GitLab release for the given tag.
+// GitLab releases do not support the draft and prerelease states
+func (c *Connector) PublishRelease(
+	opts connectors.PublishOptions,
+) (connectors.PublishStatus, error) {
+	if (opts.Draft != nil && *opts.Draft) || (opts.Prerelease != nil && *opts.Prerelease) {
+		return "", errors.New("draft and prerelease states are not supported by GitLab")
+	}
+
+	release, resp, err := c.client.Releases.GetRelease(c.ProjectID(), opts.Tag)
+	if err != nil {
+		// no release was found for this tag, this is no error for us
+		if resp == nil || resp.StatusCode != 404 {
+			return "", formatErrorCode("PublishRelease", err)
+		}
+		release = nil
+	}
+
+	if release == nil {
+		_, _, err = c.client.Releases.CreateRelease(c.ProjectID(), &client.ReleaseOptions{
+			TagName:     gitlab.String(opts.Tag),
+			Name:        gitlab.String(opts.Tag),
+			Description: gitlab.String(opts.Body),
+		})
+		if err != nil {
+			return "", formatErrorCode("PublishRelease", err)
+		}
+		return connectors.PublishCreated, nil
+	}
+
+	if opts.OnlyIfChanged && release.Description == opts.Body {
+		return connectors.PublishUnchanged, nil
+	}
+
+	_, _, err = c.client.Releases.UpdateRelease(c.ProjectID(), opts.Tag, &client.ReleaseOptions{
+		Description: gitlab.String(opts.Body),
+	})
+	if err != nil {
+		return "", formatErrorCode("PublishRelease", err)
+	}
+	return connectors.PublishUpdated, nil
+}
@@ -0,0 +1,109 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gitlab_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/gitlab/internal/testclient"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+)
+
+func TestConnector_PublishRelease(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        connectors.PublishOptions
+		returnValue testclient.ReturnValueStr
+		want        connectors.PublishStatus
+		wantErr     error
+	}{
+		{
+			name: "New release",
+			opts: connectors.PublishOptions{Tag: "v0.0.2", Body: "Notes"},
+			want: connectors.PublishCreated,
+		},
+		{
+			name: "Existing release with changed content",
+			opts: connectors.PublishOptions{Tag: "v0.0.1", Body: "Notes", OnlyIfChanged: true},
+			want: connectors.PublishUpdated,
+		},
+		{
+			name: "Existing release with same content",
+			opts: connectors.PublishOptions{
+				Tag: "v0.0.1", Body: "The very first release", OnlyIfChanged: true,
+			},
+			want: connectors.PublishUnchanged,
+		},
+		{
+			name: "Existing release with same content is always updated",
+			opts: connectors.PublishOptions{Tag: "v0.0.1", Body: "The very first release"},
+			want: connectors.PublishUpdated,
+		},
+		{
+			name:    "Prerelease is not supported",
+			opts:    connectors.PublishOptions{Tag: "v0.0.1", Body: "Notes", Prerelease: helpers.BoolPtr(true)},
+			wantErr: errors.New("draft and prerelease states are not supported by GitLab"),
+		},
+		{
+			name:        "Creation error",
+			opts:        connectors.PublishOptions{Tag: "v0.0.2", Body: "Notes"},
+			returnValue: testclient.ReturnValueStr{ReleasesServiceCreateReleaseErr: true},
+			wantErr:     errors.New("GitLab query 'PublishRelease' failed: can't create the release"),
+		},
+		{
+			name:        "Update error",
+			opts:        connectors.PublishOptions{Tag: "v0.0.1", Body: "Notes"},
+			returnValue: testclient.ReturnValueStr{ReleasesServiceUpdateReleaseErr: true},
+			wantErr:     errors.New("GitLab query 'PublishRelease' failed: can't update the release"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := setupTestConnector(tt.returnValue).(connectors.Publisher)
+
+			got, err := c.PublishRelease(tt.opts)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Connector.PublishRelease() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Connector.PublishRelease() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnector_PublishReleaseTwice(t *testing.T) {
+	c := setupTestConnector(testclient.ReturnValueStr{}).(connectors.Publisher)
+	opts := connectors.PublishOptions{Tag: "v0.0.2", Body: "Notes", OnlyIfChanged: true}
+
+	for _, want := range []connectors.PublishStatus{
+		connectors.PublishCreated,
+		connectors.PublishUnchanged,
+	} {
+		got, err := c.PublishRelease(opts)
+		if err != nil {
+			t.Fatalf("Connector.PublishRelease() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("Connector.PublishRelease() = %v, want %v", got, want)
+		}
+	}
+}
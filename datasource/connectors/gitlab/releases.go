@@ -0,0 +1,252 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/gitlab/internal/client"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// ReleasesPerPage defined how many releases are fetched per page
+var ReleasesPerPage = 30 // nolint: gochecknoglobals
+
+const (
+	releaseProcessingRoutines = 10
+)
+
+// Releases returns the GitLab releases as tags via channels.
+// The publishing date of releases is used, if no TagDateSource is configured,
+// the pipeline uses them instead of the git tags if --releases is given,
+// see Tags() for the details about returned values
+func (c *Connector) Releases(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	ctags <-chan data.Tag,
+	ctagscounter <-chan bool,
+	cmaxtags <-chan int,
+) {
+	// for detailed comments, please see the github/tags.go
+	releases := make(chan []*client.Release)
+	maxreleases := make(chan int)
+	releasescounter := make(chan bool, 100)
+
+	sctx, cancel := context.WithCancel(ctx)
+	scerr := make(chan error)
+
+	var wgRP, wgR sync.WaitGroup
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			return
+		case err, ok := <-scerr:
+			if ok {
+				cancel()
+				cerr <- err
+			}
+		}
+	}()
+
+	wgRP.Add(1)
+	go func() {
+		var wg sync.WaitGroup
+
+		closeCh := func() {
+			close(releases)
+			close(maxreleases)
+		}
+
+		resp, n, err := c.processReleasePage(sctx, 1, releases)
+		if err != nil {
+			helpers.NonBlockingErrSend(sctx, scerr, err)
+			closeCh()
+			return
+		}
+
+		if resp.TotalPages == 1 {
+			maxreleases <- n
+		} else {
+			cpages := c.processReleasePages(sctx, scerr, maxreleases, releases, &wg, resp.TotalPages)
+
+			for i := resp.TotalPages; i >= 2; i-- {
+				cpages <- i
+			}
+			close(cpages)
+		}
+
+		go func() {
+			wg.Wait()
+			closeCh()
+			wgRP.Done()
+		}()
+	}()
+
+	dtags := c.processReleases(sctx, scerr, releases, releasescounter, &wgR)
+
+	go func() {
+		wgRP.Wait()
+		wgR.Wait()
+		close(scerr)
+	}()
+
+	return dtags, releasescounter, maxreleases
+}
+
+// processReleasePage gets the releases from GitLab for given page and returns them via
+// given channel. releasesCount contains the amount of releases in the current response
+func (c *Connector) processReleasePage(
+	ctx context.Context,
+	page int,
+	ret chan<- []*client.Release,
+) (
+	resp *gitlab.Response,
+	releasesCount int,
+	err error,
+) {
+	releases, resp, err := c.client.Releases.ListReleases(
+		c.ProjectID(),
+		&gitlab.ListOptions{Page: page, PerPage: ReleasesPerPage},
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, 0, nil
+	case ret <- releases:
+		return resp, len(releases), nil
+	}
+}
+
+// processReleasePages processes GitLab release page numbers, given in the cpages channel
+// and returns the client.Release data structures via channel
+// possible errors are returned via given cerr channel
+func (c *Connector) processReleasePages(
+	ctx context.Context,
+	cerr chan<- error,
+	cmaxreleases chan<- int,
+	releases chan<- []*client.Release,
+	wg *sync.WaitGroup,
+	lastPage int,
+) (cpages chan<- int) {
+	ret := make(chan int)
+
+	for i := 0; i < releaseProcessingRoutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for page := range ret {
+				_, n, err := c.processReleasePage(ctx, page, releases)
+				if err != nil {
+					helpers.NonBlockingErrSend(ctx, cerr, err)
+					return
+				}
+
+				if page == lastPage {
+					cmaxreleases <- n + (lastPage-1)*ReleasesPerPage
+				}
+			}
+		}()
+	}
+
+	return ret
+}
+
+// processReleases processes given GitLab releases in the creleases channel and returns
+// them as tags in our data structure via channel.
+// GitLab has no drafts, upcoming releases are handled as drafts instead
+// and skipped, if IncludeDraftReleases isn't enabled
+// possible errors are returned via given cerr channel
+func (c *Connector) processReleases(
+	ctx context.Context,
+	cerr chan<- error,
+	creleases <-chan []*client.Release,
+	ctagscounter chan<- bool,
+	wg *sync.WaitGroup,
+) <-chan data.Tag {
+
+	ret := make(chan data.Tag)
+
+	for i := 0; i < releaseProcessingRoutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for releases := range creleases {
+				for _, release := range releases {
+					ctagscounter <- true
+					if release.UpcomingRelease && !c.IncludeDraftReleases {
+						continue
+					}
+					tagName := release.TagName
+
+					tagURL, err := c.getTagURL(tagName)
+					if err != nil {
+						helpers.NonBlockingErrSend(ctx, cerr, err)
+						return
+					}
+
+					// publishing date is the natural date of releases
+					var tagDate time.Time
+					if c.TagDateSource == connectors.TagDateDefault && release.ReleasedAt != nil {
+						tagDate = (*release.ReleasedAt).UTC()
+					} else {
+						tagDate, err = c.tagDate(tagName, release.Commit, nil, release)
+						if err != nil {
+							helpers.NonBlockingErrSend(ctx, cerr, err)
+							return
+						}
+					}
+
+					tag := data.Tag{
+						Name:        tagName,
+						Commit:      release.Commit.ID,
+						Date:        tagDate,
+						URL:         tagURL,
+						Title:       release.Name,
+						Description: release.Description,
+						Draft:       release.UpcomingRelease,
+					}
+
+					select {
+					case <-ctx.Done():
+						return
+					case ret <- tag:
+					}
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(ret)
+	}()
+
+	return ret
+}
@@ -19,7 +19,10 @@ package gitlab
 import (
 	"context"
 	"sync"
+	"time"
 
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/gitlab/internal/client"
 	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
 
 	"github.com/artem-sidorenko/chagen/data"
@@ -47,7 +50,7 @@ func (c *Connector) Tags(
 	cmaxtags <-chan int,
 ) {
 	// for detailed comments, please see the github/tags.go
-	tags := make(chan []*gitlab.Tag)
+	tags := make(chan []*client.Tag)
 	maxtags := make(chan int)
 	tagscounter := make(chan bool, 100)
 
@@ -118,7 +121,7 @@ func (c *Connector) Tags(
 func (c *Connector) processTagPage(
 	ctx context.Context,
 	page int,
-	ret chan<- []*gitlab.Tag,
+	ret chan<- []*client.Tag,
 ) (
 	resp *gitlab.Response,
 	tagsCount int,
@@ -143,13 +146,13 @@ func (c *Connector) processTagPage(
 }
 
 // processTagPages processes GitLab tag page numbers, given in the cpages channel and returns
-// the gitlab.Tag data structures via channel
+// the client.Tag data structures via channel
 // possible errors are returned via given cerr channel
 func (c *Connector) processTagPages(
 	ctx context.Context,
 	cerr chan<- error,
 	cmaxtags chan<- int,
-	tags chan<- []*gitlab.Tag,
+	tags chan<- []*client.Tag,
 	wg *sync.WaitGroup,
 	lastPage int,
 ) (cpages chan<- int) {
@@ -183,7 +186,7 @@ func (c *Connector) processTagPages(
 func (c *Connector) processTags(
 	ctx context.Context,
 	cerr chan<- error,
-	ctags <-chan []*gitlab.Tag,
+	ctags <-chan []*client.Tag,
 	ctagscounter chan<- bool,
 	wg *sync.WaitGroup,
 ) <-chan data.Tag {
@@ -208,11 +211,18 @@ func (c *Connector) processTags(
 						return
 					}
 
+					tagDate, err := c.tagDate(tagName, commit, tag, nil)
+					if err != nil {
+						helpers.NonBlockingErrSend(ctx, cerr, err)
+						return
+					}
+
 					tag := data.Tag{
-						Name:   tagName,
-						Commit: commit.ID,
-						Date:   (*commit.AuthoredDate).UTC(),
-						URL:    tagURL,
+						Name:        tagName,
+						Commit:      commit.ID,
+						Date:        tagDate,
+						URL:         tagURL,
+						Description: tagDescription(tag),
 					}
 
 					select {
@@ -232,3 +242,57 @@ func (c *Connector) processTags(
 
 	return ret
 }
+
+// tagDate returns the date of given tag according to the configured TagDateSource.
+// The author date is used if nothing else is configured or if the configured
+// source isn't available for this tag (lightweight tag or no release).
+// The tag or the release are fetched if nil is given
+func (c *Connector) tagDate(
+	tagName string,
+	commit *gitlab.Commit,
+	tag *client.Tag,
+	release *client.Release,
+) (time.Time, error) {
+	switch c.TagDateSource {
+	case connectors.TagDateCommit:
+		return (*commit.CommittedDate).UTC(), nil
+	case connectors.TagDateTag:
+		if tag == nil {
+			var err error
+			if tag, _, err = c.client.Tags.GetTag(c.ProjectID(), tagName); err != nil {
+				return time.Time{}, formatErrorCode("tagDate", err)
+			}
+		}
+		if tag.CreatedAt != nil {
+			return (*tag.CreatedAt).UTC(), nil
+		}
+	case connectors.TagDateRelease:
+		if release == nil {
+			var (
+				resp *gitlab.Response
+				err  error
+			)
+			release, resp, err = c.client.Releases.GetRelease(c.ProjectID(), tagName)
+			if err != nil {
+				// no release was found for this tag, this is no error for us
+				if resp == nil || resp.StatusCode != 404 {
+					return time.Time{}, formatErrorCode("tagDate", err)
+				}
+			}
+		}
+		if release != nil && release.ReleasedAt != nil {
+			return (*release.ReleasedAt).UTC(), nil
+		}
+	}
+
+	return (*commit.AuthoredDate).UTC(), nil
+}
+
+// tagDescription returns the description of given tag: the description of the release
+// or the message of the annotated tag if there is no release description
+func tagDescription(tag *client.Tag) string {
+	if tag.Release != nil && tag.Release.Description != "" {
+		return tag.Release.Description
+	}
+	return tag.Message
+}
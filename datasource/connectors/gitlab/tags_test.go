@@ -42,10 +42,11 @@ func TestConnector_Tags(t *testing.T) {
 			name: "API returns proper data",
 			want: data.Tags{
 				{
-					Name:   "v0.1.2",
-					Commit: "d8351413f688c96c2c5d6fe58ebf5ac17f545bc0",
-					Date:   helpers.Time(1048183647),
-					URL:    "https://gitlab.com/testowner/testrepo/tags/v0.1.2",
+					Name:        "v0.1.2",
+					Commit:      "d8351413f688c96c2c5d6fe58ebf5ac17f545bc0",
+					Date:        helpers.Time(1048183647),
+					URL:         "https://gitlab.com/testowner/testrepo/tags/v0.1.2",
+					Description: "Highlights of v0.1.2",
 				},
 				{
 					Name:   "v0.1.1",
@@ -54,10 +55,11 @@ func TestConnector_Tags(t *testing.T) {
 					URL:    "https://gitlab.com/testowner/testrepo/tags/v0.1.1",
 				},
 				{
-					Name:   "v0.1.0",
-					Commit: "dbbf36ffaae700a2ce03ef849d6f944031f34b95",
-					Date:   helpers.Time(1047983647),
-					URL:    "https://gitlab.com/testowner/testrepo/tags/v0.1.0",
+					Name:        "v0.1.0",
+					Commit:      "dbbf36ffaae700a2ce03ef849d6f944031f34b95",
+					Date:        helpers.Time(1047983647),
+					URL:         "https://gitlab.com/testowner/testrepo/tags/v0.1.0",
+					Description: "Try the new features",
 				},
 				{
 					Name:   "v0.0.9",
@@ -72,10 +74,11 @@ func TestConnector_Tags(t *testing.T) {
 					URL:    "https://gitlab.com/testowner/testrepo/tags/v0.0.8",
 				},
 				{
-					Name:   "v0.0.7",
-					Commit: "d21438494dd0722c1d13dc496ae1f60fb85084c1",
-					Date:   helpers.Time(1047683647),
-					URL:    "https://gitlab.com/testowner/testrepo/tags/v0.0.7",
+					Name:        "v0.0.7",
+					Commit:      "d21438494dd0722c1d13dc496ae1f60fb85084c1",
+					Date:        helpers.Time(1047683647),
+					URL:         "https://gitlab.com/testowner/testrepo/tags/v0.0.7",
+					Description: "Some bugfixes",
 				},
 				{
 					Name:   "v0.0.6",
@@ -84,10 +87,11 @@ func TestConnector_Tags(t *testing.T) {
 					URL:    "https://gitlab.com/testowner/testrepo/tags/v0.0.6",
 				},
 				{
-					Name:   "v0.0.5",
-					Commit: "746e45ea014e257bcb7caa2c100ed1e5f63ed234",
-					Date:   helpers.Time(1047483647),
-					URL:    "https://gitlab.com/testowner/testrepo/tags/v0.0.5",
+					Name:        "v0.0.5",
+					Commit:      "746e45ea014e257bcb7caa2c100ed1e5f63ed234",
+					Date:        helpers.Time(1047483647),
+					URL:         "https://gitlab.com/testowner/testrepo/tags/v0.0.5",
+					Description: "Tag message of v0.0.5",
 				},
 				{
 					Name:   "v0.0.4",
@@ -108,10 +112,11 @@ func TestConnector_Tags(t *testing.T) {
 					URL:    "https://gitlab.com/testowner/testrepo/tags/v0.0.2",
 				},
 				{
-					Name:   "v0.0.1",
-					Commit: "7d84cdb2f7c2d4619cda4b8adeb1897097b5c8fc",
-					Date:   helpers.Time(1047083647),
-					URL:    "https://gitlab.com/testowner/testrepo/tags/v0.0.1",
+					Name:        "v0.0.1",
+					Commit:      "7d84cdb2f7c2d4619cda4b8adeb1897097b5c8fc",
+					Date:        helpers.Time(1047083647),
+					URL:         "https://gitlab.com/testowner/testrepo/tags/v0.0.1",
+					Description: "The very first release",
 				},
 			},
 			wantMaxtags: []int{12},
@@ -165,3 +170,95 @@ func TestConnector_Tags(t *testing.T) {
 		})
 	}
 }
+
+func TestConnector_TagsDateSource(t *testing.T) {
+	tests := []struct {
+		name          string
+		tagDateSource string
+		returnValue   testclient.ReturnValueStr
+		want          map[string]time.Time
+		wantErr       error
+	}{
+		{
+			name:          "Committer date",
+			tagDateSource: "commit",
+			want: map[string]time.Time{
+				"v0.0.1": helpers.Time(1047083677),
+				"v0.0.2": helpers.Time(1047183677),
+				"v0.1.2": helpers.Time(1048183677),
+			},
+		},
+		{
+			name:          "Author date",
+			tagDateSource: "author",
+			want: map[string]time.Time{
+				"v0.0.1": helpers.Time(1047083647),
+				"v0.0.2": helpers.Time(1047183647),
+				"v0.1.2": helpers.Time(1048183647),
+			},
+		},
+		{
+			name:          "Tagger date with fallback for lightweight tags",
+			tagDateSource: "tag",
+			want: map[string]time.Time{
+				"v0.0.1": helpers.Time(1047083697),
+				"v0.0.2": helpers.Time(1047183647),
+				"v0.1.2": helpers.Time(1048183697),
+			},
+		},
+		{
+			name:          "Release date with fallback for tags without release",
+			tagDateSource: "release",
+			want: map[string]time.Time{
+				"v0.0.1": helpers.Time(1047083657),
+				"v0.0.2": helpers.Time(1047183647),
+				"v0.1.2": helpers.Time(1048183657),
+			},
+		},
+		{
+			name:          "GetRelease call fails",
+			tagDateSource: "release",
+			returnValue: testclient.ReturnValueStr{
+				ReleasesServiceGetReleaseErr: true,
+			},
+			wantErr: errors.New("GitLab query 'tagDate' failed: can't fetch the release"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitlab.TagsPerPage = 5
+			c := setupTestConnectorWithFlags(
+				tt.returnValue,
+				map[string]string{"tag-date-source": tt.tagDateSource},
+			)
+			cerr := make(chan error, 1)
+
+			cgot, _, cmaxtags := c.Tags(context.Background(), cerr)
+			helpers.GetChannelValuesInt(cmaxtags)
+
+			got := map[string]time.Time{}
+			for t := range cgot {
+				if _, ok := tt.want[t.Name]; ok {
+					got[t.Name] = t.Date
+				}
+			}
+
+			// sleep and allow the possible error to be delivered to the channel
+			time.Sleep(time.Millisecond * 200)
+			var err error
+			select {
+			case err = <-cerr:
+			default:
+			}
+
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Connector.Tags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Connector.Tags() dates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
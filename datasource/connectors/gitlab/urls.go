@@ -47,3 +47,14 @@ func (c *Connector) getUsernameURL(username string) (string, error) {
 func (c *Connector) GetNewTagURL(TagName string) (string, error) {
 	return c.getTagURL(TagName)
 }
+
+// CompareURL returns the URL with the changes between given tags
+func (c *Connector) CompareURL(from, to string) (string, error) {
+	u, err := url.Parse(c.ProjectURL)
+	if err != nil {
+		return "", err
+	}
+
+	u.Path = path.Join(u.Path, "/compare/"+from+"..."+to)
+	return u.String(), nil
+}
@@ -42,6 +42,11 @@ func StringPtr(s string) *string {
 	return &s
 }
 
+// BoolPtr returns a pointer for a given bool
+func BoolPtr(b bool) *bool {
+	return &b
+}
+
 // TimePtr returns a pointer for a given time
 func TimePtr(t time.Time) *time.Time {
 	return &t
@@ -0,0 +1,155 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package helpers
+
+import (
+	"context"
+	"sync"
+)
+
+// Page describes the paging information of a fetched page
+type Page struct {
+	Items    int // amount of items on this page
+	LastPage int // number of the last page, 0 if there are no further pages
+}
+
+// PageFunc fetches the given page number, sends its items
+// to the connector channels and returns the paging information
+type PageFunc func(ctx context.Context, page int) (Page, error)
+
+// FetchPages fetches all pages via fetch like the GitHub connector does: the first page
+// is fetched at first to get the number of last page, afterwards the other pages are
+// fetched in parallel by the given amount of routines.
+// The max amount of items is sent to cmax as soon as it is known,
+// perPage is used for its calculation. Errors are sent to cerr.
+// FetchPages returns when all pages are fetched
+func FetchPages(
+	ctx context.Context,
+	cerr chan<- error,
+	cmax chan<- int,
+	routines int,
+	perPage int,
+	fetch PageFunc,
+) {
+	sctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	first, err := fetch(sctx, 1)
+	if err != nil {
+		NonBlockingErrSend(ctx, cerr, err)
+		return
+	}
+	if first.LastPage <= 1 {
+		NonBlockingMaxSend(ctx, cmax, first.Items)
+		return
+	}
+
+	pages := make(chan int)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	for i := 0; i < routines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for page := range pages {
+				p, err := fetch(sctx, page)
+				if err != nil {
+					// only the first error is reported, it stops the other routines
+					errOnce.Do(func() {
+						cancel()
+						NonBlockingErrSend(ctx, cerr, err)
+					})
+					return
+				}
+				if page == first.LastPage {
+					NonBlockingMaxSend(ctx, cmax, p.Items+(first.LastPage-1)*perPage)
+				}
+			}
+		}()
+	}
+
+	// the last page is fetched first, so the max amount is known early
+feed:
+	for page := first.LastPage; page >= 2; page-- {
+		select {
+		case <-sctx.Done():
+			break feed
+		case pages <- page:
+		}
+	}
+	close(pages)
+	wg.Wait()
+}
+
+// NonBlockingMaxSend sends the max amount of items to the channel cmax
+// on the way, where the block might be released via context
+func NonBlockingMaxSend(ctx context.Context, cmax chan<- int, max int) {
+	select {
+	case <-ctx.Done():
+	case cmax <- max:
+	}
+}
+
+// CursorPage describes the paging information of a page fetched via cursor
+type CursorPage struct {
+	Items int    // amount of items on this page
+	Total int    // total amount of items, if provided by the API, 0 otherwise
+	Next  string // cursor of the next page, empty if this page is the last one
+}
+
+// CursorFunc fetches the page at given cursor, sends its items
+// to the connector channels and returns the paging information.
+// The first page is fetched with an empty cursor
+type CursorFunc func(ctx context.Context, cursor string) (CursorPage, error)
+
+// FetchCursorPages fetches all pages via fetch for the APIs with cursor based paging
+// (e.g. next links, isLastPage/nextPageStart or continuation tokens). The cursor of next
+// page is known only after fetching the current one, so the pages are fetched sequentially.
+// The max amount of items is sent to cmax as soon as it is known: either the total amount
+// provided by the API or the sum of items after the last page. Errors are sent to cerr.
+// FetchCursorPages returns when all pages are fetched
+func FetchCursorPages(
+	ctx context.Context,
+	cerr chan<- error,
+	cmax chan<- int,
+	fetch CursorFunc,
+) {
+	items := 0
+	maxSent := false
+	cursor := ""
+	for {
+		p, err := fetch(ctx, cursor)
+		if err != nil {
+			NonBlockingErrSend(ctx, cerr, err)
+			return
+		}
+		items += p.Items
+
+		if p.Total > 0 && !maxSent {
+			NonBlockingMaxSend(ctx, cmax, p.Total)
+			maxSent = true
+		}
+		if p.Next == "" || p.Next == cursor {
+			if !maxSent {
+				NonBlockingMaxSend(ctx, cmax, items)
+			}
+			return
+		}
+		cursor = p.Next
+	}
+}
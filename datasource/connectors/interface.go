@@ -51,3 +51,117 @@ type Connector interface {
 	GetNewTagURL(string) (string, error)
 	RepositoryExists() (bool, error)
 }
+
+// The optional capabilities of connectors are described by own interfaces,
+// the users have to detect them with type assertions and should degrade
+// gracefully if the selected connector doesn't provide them
+
+// CommitProvider is implemented by the connectors,
+// which are able to provide the commits of the default branch.
+// The tags of repository are given, so the connectors can limit
+// the queried history to the ranges between them
+type CommitProvider interface {
+	Commits(
+		ctx context.Context,
+		cerr chan<- error,
+		tags data.Tags,
+	) (
+		ccommits <-chan data.Commit,
+		ccommitscounter <-chan bool,
+		cmaxcommits <-chan int,
+	)
+}
+
+// CompareURLProvider is implemented by the connectors,
+// which are able to link the changes between two tags
+type CompareURLProvider interface {
+	CompareURL(from, to string) (string, error)
+}
+
+// ReleaseNotesProvider is implemented by the connectors, which are able
+// to provide the hosted releases with their notes instead of git tags.
+// The values are returned like in Connector.Tags()
+type ReleaseNotesProvider interface {
+	Releases(
+		ctx context.Context,
+		cerr chan<- error,
+	) (
+		ctags <-chan data.Tag,
+		ctagsscounter <-chan bool,
+		cmaxtags <-chan int,
+	)
+}
+
+// MilestoneProvider is implemented by the connectors,
+// which are able to provide the milestones of repository
+type MilestoneProvider interface {
+	Milestones() (data.Milestones, error)
+}
+
+// optional capabilities of connectors, the values are used in messages for humans
+const (
+	FeatureCommits      = "direct commits"
+	FeatureCompareURLs  = "compare links"
+	FeatureReleaseNotes = "hosted releases"
+	FeatureMilestones   = "milestones"
+	FeaturePublishing   = "publishing of releases"
+)
+
+// Features returns all optional features of connectors in a stable order
+func Features() []string {
+	return []string{
+		FeatureReleaseNotes,
+		FeatureCommits,
+		FeatureCompareURLs,
+		FeatureMilestones,
+		FeaturePublishing,
+	}
+}
+
+// Supports returns true if the connector implements the interface of given feature
+func Supports(conn Connector, feature string) bool {
+	var ok bool
+	switch feature {
+	case FeatureCommits:
+		_, ok = conn.(CommitProvider)
+	case FeatureCompareURLs:
+		_, ok = conn.(CompareURLProvider)
+	case FeatureReleaseNotes:
+		_, ok = conn.(ReleaseNotesProvider)
+	case FeatureMilestones:
+		_, ok = conn.(MilestoneProvider)
+	case FeaturePublishing:
+		_, ok = conn.(Publisher)
+	}
+	return ok
+}
+
+// PublishStatus describes the result of publishing a release
+type PublishStatus string
+
+// possible results of publishing a release
+const (
+	// PublishCreated is returned if a new release was created
+	PublishCreated PublishStatus = "created"
+	// PublishUpdated is returned if an existing release was updated
+	PublishUpdated PublishStatus = "updated"
+	// PublishUnchanged is returned if the existing release has already the same content
+	PublishUnchanged PublishStatus = "unchanged"
+)
+
+// PublishOptions describes the release, which should be published.
+// Draft and Prerelease are nil, if the states of existing releases should be kept
+type PublishOptions struct {
+	Tag        string
+	Body       string
+	Draft      *bool
+	Prerelease *bool
+	// OnlyIfChanged avoids updating of existing releases with the same content
+	OnlyIfChanged bool
+}
+
+// Publisher is implemented by the connectors,
+// which are able to create or update the hosted releases
+type Publisher interface {
+	PublishRelease(opts PublishOptions) (PublishStatus, error)
+}
@@ -0,0 +1,168 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timestampLayout is the format of Jira timestamps
+const timestampLayout = "2006-01-02T15:04:05.000-0700"
+
+// searchFields are the fields of issues, which are requested from the search API
+const searchFields = "summary,status,resolution,resolutiondate," +
+	"statuscategorychangedate,labels,issuetype"
+
+// statusCategoryDone is the key of status category of completed issues
+const statusCategoryDone = "done"
+
+// apiTimestamp is the timestamp of Jira API
+type apiTimestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (t *apiTimestamp) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	ts, err := time.Parse(timestampLayout, s)
+	if err != nil {
+		return err
+	}
+	t.Time = ts.UTC()
+	return nil
+}
+
+// the API structures, only the needed fields are described
+
+type apiName struct {
+	Name string `json:"name"`
+}
+
+type apiStatus struct {
+	StatusCategory struct {
+		Key string `json:"key"`
+	} `json:"statusCategory"`
+}
+
+type apiIssueFields struct {
+	Summary                  string        `json:"summary"`
+	Status                   *apiStatus    `json:"status"`
+	Resolution               *apiName      `json:"resolution"`
+	ResolutionDate           *apiTimestamp `json:"resolutiondate"`
+	StatusCategoryChangeDate *apiTimestamp `json:"statuscategorychangedate"`
+	Labels                   []string      `json:"labels"`
+	IssueType                *apiName      `json:"issuetype"`
+}
+
+type apiIssue struct {
+	Key    string         `json:"key"`
+	Fields apiIssueFields `json:"fields"`
+}
+
+// apiSearchResult is the result of search API, Jira Server provides
+// the startAt based paging, Jira Cloud the paging via nextPageToken
+type apiSearchResult struct {
+	StartAt       int        `json:"startAt"`
+	Total         int        `json:"total"`
+	Issues        []apiIssue `json:"issues"`
+	NextPageToken string     `json:"nextPageToken"`
+}
+
+type apiError struct {
+	ErrorMessages []string `json:"errorMessages"`
+}
+
+// search returns the page of configured query at given cursor,
+// the cursor is the startAt value for Jira Server and the nextPageToken for Jira Cloud
+func (c *Connector) search(
+	ctx context.Context,
+	cursor string,
+	maxResults int,
+) (apiSearchResult, error) {
+	path := "/rest/api/2/search"
+	query := url.Values{}
+	query.Set("jql", c.JQL)
+	query.Set("maxResults", strconv.Itoa(maxResults))
+	query.Set("fields", searchFields)
+	if c.Cloud {
+		path += "/jql"
+		if cursor != "" {
+			query.Set("nextPageToken", cursor)
+		}
+	} else {
+		if cursor == "" {
+			cursor = "0"
+		}
+		query.Set("startAt", cursor)
+	}
+
+	var ret apiSearchResult
+	err := c.get(ctx, path, query, &ret)
+	return ret, err
+}
+
+// get requests the given API path and decodes the JSON response to v.
+// The error messages of Jira are returned as part of the error
+func (c *Connector) get(
+	ctx context.Context,
+	path string,
+	query url.Values,
+	v interface{},
+) error {
+	u := c.BaseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case c.User != "":
+		req.SetBasicAuth(c.User, c.Token)
+	case c.Token != "":
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr apiError
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && len(apiErr.ErrorMessages) > 0 {
+			return fmt.Errorf("GET %v: %v: %v",
+				path, resp.Status, strings.Join(apiErr.ErrorMessages, ", "))
+		}
+		return fmt.Errorf("GET %v: %v", path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
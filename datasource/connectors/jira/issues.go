@@ -0,0 +1,135 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package jira
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// IssuesPerPage defined how many issues are fetched per page
+var IssuesPerPage = 100 // nolint: gochecknoglobals
+
+// closeReasons maps the lower cased names of Jira resolutions to the close reasons
+var closeReasons = map[string]string{ // nolint: gochecknoglobals
+	"done":             data.CloseReasonCompleted,
+	"fixed":            data.CloseReasonCompleted,
+	"resolved":         data.CloseReasonCompleted,
+	"won't do":         data.CloseReasonNotPlanned,
+	"won't fix":        data.CloseReasonNotPlanned,
+	"duplicate":        data.CloseReasonNotPlanned,
+	"cannot reproduce": data.CloseReasonNotPlanned,
+	"declined":         data.CloseReasonNotPlanned,
+	"incomplete":       data.CloseReasonNotPlanned,
+}
+
+// Issues returns the issues found by the configured query via channels,
+// the issues without resolution or done status are skipped
+// Returns possible errors via given cerr channel
+// cissues returns issues
+// cissuescounter returns the channel, which ticks when an issue is proceeded
+// cmaxissues returns the max available amount of issues
+func (c *Connector) Issues(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	cissues <-chan data.Issue,
+	cissuescounter <-chan bool,
+	cmaxissues <-chan int,
+) {
+	issues := make(chan data.Issue)
+	maxissues := make(chan int, 1)
+	issuescounter := make(chan bool, 100)
+
+	go func() {
+		defer close(issues)
+		defer close(issuescounter)
+		defer close(maxissues)
+
+		helpers.FetchCursorPages(ctx, cerr, maxissues,
+			func(ctx context.Context, cursor string) (helpers.CursorPage, error) {
+				res, err := c.search(ctx, cursor, IssuesPerPage)
+				if err != nil {
+					return helpers.CursorPage{}, formatErrorCode("Issues", err)
+				}
+
+				for _, i := range res.Issues {
+					if ri, ok := c.issue(i); ok {
+						select {
+						case <-ctx.Done():
+							return helpers.CursorPage{}, ctx.Err()
+						case issues <- ri:
+						}
+					}
+					issuescounter <- true
+				}
+				return c.page(res), nil
+			})
+	}()
+
+	return issues, issuescounter, maxissues
+}
+
+// page returns the paging information of given search result
+func (c *Connector) page(res apiSearchResult) helpers.CursorPage {
+	ret := helpers.CursorPage{Items: len(res.Issues)}
+	if c.Cloud {
+		// Jira Cloud does not provide the total amount
+		ret.Next = res.NextPageToken
+		return ret
+	}
+	ret.Total = res.Total
+	if next := res.StartAt + len(res.Issues); len(res.Issues) > 0 && next < res.Total {
+		ret.Next = strconv.Itoa(next)
+	}
+	return ret
+}
+
+// issue converts the Jira issue to our data structure, the issue type
+// is used as label additionally. The date of status change is used as closing date
+// for issues without resolution, if they are in the done status category.
+// It returns false, if the issue is not closed
+func (c *Connector) issue(i apiIssue) (data.Issue, bool) {
+	f := i.Fields
+	ret := data.Issue{
+		Key:    i.Key,
+		Name:   f.Summary,
+		URL:    c.issueURL(i.Key),
+		Labels: f.Labels,
+	}
+	switch {
+	case f.ResolutionDate != nil:
+		ret.ClosedDate = f.ResolutionDate.Time
+	case f.StatusCategoryChangeDate != nil && f.Status != nil &&
+		f.Status.StatusCategory.Key == statusCategoryDone:
+		ret.ClosedDate = f.StatusCategoryChangeDate.Time
+	default:
+		return data.Issue{}, false
+	}
+	if f.Resolution != nil {
+		ret.CloseReason = closeReasons[strings.ToLower(f.Resolution.Name)]
+	}
+	if f.IssueType != nil && f.IssueType.Name != "" {
+		ret.Labels = append([]string{f.IssueType.Name}, ret.Labels...)
+	}
+	return ret, true
+}
@@ -0,0 +1,113 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package jira_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource/connectors/jira"
+	"github.com/artem-sidorenko/chagen/internal/testing/helpers"
+)
+
+func TestConnector_Issues(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name  string
+		cloud bool
+	}{
+		{
+			name: "Jira Server",
+		},
+		{
+			name:  "Jira Cloud",
+			cloud: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jira.IssuesPerPage = 2
+			c, err := newTestConnector(srv.URL, nil)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			c.Cloud = tt.cloud
+			browse := srv.URL + "/browse/"
+			cerr := make(chan error, 1)
+
+			cgot, cissuescounter, cmaxissues := c.Issues(context.Background(), cerr)
+
+			var got data.Issues
+			for i := range cgot {
+				got = append(got, i)
+			}
+			processed := 0
+			for range cissuescounter {
+				processed++
+			}
+			gotmax := helpers.GetChannelValuesInt(cmaxissues)
+
+			select {
+			case err := <-cerr:
+				t.Fatalf("Connector.Issues() error = %v", err)
+			default:
+			}
+
+			want := data.Issues{
+				{
+					Key:         "ABC-12",
+					Name:        "Crash on empty config",
+					ClosedDate:  time.Date(2019, 3, 18, 9, 0, 0, 0, time.UTC),
+					URL:         browse + "ABC-12",
+					Labels:      []string{"Bug", "backend"},
+					CloseReason: data.CloseReasonCompleted,
+				},
+				{
+					Key:         "ABC-10",
+					Name:        "Support XML configs",
+					ClosedDate:  time.Date(2019, 3, 17, 9, 0, 0, 0, time.UTC),
+					URL:         browse + "ABC-10",
+					Labels:      []string{"Story"},
+					CloseReason: data.CloseReasonNotPlanned,
+				},
+				{
+					Key:        "ABC-7",
+					Name:       "Document the options",
+					ClosedDate: time.Date(2019, 3, 16, 8, 0, 0, 0, time.UTC),
+					URL:        browse + "ABC-7",
+					Labels:     []string{"Task"},
+				},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Connector.Issues() = %+v, want %+v", got, want)
+			}
+			// the not closed issues are processed too
+			if processed != 4 {
+				t.Errorf("Connector.Issues() processed = %v, want %v", processed, 4)
+			}
+			// Jira Cloud does not provide the total amount, it is known after the last page
+			if !reflect.DeepEqual(gotmax, []int{4}) {
+				t.Errorf("Connector.Issues() maxissues = %v, want %v", gotmax, []int{4})
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package jira implements the connector for Jira, which provides only issues.
+// It is used via --issues-endpoint together with a connector of code hosting
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+
+	"github.com/urfave/cli"
+)
+
+// AccessTokenEnvVar contains the name of environment variable which sets
+// the API token of Jira Cloud or the personal access token of Jira Server
+const AccessTokenEnvVar = "CHAGEN_JIRA_TOKEN" // nolint: gosec
+
+// UserEnvVar contains the name of environment variable which sets the user name
+// for API tokens of Jira Cloud, personal access tokens are used without it
+const UserEnvVar = "CHAGEN_JIRA_USER"
+
+// cloudDomain is the domain of Jira Cloud sites, they provide another search API
+const cloudDomain = ".atlassian.net"
+
+// Connector implements the Jira connector
+type Connector struct {
+	context context.Context
+	client  *http.Client
+	BaseURL string
+	User    string
+	Token   string
+	JQL     string
+	Cloud   bool
+}
+
+// RepositoryExists checks if the configured query can be used,
+// Jira refuses the queries with unknown projects
+func (c *Connector) RepositoryExists() (bool, error) {
+	if _, err := c.search(c.context, "", 1); err != nil {
+		return false, formatErrorCode("RepositoryExists", err)
+	}
+	return true, nil
+}
+
+// New returns a new initialized Connector or error if any
+func New(ctx *cli.Context) (connectors.Connector, error) {
+	baseURL := strings.TrimSuffix(ctx.String("jira-url"), "/")
+	if baseURL == "" {
+		return nil, errors.New("option --jira-url is required")
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse the Jira URL: %v", err)
+	}
+
+	jql := ctx.String("jira-jql")
+	if jql == "" {
+		project := ctx.String("jira-project")
+		if project == "" {
+			return nil, errors.New("option --jira-project or --jira-jql is required")
+		}
+		jql = fmt.Sprintf(`project = "%s" AND statusCategory = Done ORDER BY key`, project)
+	}
+
+	return &Connector{
+		context: context.Background(),
+		client:  http.DefaultClient,
+		BaseURL: baseURL,
+		User:    os.Getenv(UserEnvVar),
+		Token:   os.Getenv(AccessTokenEnvVar),
+		JQL:     jql,
+		Cloud:   strings.HasSuffix(u.Hostname(), cloudDomain),
+	}, nil
+}
+
+// CLIFlags returns the possible CLI flags for this connector
+func CLIFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "jira-url",
+			Usage: "Base URL of the Jira instance, e.g. https://example.atlassian.net",
+		},
+		cli.StringFlag{
+			Name:  "jira-project",
+			Usage: "Key of Jira project, its done issues are used as closed issues",
+		},
+		cli.StringFlag{
+			Name:  "jira-jql",
+			Usage: "JQL query for the closed issues, it is used instead of --jira-project",
+		},
+	}
+}
+
+func init() { // nolint: gochecknoinits
+	connectors.RegisterConnector("jira", "Jira", New, CLIFlags)
+	connectors.RegisterConnectorDetails("jira",
+		[]string{AccessTokenEnvVar, UserEnvVar}, &Connector{})
+}
@@ -0,0 +1,237 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package jira_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/jira"
+	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+)
+
+const (
+	testUser  = "testuser"
+	testToken = "testtoken"
+)
+
+// recorded returns the path of recorded API response
+func recorded(name string) string {
+	return filepath.Join("testdata", name+".json")
+}
+
+// newTestServer returns the server, which replays the recorded API responses
+// of ABC project from testdata. The file name is derived from the path and
+// the paging parameter, e.g. /rest/api/2/search?startAt=2 is served from
+// search_startAt2.json and /rest/api/2/search/jql without nextPageToken
+// from searchjql_first.json. The queries for MISSING project are refused
+func newTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != testUser || pass != testToken {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		query := r.URL.Query()
+		if strings.Contains(query.Get("jql"), "MISSING") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorMessages":["The value 'MISSING' does not exist for the field 'project'."],"errors":{}}`)) // nolint: errcheck, gosec, lll
+			return
+		}
+
+		var name string
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			name = "search_startAt" + query.Get("startAt")
+		case "/rest/api/2/search/jql":
+			name = "searchjql_first"
+			if token := query.Get("nextPageToken"); token != "" {
+				name = "searchjql_" + token
+			}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		content, err := ioutil.ReadFile(recorded(name))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content) // nolint: errcheck, gosec
+	}))
+}
+
+// newTestConnector returns the connector configured for the test server
+func newTestConnector(url string, flags map[string]string) (*jira.Connector, error) {
+	os.Setenv(jira.UserEnvVar, testUser)         // nolint: errcheck, gosec
+	os.Setenv(jira.AccessTokenEnvVar, testToken) // nolint: errcheck, gosec
+	defer os.Unsetenv(jira.UserEnvVar)           // nolint: errcheck
+	defer os.Unsetenv(jira.AccessTokenEnvVar)    // nolint: errcheck
+
+	cliFlags := map[string]string{
+		"jira-url":     url,
+		"jira-project": "ABC",
+	}
+	for k, v := range flags {
+		cliFlags[k] = v
+	}
+	conn, err := jira.New(tcli.TestContext(jira.CLIFlags(), cliFlags))
+	if err != nil {
+		return nil, err
+	}
+	return conn.(*jira.Connector), nil
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		flags   map[string]string
+		want    *jira.Connector
+		wantErr error
+	}{
+		{
+			name: "Jira Server with project",
+			url:  "https://jira.example.com/",
+			want: &jira.Connector{
+				BaseURL: "https://jira.example.com",
+				User:    testUser,
+				Token:   testToken,
+				JQL:     `project = "ABC" AND statusCategory = Done ORDER BY key`,
+			},
+		},
+		{
+			name:  "Jira Cloud with JQL",
+			url:   "https://example.atlassian.net",
+			flags: map[string]string{"jira-jql": "project = ABC AND resolution = Fixed"},
+			want: &jira.Connector{
+				BaseURL: "https://example.atlassian.net",
+				User:    testUser,
+				Token:   testToken,
+				JQL:     "project = ABC AND resolution = Fixed",
+				Cloud:   true,
+			},
+		},
+		{
+			name:    "Missing URL",
+			wantErr: errors.New("option --jira-url is required"),
+		},
+		{
+			name:    "Missing project and JQL",
+			url:     "https://jira.example.com",
+			flags:   map[string]string{"jira-project": ""},
+			wantErr: errors.New("option --jira-project or --jira-jql is required"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newTestConnector(tt.url, tt.flags)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if c.BaseURL != tt.want.BaseURL || c.User != tt.want.User ||
+				c.Token != tt.want.Token || c.JQL != tt.want.JQL || c.Cloud != tt.want.Cloud {
+				t.Errorf("New() = %+v, want %+v", c, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnector_RepositoryExists(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		project string
+		token   string
+		want    bool
+		wantErr error
+	}{
+		{
+			name:    "Existing project",
+			project: "ABC",
+			want:    true,
+		},
+		{
+			name:    "Missing project",
+			project: "MISSING",
+			wantErr: errors.New("Jira query 'RepositoryExists' failed: GET /rest/api/2/search: 400 Bad Request: The value 'MISSING' does not exist for the field 'project'."), // nolint: lll
+		},
+		{
+			name:    "Wrong password",
+			project: "ABC",
+			token:   "wrongtoken",
+			wantErr: errors.New("Jira query 'RepositoryExists' failed: GET /rest/api/2/search: 401 Unauthorized"), // nolint: lll
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newTestConnector(srv.URL, map[string]string{"jira-project": tt.project})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if tt.token != "" {
+				c.Token = tt.token
+			}
+
+			got, err := c.RepositoryExists()
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("Connector.RepositoryExists() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Connector.RepositoryExists() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnector_IssuesOnly(t *testing.T) {
+	c, err := newTestConnector("https://jira.example.com", nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	wantErr := errors.New("only issues are provided by Jira, use it via --issues-endpoint jira")
+
+	cerr := make(chan error, 1)
+	ctags, _, _ := c.Tags(context.Background(), cerr)
+	for range ctags {
+		t.Errorf("Connector.Tags() returned a tag")
+	}
+	if err := <-cerr; !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("Connector.Tags() error = %v, wantErr %v", err, wantErr)
+	}
+
+	if _, err := c.GetNewTagURL("v1.0.0"); !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("Connector.GetNewTagURL() error = %v, wantErr %v", err, wantErr)
+	}
+}
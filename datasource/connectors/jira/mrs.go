@@ -0,0 +1,54 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package jira
+
+import (
+	"context"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// MRs returns no MRs, as Jira has no repositories.
+// The MRs of code hosting are linked to the issues via the issue keys
+// Returns possible errors via given cerr channel
+// cmrs returns MRs
+// cmrscounter returns the channel, which ticks when a MR is proceeded
+// cmaxmrs returns the max available amount of MRs
+func (c *Connector) MRs(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	cmrs <-chan data.MR,
+	cmrscounter <-chan bool,
+	cmaxmrs <-chan int,
+) {
+	mrs := make(chan data.MR)
+	maxmrs := make(chan int, 1)
+	mrscounter := make(chan bool, 100)
+
+	go func() {
+		defer close(mrs)
+		defer close(mrscounter)
+		defer close(maxmrs)
+
+		helpers.NonBlockingMaxSend(ctx, maxmrs, 0)
+	}()
+
+	return mrs, mrscounter, maxmrs
+}
@@ -0,0 +1,59 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package jira
+
+import (
+	"context"
+	"errors"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors/helpers"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
+
+// errIssuesOnly is returned, if the data of code hosting is requested from Jira
+var errIssuesOnly = errors.New( // nolint: gochecknoglobals
+	"only issues are provided by Jira, use it via --issues-endpoint jira",
+)
+
+// Tags returns an error, as Jira has no repositories.
+// Returns possible errors via given cerr channel
+// ctags returns tags
+// ctagscounter returns the channel, which ticks when a tag is proceeded
+// cmaxtags returns the max available amount of tags
+func (c *Connector) Tags(
+	ctx context.Context,
+	cerr chan<- error,
+) (
+	ctags <-chan data.Tag,
+	ctagscounter <-chan bool,
+	cmaxtags <-chan int,
+) {
+	tags := make(chan data.Tag)
+	maxtags := make(chan int, 1)
+	tagscounter := make(chan bool, 100)
+
+	go func() {
+		defer close(tags)
+		defer close(tagscounter)
+		defer close(maxtags)
+
+		helpers.NonBlockingErrSend(ctx, cerr, errIssuesOnly)
+	}()
+
+	return tags, tagscounter, maxtags
+}
@@ -0,0 +1,31 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package jira
+
+import (
+	"fmt"
+)
+
+// GetNewTagURL returns an error, as Jira has no repositories
+func (c *Connector) GetNewTagURL(TagName string) (string, error) {
+	return "", errIssuesOnly
+}
+
+// issueURL returns the URL of issue with given key
+func (c *Connector) issueURL(key string) string {
+	return fmt.Sprintf("%s/browse/%s", c.BaseURL, key)
+}
@@ -0,0 +1,97 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package connectors
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+)
+
+// TagDateSource describes, which date should be used as date of a tag
+type TagDateSource string
+
+// possible sources for the tag date
+const (
+	// TagDateDefault lets the connector decide about the date
+	TagDateDefault TagDateSource = ""
+	// TagDateCommit uses the committer date of the tagged commit
+	TagDateCommit TagDateSource = "commit"
+	// TagDateAuthor uses the author date of the tagged commit
+	TagDateAuthor TagDateSource = "author"
+	// TagDateTag uses the tagger date of annotated tag
+	TagDateTag TagDateSource = "tag"
+	// TagDateRelease uses the publishing date of the release
+	TagDateRelease TagDateSource = "release"
+)
+
+// GetTagDateSource returns the configured TagDateSource from CLI
+// or error if the given value isn't supported
+func GetTagDateSource(ctx *cli.Context) (TagDateSource, error) {
+	s := TagDateSource(ctx.String("tag-date-source"))
+	switch s {
+	case TagDateDefault, TagDateCommit, TagDateAuthor, TagDateTag, TagDateRelease:
+		return s, nil
+	default:
+		return "", fmt.Errorf("unsupported tag date source: %v", s)
+	}
+}
+
+// FetchMRFiles returns true if the changed files of MRs are needed for the
+// filtering by paths. Connectors should fetch the files only in this case,
+// as it needs an additional API call for each MR
+func FetchMRFiles(ctx *cli.Context) bool {
+	return len(ctx.StringSlice("path")) > 0 || len(ctx.StringSlice("component-paths")) > 0
+}
+
+// FetchTagDescriptions returns true if the descriptions of tags are rendered.
+// Connectors should fetch the messages of annotated tags only in this case,
+// as it needs additional API calls for each tag
+func FetchTagDescriptions(ctx *cli.Context) bool {
+	return ctx.Bool("release-descriptions")
+}
+
+// FetchClosingReferences returns true if the links between issues and MRs are needed:
+// for nesting or hiding of linked issues or for the filtering of completed issues.
+// The commands without --linked-issues always need them. Connectors should
+// query them only in this case, if it needs additional API calls
+func FetchClosingReferences(ctx *cli.Context) bool {
+	// list is the default value of --linked-issues, see generator.LinkedIssuesList
+	return ctx.String("linked-issues") != "list" || ctx.Bool("only-completed-issues")
+}
+
+// CommonCLIFlags returns the CLI flags, which are shared by all connectors
+func CommonCLIFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "tag-date-source",
+			Usage: "Date used for tags: commit, author, tag or release (default: depends on endpoint)",
+		},
+		cli.BoolFlag{
+			Name:  "releases",
+			Usage: "Use the hosted releases instead of git tags",
+		},
+		cli.BoolFlag{
+			Name:  "include-draft-releases",
+			Usage: "Include the draft releases, if --releases is used",
+		},
+		cli.StringSliceFlag{
+			Name:  "path",
+			Usage: "Include only MRs/PRs, which changed files in the given `path`, globs are supported. Can be repeated", // nolint: lll
+		},
+	}
+}
@@ -0,0 +1,65 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package connectors_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+	tcli "github.com/artem-sidorenko/chagen/internal/testing/cli"
+)
+
+func TestGetTagDateSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		want    connectors.TagDateSource
+		wantErr error
+	}{
+		{
+			name:  "Flag is not set",
+			flags: map[string]string{},
+			want:  connectors.TagDateDefault,
+		},
+		{
+			name:  "Supported value",
+			flags: map[string]string{"tag-date-source": "release"},
+			want:  connectors.TagDateRelease,
+		},
+		{
+			name:    "Unsupported value",
+			flags:   map[string]string{"tag-date-source": "tomorrow"},
+			wantErr: errors.New("unsupported tag date source: tomorrow"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tcli.TestContext(connectors.CommonCLIFlags(), tt.flags)
+
+			got, err := connectors.GetTagDateSource(ctx)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("GetTagDateSource() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetTagDateSource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -18,6 +18,13 @@
 package datasource
 
 import (
-	_ "github.com/artem-sidorenko/chagen/datasource/connectors/github" //enable github
-	_ "github.com/artem-sidorenko/chagen/datasource/connectors/gitlab" //enable gitlab
+	_ "github.com/artem-sidorenko/chagen/datasource/connectors/azure"     //enable azure
+	_ "github.com/artem-sidorenko/chagen/datasource/connectors/bitbucket" //enable bitbucket
+	_ "github.com/artem-sidorenko/chagen/datasource/connectors/exec"      //enable exec
+	_ "github.com/artem-sidorenko/chagen/datasource/connectors/file"      //enable file
+	_ "github.com/artem-sidorenko/chagen/datasource/connectors/gerrit"    //enable gerrit
+	_ "github.com/artem-sidorenko/chagen/datasource/connectors/gitea"     //enable gitea
+	_ "github.com/artem-sidorenko/chagen/datasource/connectors/github"    //enable github
+	_ "github.com/artem-sidorenko/chagen/datasource/connectors/gitlab"    //enable gitlab
+	_ "github.com/artem-sidorenko/chagen/datasource/connectors/jira"      //enable jira
 )
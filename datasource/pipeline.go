@@ -0,0 +1,563 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/data"
+	"github.com/artem-sidorenko/chagen/datasource/connectors"
+
+	"github.com/urfave/cli"
+)
+
+// NewReleaseAuto is used as name of new release, if the next version
+// should be determined from the unreleased changes
+const NewReleaseAuto = "auto"
+
+// Options describes which data should be fetched from the connector
+// and how it should be filtered
+type Options struct {
+	Endpoint     string
+	TagsFilter   *regexp.Regexp
+	IssuesFilter *data.LabelFilter
+	MRsFilter    *data.LabelFilter
+	PathFilter   *data.PathFilter
+	NewRelease   string
+	BumpRules    *data.BumpRules
+	Component    string // monorepo component, which tags are used for the next version
+	Commits      bool   // direct commits without MR should be fetched
+	Releases     bool   // hosted releases should be used instead of git tags
+
+	IssuesEndpoint  string               // endpoint for issues, if they are not fetched from Endpoint
+	IssuesConnector connectors.Connector // connector of IssuesEndpoint, set by NewConnector
+}
+
+// NewEndpointOptions returns the Options without any filtering,
+// only the endpoint is configured via CLI flags
+func NewEndpointOptions(ctx *cli.Context) (*Options, error) {
+	// verify the given endpoint, it should be one of supported connectors
+	if ctx.String("endpoint") == "" {
+		return nil, fmt.Errorf("endpoint type is missing")
+	}
+	endpoint := ctx.String("endpoint")
+	if !connectors.ConnectorRegistered(endpoint) {
+		return nil, fmt.Errorf("given endpoint isn't supported: %v", endpoint)
+	}
+	issuesEndpoint := ctx.String("issues-endpoint")
+	if issuesEndpoint != "" && !connectors.ConnectorRegistered(issuesEndpoint) {
+		return nil, fmt.Errorf("given issues endpoint isn't supported: %v", issuesEndpoint)
+	}
+
+	return &Options{
+		Endpoint:       endpoint,
+		IssuesEndpoint: issuesEndpoint,
+		Releases:       ctx.Bool("releases"),
+	}, nil
+}
+
+// NewOptions returns the Options configured via CLI flags
+func NewOptions(ctx *cli.Context) (*Options, error) {
+	opts, err := NewEndpointOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts.NewRelease = ctx.String("new-release")
+
+	if !ctx.Bool("no-filter-tags") { // if the flag is not there, lets apply the filter
+		filterReStr := ctx.String("filter-tags")
+		if filterReStr == "" {
+			return nil, fmt.Errorf("regular expression for tag filtering should be defined")
+		}
+		if opts.TagsFilter, err = regexp.Compile(filterReStr); err != nil {
+			return nil, fmt.Errorf("can't compile the regular expression: %v", err)
+		}
+	}
+
+	// generic label rules are applied to issues and MRs, specific rules are added to them
+	opts.IssuesFilter, err = data.NewLabelFilter(
+		append(SplitList(ctx.String("include-labels")), SplitList(ctx.String("include-issue-labels"))...),
+		append(SplitList(ctx.String("exclude-labels")), SplitList(ctx.String("exclude-issue-labels"))...),
+	)
+	if err != nil {
+		return nil, err
+	}
+	opts.MRsFilter, err = data.NewLabelFilter(
+		append(SplitList(ctx.String("include-labels")), SplitList(ctx.String("include-mr-labels"))...),
+		append(SplitList(ctx.String("exclude-labels")), SplitList(ctx.String("exclude-mr-labels"))...),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if paths := ctx.StringSlice("path"); len(paths) > 0 {
+		if opts.PathFilter, err = data.NewPathFilter(paths); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Commits = ctx.Bool("commits"); opts.Commits && opts.PathFilter != nil {
+		return nil, fmt.Errorf("options --commits and --path can't be combined")
+	}
+
+	opts.Component = ctx.String("component")
+	opts.BumpRules, err = data.NewBumpRules(
+		SplitList(ctx.String("major-labels")),
+		SplitList(ctx.String("minor-labels")),
+		ctx.Bool("conventional-commits"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// GetData creates the configured connector and returns all needed data from it,
+// the progress is printed to the given writer
+func GetData(
+	ctx *cli.Context,
+	opts *Options,
+	progress io.Writer,
+) (data.Tags, data.Issues, data.MRs, error) {
+	conn, err := NewConnector(ctx, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return GetConnectorData(conn, opts, progress)
+}
+
+// NewConnector creates the configured connector
+// and verifies the existence of the repository.
+// If opts.IssuesEndpoint is given, its connector is created and verified too,
+// it is stored in opts.IssuesConnector
+func NewConnector(ctx *cli.Context, opts *Options) (connectors.Connector, error) {
+	conn, err := newVerifiedConnector(ctx, opts.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.IssuesEndpoint != "" {
+		if opts.IssuesConnector, err = newVerifiedConnector(ctx, opts.IssuesEndpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+// newVerifiedConnector creates the connector for given endpoint
+// and verifies the existence of the repository
+func newVerifiedConnector(ctx *cli.Context, endpoint string) (connectors.Connector, error) {
+	conn, err := connectors.NewConnector(endpoint, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := conn.RepositoryExists()
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		// TODO: this should provide detailed information about repository: owner, repo name
+		return nil, fmt.Errorf("project not found")
+	}
+
+	return conn, nil
+}
+
+// collectData fans-in data from different channels to the data structures
+func collectData( // nolint: gocyclo
+	ctx context.Context,
+	ctags <-chan data.Tag,
+	cissues <-chan data.Issue,
+	cmrs <-chan data.MR,
+	cerr <-chan error,
+) (
+	data.Tags,
+	data.Issues,
+	data.MRs,
+	error,
+) {
+	var (
+		tags   data.Tags
+		issues data.Issues
+		mrs    data.MRs
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return tags, issues, mrs, ctx.Err()
+		case err, ok := <-cerr:
+			if ok {
+				return nil, nil, nil, err
+			}
+		case t, ok := <-ctags:
+			if ok {
+				tags = append(tags, t)
+			} else { // tags are finished, nil the channel
+				ctags = nil
+			}
+		case i, ok := <-cissues:
+			if ok {
+				issues = append(issues, i)
+			} else { // issues are finished, nil the channel
+				cissues = nil
+			}
+		case m, ok := <-cmrs:
+			if ok {
+				mrs = append(mrs, m)
+			} else { // MRs are finished, nil the channel
+				cmrs = nil
+			}
+		}
+		// all channels finished, return data
+		if ctags == nil && cissues == nil && cmrs == nil {
+			return tags, issues, mrs, nil
+		}
+	}
+}
+
+// collectCommits collects the commits from the channels
+// and prints the progress on given output
+func collectCommits(
+	ctx context.Context,
+	out io.Writer,
+	ccommits <-chan data.Commit,
+	ccommitscounter <-chan bool,
+	cmaxcommits <-chan int,
+	cerr <-chan error,
+) (data.Commits, error) {
+	var commits data.Commits
+	counter := 0
+	max := "X"
+
+	// print newline character when leaving the progress printing
+	defer func() {
+		fmt.Fprintf(out, "\n") // nolint: errcheck
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err, ok := <-cerr:
+			if ok {
+				return nil, err
+			}
+		case v, ok := <-cmaxcommits:
+			if ok {
+				max = strconv.Itoa(v)
+			} else {
+				cmaxcommits = nil
+			}
+		case _, ok := <-ccommitscounter:
+			if ok {
+				counter++
+			} else {
+				ccommitscounter = nil
+			}
+		case c, ok := <-ccommits:
+			if ok {
+				commits = append(commits, c)
+			} else { // commits are finished, nil the channel
+				ccommits = nil
+			}
+		}
+		// all channels finished, return data
+		if ccommits == nil && ccommitscounter == nil && cmaxcommits == nil {
+			return commits, nil
+		}
+
+		fmt.Fprintf(out, "\rProgress: %v/%v commits", counter, max) // nolint: errcheck
+	}
+}
+
+// NextVersion returns the next version for the changes using opts.BumpRules.
+// If the tag filter captures the monorepo components, only the tags
+// of opts.Component are used, as each component has own versions
+func NextVersion(tags data.Tags, issues data.Issues, mrs data.MRs, opts *Options) (string, error) {
+	if data.HasComponents(opts.TagsFilter) {
+		if opts.Component == "" {
+			return "", fmt.Errorf("option --component is required for the tags of monorepo components")
+		}
+		tags = data.FilterTagsByComponent(tags, opts.TagsFilter, opts.Component)
+	}
+
+	return data.NextVersion(tags, issues, mrs, opts.BumpRules)
+}
+
+// GetConnectorData returns all needed data from connector
+// if opts.NewRelease is specified, a new releases for
+// untagged activities is created, NewReleaseAuto determines
+// its name using opts.BumpRules
+func GetConnectorData(
+	conn connectors.Connector,
+	opts *Options,
+	progress io.Writer,
+) (data.Tags, data.Issues, data.MRs, error) {
+	tags, issues, mrs, _, err := GetConnectorDataWithCommits(conn, opts, progress)
+	return tags, issues, mrs, err
+}
+
+// GetConnectorDataWithCommits returns all needed data from connector
+// like GetConnectorData. If opts.Commits is specified, the direct commits
+// without MR are returned too. No commits are returned, if the connector
+// doesn't implement the connectors.CommitProvider.
+// If opts.Releases is specified, the hosted releases are returned as tags.
+// The git tags are returned, if the connector doesn't implement
+// the connectors.ReleaseNotesProvider.
+// The issues are fetched from opts.IssuesConnector, if it is set
+func GetConnectorDataWithCommits( // nolint: gocyclo
+	conn connectors.Connector,
+	opts *Options,
+	progress io.Writer,
+) (data.Tags, data.Issues, data.MRs, data.Commits, error) {
+
+	var (
+		tags    data.Tags
+		issues  data.Issues
+		mrs     data.MRs
+		commits data.Commits
+	)
+
+	issuesConn := conn
+	if opts.IssuesConnector != nil {
+		issuesConn = opts.IssuesConnector
+	}
+
+	var releaseProvider connectors.ReleaseNotesProvider
+	if opts.Releases {
+		releaseProvider, _ = conn.(connectors.ReleaseNotesProvider)
+	}
+
+	var commitProvider connectors.CommitProvider
+	if opts.Commits {
+		commitProvider, _ = conn.(connectors.CommitProvider)
+	}
+
+	// one minute for data collection should be enougth for now
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// we use cerr to track the possible errors in all goroutines invoked here
+	cerr := make(chan error)
+
+	var (
+		ctags        <-chan data.Tag
+		ctagscounter <-chan bool
+		cmaxtags     <-chan int
+	)
+	if releaseProvider != nil {
+		ctags, ctagscounter, cmaxtags = releaseProvider.Releases(ctx, cerr)
+	} else {
+		ctags, ctagscounter, cmaxtags = conn.Tags(ctx, cerr)
+	}
+	cissues, cissuescounter, cmaxissues := issuesConn.Issues(ctx, cerr)
+	cmrs, cmrscounter, cmaxmrs := conn.MRs(ctx, cerr)
+
+	// invoke the progress printer
+	printProgress(
+		ctx, progress,
+		ctagscounter, cmaxtags,
+		cissuescounter, cmaxissues,
+		cmrscounter, cmaxmrs)
+
+	//fan-in everything
+	tags, issues, mrs, err := collectData(
+		ctx,
+		ctags,
+		cissues,
+		cmrs,
+		cerr,
+	)
+	if err == nil && commitProvider != nil {
+		ccommits, ccommitscounter, cmaxcommits := commitProvider.Commits(ctx, cerr, tags)
+		commits, err = collectCommits(ctx, progress, ccommits, ccommitscounter, cmaxcommits, cerr)
+	}
+	// cerr isn't closed: on errors the producers might still send their errors,
+	// they are stopped via ctx and give up sending once it is done
+	cancel()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	// the commits of all MRs are skipped, even if the MRs are filtered out
+	if commitProvider != nil {
+		commits = data.DirectCommits(commits, mrs)
+	}
+
+	// the first contributions are detected on the complete MR history
+	data.MarkReturningAuthors(mrs)
+	// the issues are linked before filtering, the issues should stay
+	// linked to their MRs even if one of them is filtered out
+	data.LinkIssues(issues, mrs)
+
+	// we should apply the filter to the tags
+	if opts.TagsFilter != nil {
+		tags = data.FilterTags(tags, opts.TagsFilter)
+	}
+
+	// we should filter the labels
+	if opts.IssuesFilter != nil {
+		issues = data.FilterIssuesByLabels(issues, opts.IssuesFilter)
+	}
+	if opts.MRsFilter != nil {
+		mrs = data.FilterMRsByLabels(mrs, opts.MRsFilter)
+	}
+	if opts.PathFilter != nil {
+		mrs = data.FilterMRsByPaths(mrs, opts.PathFilter)
+	}
+
+	if opts.NewRelease != "" {
+		newRelease := opts.NewRelease
+		if newRelease == NewReleaseAuto {
+			if newRelease, err = NextVersion(tags, issues, mrs, opts); err != nil {
+				return nil, nil, nil, nil, err
+			}
+		}
+
+		var relURL string
+		relURL, err = conn.GetNewTagURL(newRelease)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+
+		tags = append(tags, data.Tag{
+			Name: newRelease,
+			Date: time.Now(),
+			URL:  relURL,
+		})
+	}
+
+	return tags, issues, mrs, commits, nil
+}
+
+// SplitList splits the comma separated list and trims the spaces of elements
+func SplitList(list string) []string {
+	if list == "" {
+		return nil
+	}
+
+	ret := strings.Split(list, ",")
+	for i := range ret {
+		ret[i] = strings.Trim(ret[i], " ")
+	}
+	return ret
+}
+
+// CLIFlags returns the CLI flags, which control the data collection
+func CLIFlags() []cli.Flag {
+	return append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "new-release, r",
+			Usage: "Use the given release name and create a new release for all changes after the last tagged release, auto suggests the next version", // nolint: lll
+		},
+		cli.StringFlag{
+			Name:  "component",
+			Usage: "Monorepo component, which tags are used for the next version if the tag filter captures components", // nolint: lll
+		},
+		cli.StringFlag{
+			Name:  "major-labels",
+			Usage: "Issues and MRs/PRs with specified labels `x,y,z` cause a major version bump",
+			Value: "breaking",
+		},
+		cli.StringFlag{
+			Name:  "minor-labels",
+			Usage: "Issues and MRs/PRs with specified labels `x,y,z` cause a minor version bump",
+			Value: "enhancement",
+		},
+		cli.BoolFlag{
+			Name:  "conventional-commits",
+			Usage: "Use the Conventional Commit prefixes of MR/PR titles like feat: or fix!: for version bumps",
+		},
+		cli.StringFlag{
+			Name:  "filter-tags, t",
+			Usage: "Only use tags, which match to the given regular expression",
+			Value: `^v\d+\.\d+\.\d+$`,
+		},
+		cli.BoolFlag{
+			Name:  "no-filter-tags",
+			Usage: "Disable filtering of tags",
+		},
+		cli.StringFlag{
+			Name:  "exclude-labels",
+			Usage: "Exclude issues and MRs/PRs with specified labels `x,y,z`, globs and /regex/ are supported",
+			Value: "duplicate, question, invalid, wontfix, no changelog",
+		},
+		cli.StringFlag{
+			Name:  "include-labels",
+			Usage: "Include only issues and MRs/PRs with one of specified labels `x,y,z`",
+		},
+		cli.StringFlag{
+			Name:  "exclude-issue-labels",
+			Usage: "Exclude issues with specified labels `x,y,z` additionally",
+		},
+		cli.StringFlag{
+			Name:  "include-issue-labels",
+			Usage: "Include only issues with one of specified labels `x,y,z`",
+		},
+		cli.StringFlag{
+			Name:  "exclude-mr-labels",
+			Usage: "Exclude MRs/PRs with specified labels `x,y,z` additionally",
+		},
+		cli.StringFlag{
+			Name:  "include-mr-labels",
+			Usage: "Include only MRs/PRs with one of specified labels `x,y,z`",
+		},
+	}, EndpointCLIFlags()...)
+}
+
+// EndpointCLIFlags returns the CLI flags, which configure the endpoint
+func EndpointCLIFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "endpoint",
+			Usage: "API endpoint type: " + strings.Join(connectors.RegisteredConnectors(), ", "),
+			Value: "github",
+		},
+		cli.StringFlag{
+			Name:  "issues-endpoint",
+			Usage: "API endpoint type for issues, if they should not be fetched from the endpoint, e.g. jira", // nolint: lll
+		},
+	}
+}
+
+// ConnectorCLIFlags returns the CLI flags of all registered connectors
+func ConnectorCLIFlags() []cli.Flag {
+	flags := connectors.CommonCLIFlags()
+
+	for _, conn := range connectors.RegisteredConnectors() {
+		connectorFlags, err := connectors.CLIFlags(conn)
+		if err != nil {
+			panic(err)
+		}
+
+		flags = append(flags, connectorFlags...)
+	}
+
+	return flags
+}
@@ -14,7 +14,7 @@
    limitations under the License.
 */
 
-package generate
+package datasource
 
 import (
 	"context"
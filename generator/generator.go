@@ -18,60 +18,198 @@
 package generator
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"strings"
 	"text/template"
 
 	"github.com/artem-sidorenko/chagen/data"
 	"github.com/artem-sidorenko/chagen/internal/info"
 )
 
-const changelogTemplate = `Changelog
+// changelogTemplate defines the templates for the whole changelog,
+// for a single release, which is also used for release notes, for the release
+// without its heading and for an issue, which is referenced by its key
+// if it comes from an external tracker
+const changelogTemplate = `{{define "changelog" -}}
+Changelog
 =========
 {{ range .Releases}}
+{{template "release" release $ .}}
+{{ end}}
+*This Changelog was automatically generated with [chagen {{.ChagenVersion}}]({{.ChagenURL}})*
+{{end}}
+
+{{- define "release" -}}
 ## [{{.Release}}]({{.ReleaseURL}}) ({{.Date}})
+{{- template "release-body" .}}
+{{- end}}
+
+{{- define "release-body" -}}
+
+{{- with .CompareURL}}
+
+[Full changelog]({{.}})
+{{- end}}
+
+{{- with .Milestone}}
+
+Milestone: [{{.Title}}]({{.URL}})
+{{- end}}
+
+{{- if and $.ShowDescriptions .Description}}
 
-{{- if .Issues}}
+{{.Description}}
+{{- end}}
+
+{{- $issues := .Issues}}
+{{- if ne $.LinkedIssues "list"}}{{$issues = .UnlinkedIssues}}{{end}}
+
+{{- if $issues}}
 
 Closed issues
 -------------
-{{- range .Issues}}
-- {{.Name}} [\#{{.ID}}]({{.URL}})
+{{- range $issues}}
+- {{template "issue" .}}
 {{- end}}
 {{- end}}
 
-{{- if .MRs}}
+{{- if or .MRs .CollapsedMRs}}
 
 Merged pull requests
 --------------------
 {{- range .MRs}}
 - {{.Name}} [\#{{.ID}}]({{.URL}}) ([{{.Author}}]({{.AuthorURL}}))
+{{- if eq $.LinkedIssues "nest"}}
+{{- range .ClosedIssues}}
+  - {{template "issue" .}}
 {{- end}}
 {{- end}}
-{{ end}}
-*This Changelog was automatically generated with [chagen {{.ChagenVersion}}]({{.ChagenURL}})*
-`
+{{- end}}
+
+{{- with .CollapsedMRs}}
+
+<details>
+<summary>{{len .}} dependency update{{if ne (len .) 1}}s{{end}}</summary>
+
+{{range .}}- {{.Name}} [\#{{.ID}}]({{.URL}}) ([{{.Author}}]({{.AuthorURL}}))
+{{end -}}
+</details>
+{{- end}}
+{{- end}}
+
+{{- if .Commits}}
+
+Direct commits
+--------------
+{{- range .Commits}}
+- {{.Title}} [{{.ShortSHA}}]({{.URL}}) ({{if .AuthorURL}}[{{.Author}}]({{.AuthorURL}}){{else}}{{.Author}}{{end}})
+{{- end}}
+{{- end}}
+
+{{- if and $.ShowContributors .Contributors}}
+
+Contributors
+------------
+{{- range .Contributors}}
+- [{{.Name}}]({{.URL}}){{if .FirstTime}} (first contribution){{end}}
+{{- end}}
+{{- end}}
+{{- end}}
+
+{{- define "issue" -}}
+{{.Name}} {{if .Key}}[{{.Key}}]({{.URL}}){{else}}[\#{{.ID}}]({{.URL}}){{end}}
+{{- end}}`
+
+// possible modes for rendering of issues, which are closed by MRs
+const (
+	// LinkedIssuesList lists all issues, regardless of the MRs
+	LinkedIssuesList = "list"
+	// LinkedIssuesNest shows the issues nested under the MRs, which closed them
+	LinkedIssuesNest = "nest"
+	// LinkedIssuesHide hides the issues, which are already covered by MRs
+	LinkedIssuesHide = "hide"
+)
 
 // Generator is resposible for generation of Changelogs.
 // Each data field represents the data structure, which is consumed by the template.
+// ShowDescriptions controls if release descriptions are rendered,
+// LinkedIssues controls how issues closed by MRs are rendered,
+// ShowContributors controls if the contributors of releases are rendered
 type Generator struct {
-	Releases      data.Releases
-	ChagenVersion string
-	ChagenURL     string
+	Releases         data.Releases
+	ChagenVersion    string
+	ChagenURL        string
+	ShowDescriptions bool
+	LinkedIssues     string
+	ShowContributors bool
+}
+
+// release is an alias for embedding, the embedded field should
+// not be named Release to keep the field Release of data.Release accessible
+type release = data.Release
+
+// releaseContext is consumed by the release template,
+// it provides the release data and the rendering settings of Generator
+type releaseContext struct {
+	*Generator
+	release
+}
+
+// template returns the parsed changelog templates
+func (g *Generator) template() *template.Template {
+	return template.Must(template.New("Changelog template").Funcs(template.FuncMap{
+		"release": func(g *Generator, r data.Release) releaseContext {
+			return releaseContext{Generator: g, release: r}
+		},
+	}).Parse(changelogTemplate))
 }
 
 // Render the content via template and write it to wr.
 // It returns the result of template complication
 func (g *Generator) Render(wr io.Writer) error {
-	t := template.Must(template.New("Changelog template").Parse(changelogTemplate))
-	return t.Execute(wr, g)
+	return g.template().ExecuteTemplate(wr, "changelog", g)
+}
+
+// RenderRelease renders only the section of release with given name
+// without the changelog header and footer, e.g. for release notes.
+// It returns an error if the release is missing or the template fails
+func (g *Generator) RenderRelease(wr io.Writer, name string) error {
+	return g.renderRelease(wr, name, "release")
+}
+
+// RenderReleaseBody renders the section of release with given name like RenderRelease,
+// but without its heading, e.g. for the messages of release tags
+func (g *Generator) RenderReleaseBody(wr io.Writer, name string) error {
+	return g.renderRelease(wr, name, "release-body")
+}
+
+// renderRelease renders the release with given name via the given template
+func (g *Generator) renderRelease(wr io.Writer, name, tmpl string) error {
+	for _, r := range g.Releases {
+		if r.Release != name {
+			continue
+		}
+
+		buf := &bytes.Buffer{}
+		if err := g.template().ExecuteTemplate(buf, tmpl, releaseContext{g, r}); err != nil {
+			return err
+		}
+		_, err := io.WriteString(wr, strings.TrimSpace(buf.String())+"\n")
+		return err
+	}
+	return fmt.Errorf("release %v not found", name)
 }
 
 // New returns a new generator,
 // which is filled and initialized with release data
 func New(r data.Releases) *Generator {
 	return &Generator{
-		Releases:      r,
-		ChagenVersion: info.Version(),
-		ChagenURL:     info.URL,
+		Releases:         r,
+		ChagenVersion:    info.Version(),
+		ChagenURL:        info.URL,
+		ShowDescriptions: true,
+		LinkedIssues:     LinkedIssuesList,
 	}
 }
@@ -25,8 +25,36 @@ import (
 )
 
 func TestGenerator_Render(t *testing.T) {
+
+	linkedReleases := data.Releases{
+		{
+			Release:    "v0.1.0",
+			ReleaseURL: "https://example.com/release/v0.1.0",
+			Date:       "2017-04-13",
+			Issues: data.Issues{
+				{Name: "Linked issue", ID: 1, URL: "https://example.com/issue/1"},
+				{Name: "Other issue", ID: 2, URL: "https://example.com/issue/2"},
+			},
+			MRs: data.MRs{
+				{
+					Name:      "Fix",
+					ID:        10,
+					URL:       "https://example.com/pulls/10",
+					Author:    "Author",
+					AuthorURL: "https://example.com/authors/author",
+					Closes:    []int{1},
+					ClosedIssues: data.Issues{
+						{Name: "Linked issue", ID: 1, URL: "https://example.com/issue/1"},
+					},
+				},
+			},
+		},
+	}
 	type fields struct {
-		Releases data.Releases
+		Releases         data.Releases
+		HideDescriptions bool
+		LinkedIssues     string
+		ShowContributors bool
 	}
 	tests := []struct {
 		name    string
@@ -113,6 +141,332 @@ Closed issues
 -------------
 - Test issue [\#1](https://example.com/issue/1)
 
+*This Changelog was automatically generated with [chagen unknown](https://github.com/artem-sidorenko/chagen)*
+`,
+		},
+		{
+			name: "release with description",
+			fields: fields{
+				Releases: data.Releases{
+					{
+						Release:     "v0.1.0",
+						ReleaseURL:  "https://example.com/release/v0.1.0",
+						Date:        "2017-04-13",
+						Description: "Highlights of this release",
+						Issues: data.Issues{
+							{
+								Name: "Test issue",
+								ID:   1,
+								URL:  "https://example.com/issue/1",
+							},
+						},
+					},
+				},
+			},
+			// nolint: lll
+			wantWr: `Changelog
+=========
+
+## [v0.1.0](https://example.com/release/v0.1.0) (2017-04-13)
+
+Highlights of this release
+
+Closed issues
+-------------
+- Test issue [\#1](https://example.com/issue/1)
+
+*This Changelog was automatically generated with [chagen unknown](https://github.com/artem-sidorenko/chagen)*
+`,
+		},
+		{
+			name: "release with hidden description",
+			fields: fields{
+				Releases: data.Releases{
+					{
+						Release:     "v0.1.0",
+						ReleaseURL:  "https://example.com/release/v0.1.0",
+						Date:        "2017-04-13",
+						Description: "Highlights of this release",
+					},
+				},
+				HideDescriptions: true,
+			},
+			// nolint: lll
+			wantWr: `Changelog
+=========
+
+## [v0.1.0](https://example.com/release/v0.1.0) (2017-04-13)
+
+*This Changelog was automatically generated with [chagen unknown](https://github.com/artem-sidorenko/chagen)*
+`,
+		},
+		{
+			name: "issues nested under MRs",
+			fields: fields{
+				Releases:     linkedReleases,
+				LinkedIssues: generator.LinkedIssuesNest,
+			},
+			// nolint: lll
+			wantWr: `Changelog
+=========
+
+## [v0.1.0](https://example.com/release/v0.1.0) (2017-04-13)
+
+Closed issues
+-------------
+- Other issue [\#2](https://example.com/issue/2)
+
+Merged pull requests
+--------------------
+- Fix [\#10](https://example.com/pulls/10) ([Author](https://example.com/authors/author))
+  - Linked issue [\#1](https://example.com/issue/1)
+
+*This Changelog was automatically generated with [chagen unknown](https://github.com/artem-sidorenko/chagen)*
+`,
+		},
+		{
+			name: "issues covered by MRs are hidden",
+			fields: fields{
+				Releases:     linkedReleases,
+				LinkedIssues: generator.LinkedIssuesHide,
+			},
+			// nolint: lll
+			wantWr: `Changelog
+=========
+
+## [v0.1.0](https://example.com/release/v0.1.0) (2017-04-13)
+
+Closed issues
+-------------
+- Other issue [\#2](https://example.com/issue/2)
+
+Merged pull requests
+--------------------
+- Fix [\#10](https://example.com/pulls/10) ([Author](https://example.com/authors/author))
+
+*This Changelog was automatically generated with [chagen unknown](https://github.com/artem-sidorenko/chagen)*
+`,
+		},
+		{
+			name: "issues of external tracker",
+			fields: fields{
+				Releases: data.Releases{
+					{
+						Release:    "v0.1.0",
+						ReleaseURL: "https://example.com/release/v0.1.0",
+						Date:       "2017-04-13",
+						Issues: data.Issues{
+							{
+								Name: "Jira issue",
+								Key:  "ABC-12",
+								URL:  "https://jira.example.com/browse/ABC-12",
+							},
+						},
+					},
+				},
+			},
+			// nolint: lll
+			wantWr: `Changelog
+=========
+
+## [v0.1.0](https://example.com/release/v0.1.0) (2017-04-13)
+
+Closed issues
+-------------
+- Jira issue [ABC-12](https://jira.example.com/browse/ABC-12)
+
+*This Changelog was automatically generated with [chagen unknown](https://github.com/artem-sidorenko/chagen)*
+`,
+		},
+		{
+			name: "collapsed dependency updates",
+			fields: fields{
+				Releases: data.Releases{
+					{
+						Release:    "v0.1.0",
+						ReleaseURL: "https://example.com/release/v0.1.0",
+						Date:       "2017-04-13",
+						MRs: data.MRs{
+							{
+								Name:      "Fix",
+								ID:        10,
+								URL:       "https://example.com/pulls/10",
+								Author:    "Author",
+								AuthorURL: "https://example.com/authors/author",
+							},
+						},
+						CollapsedMRs: data.MRs{
+							{
+								Name:      "Bump foo",
+								ID:        11,
+								URL:       "https://example.com/pulls/11",
+								Author:    "dependabot[bot]",
+								AuthorURL: "https://example.com/apps/dependabot",
+							},
+							{
+								Name:      "Bump bar",
+								ID:        12,
+								URL:       "https://example.com/pulls/12",
+								Author:    "dependabot[bot]",
+								AuthorURL: "https://example.com/apps/dependabot",
+							},
+						},
+					},
+					{
+						Release:    "v0.0.1",
+						ReleaseURL: "https://example.com/release/v0.0.1",
+						Date:       "2017-04-10",
+						CollapsedMRs: data.MRs{
+							{
+								Name:      "Bump foo",
+								ID:        5,
+								URL:       "https://example.com/pulls/5",
+								Author:    "renovate-bot",
+								AuthorURL: "https://example.com/users/renovate-bot",
+							},
+						},
+					},
+				},
+			},
+			// nolint: lll
+			wantWr: `Changelog
+=========
+
+## [v0.1.0](https://example.com/release/v0.1.0) (2017-04-13)
+
+Merged pull requests
+--------------------
+- Fix [\#10](https://example.com/pulls/10) ([Author](https://example.com/authors/author))
+
+<details>
+<summary>2 dependency updates</summary>
+
+- Bump foo [\#11](https://example.com/pulls/11) ([dependabot[bot]](https://example.com/apps/dependabot))
+- Bump bar [\#12](https://example.com/pulls/12) ([dependabot[bot]](https://example.com/apps/dependabot))
+</details>
+
+## [v0.0.1](https://example.com/release/v0.0.1) (2017-04-10)
+
+Merged pull requests
+--------------------
+
+<details>
+<summary>1 dependency update</summary>
+
+- Bump foo [\#5](https://example.com/pulls/5) ([renovate-bot](https://example.com/users/renovate-bot))
+</details>
+
+*This Changelog was automatically generated with [chagen unknown](https://github.com/artem-sidorenko/chagen)*
+`,
+		},
+		{
+			name: "release with direct commits",
+			fields: fields{
+				Releases: data.Releases{
+					{
+						Release:    "v0.1.0",
+						ReleaseURL: "https://example.com/release/v0.1.0",
+						Date:       "2017-04-13",
+						Commits: data.Commits{
+							{
+								SHA:       "5e2c7d0f9b1a4c3e8d6f2a1b0c9e8d7f6a5b4c3d",
+								Message:   "Fix typo in README\n\nThe link was wrong",
+								URL:       "https://example.com/commit/5e2c7d0",
+								Author:    "Author",
+								AuthorURL: "https://example.com/authors/author",
+							},
+							{
+								SHA:     "f0e1d2c3b4a5968778695a4b3c2d1e0f9a8b7c6d",
+								Message: "Update dependencies",
+								URL:     "https://example.com/commit/f0e1d2c",
+								Author:  "Other Author",
+							},
+						},
+					},
+				},
+			},
+			// nolint: lll
+			wantWr: `Changelog
+=========
+
+## [v0.1.0](https://example.com/release/v0.1.0) (2017-04-13)
+
+Direct commits
+--------------
+- Fix typo in README [5e2c7d0](https://example.com/commit/5e2c7d0) ([Author](https://example.com/authors/author))
+- Update dependencies [f0e1d2c](https://example.com/commit/f0e1d2c) (Other Author)
+
+*This Changelog was automatically generated with [chagen unknown](https://github.com/artem-sidorenko/chagen)*
+`,
+		},
+		{
+			name: "release with compare link and milestone",
+			fields: fields{
+				Releases: data.Releases{
+					{
+						Release:    "v0.1.0",
+						ReleaseURL: "https://example.com/release/v0.1.0",
+						Date:       "2017-04-13",
+						CompareURL: "https://example.com/compare/v0.0.1...v0.1.0",
+						Milestone: &data.Milestone{
+							Title: "0.1.0",
+							URL:   "https://example.com/milestone/1",
+						},
+					},
+				},
+			},
+			wantWr: `Changelog
+=========
+
+## [v0.1.0](https://example.com/release/v0.1.0) (2017-04-13)
+
+[Full changelog](https://example.com/compare/v0.0.1...v0.1.0)
+
+Milestone: [0.1.0](https://example.com/milestone/1)
+
+*This Changelog was automatically generated with [chagen unknown](https://github.com/artem-sidorenko/chagen)*
+`,
+		},
+		{
+			name: "release with contributors",
+			fields: fields{
+				Releases: data.Releases{
+					{
+						Release:    "v0.1.0",
+						ReleaseURL: "https://example.com/release/v0.1.0",
+						Date:       "2017-04-13",
+						MRs: data.MRs{
+							{
+								Name:      "Fix",
+								ID:        10,
+								URL:       "https://example.com/pulls/10",
+								Author:    "Author",
+								AuthorURL: "https://example.com/authors/author",
+							},
+						},
+						Contributors: data.Contributors{
+							{Name: "Author", URL: "https://example.com/authors/author", FirstTime: true},
+							{Name: "Other", URL: "https://example.com/authors/other"},
+						},
+					},
+				},
+				ShowContributors: true,
+			},
+			// nolint: lll
+			wantWr: `Changelog
+=========
+
+## [v0.1.0](https://example.com/release/v0.1.0) (2017-04-13)
+
+Merged pull requests
+--------------------
+- Fix [\#10](https://example.com/pulls/10) ([Author](https://example.com/authors/author))
+
+Contributors
+------------
+- [Author](https://example.com/authors/author) (first contribution)
+- [Other](https://example.com/authors/other)
+
 *This Changelog was automatically generated with [chagen unknown](https://github.com/artem-sidorenko/chagen)*
 `,
 		},
@@ -120,6 +474,11 @@ Closed issues
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := generator.New(tt.fields.Releases)
+			g.ShowDescriptions = !tt.fields.HideDescriptions
+			g.ShowContributors = tt.fields.ShowContributors
+			if tt.fields.LinkedIssues != "" {
+				g.LinkedIssues = tt.fields.LinkedIssues
+			}
 			wr := &bytes.Buffer{}
 			if err := g.Render(wr); (err != nil) != tt.wantErr {
 				t.Errorf("Generator.Render() error = %v, wantErr %v", err, tt.wantErr)
@@ -131,3 +490,90 @@ Closed issues
 		})
 	}
 }
+
+func TestGenerator_RenderRelease(t *testing.T) {
+	releases := data.Releases{
+		{
+			Release:     "v0.1.0",
+			ReleaseURL:  "https://example.com/release/v0.1.0",
+			Date:        "2017-04-13",
+			Description: "Highlights of this release",
+			Issues: data.Issues{
+				{Name: "Test issue", ID: 10, URL: "https://example.com/issue/10"},
+			},
+		},
+		{
+			Release:    "v0.0.1",
+			ReleaseURL: "https://example.com/release/v0.0.1",
+			Date:       "2017-04-10",
+		},
+	}
+	tests := []struct {
+		name    string
+		release string
+		wantWr  string
+		wantErr bool
+	}{
+		{
+			name:    "existing release",
+			release: "v0.1.0",
+			wantWr: `## [v0.1.0](https://example.com/release/v0.1.0) (2017-04-13)
+
+Highlights of this release
+
+Closed issues
+-------------
+- Test issue [\#10](https://example.com/issue/10)
+`,
+		},
+		{
+			name:    "empty release",
+			release: "v0.0.1",
+			wantWr: `## [v0.0.1](https://example.com/release/v0.0.1) (2017-04-10)
+`,
+		},
+		{
+			name:    "missing release",
+			release: "v1.0.0",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := generator.New(releases)
+			wr := &bytes.Buffer{}
+			if err := g.RenderRelease(wr, tt.release); (err != nil) != tt.wantErr {
+				t.Errorf("Generator.RenderRelease() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotWr := wr.String(); gotWr != tt.wantWr {
+				t.Errorf("Generator.RenderRelease() = %v, want %v", gotWr, tt.wantWr)
+			}
+		})
+	}
+}
+
+func TestGenerator_RenderReleaseBody(t *testing.T) {
+	g := generator.New(data.Releases{
+		{
+			Release:    "v0.1.0",
+			ReleaseURL: "https://example.com/release/v0.1.0",
+			Date:       "2017-04-13",
+			Issues: data.Issues{
+				{Name: "Test issue", ID: 10, URL: "https://example.com/issue/10"},
+			},
+		},
+	})
+	want := `Closed issues
+-------------
+- Test issue [\#10](https://example.com/issue/10)
+`
+
+	wr := &bytes.Buffer{}
+	if err := g.RenderReleaseBody(wr, "v0.1.0"); err != nil {
+		t.Fatalf("Generator.RenderReleaseBody() error = %v", err)
+	}
+	if gotWr := wr.String(); gotWr != want {
+		t.Errorf("Generator.RenderReleaseBody() = %v, want %v", gotWr, want)
+	}
+}
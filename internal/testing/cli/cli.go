@@ -27,6 +27,12 @@ import (
 // we need this for testing, unfotunelly useful functions within
 // cli package are private, so we have to do it by ourself
 func TestContext(flags []cli.Flag, set map[string]string) *cli.Context {
+	return TestContextWithArgs(flags, set, nil)
+}
+
+// TestContextWithArgs creates the simulation of CLI flag setting
+// with the given positional arguments
+func TestContextWithArgs(flags []cli.Flag, set map[string]string, args []string) *cli.Context {
 	flagset := flag.NewFlagSet("", flag.ContinueOnError)
 	for _, x := range flags {
 		x.Apply(flagset)
@@ -36,6 +42,9 @@ func TestContext(flags []cli.Flag, set map[string]string) *cli.Context {
 			panic(err)
 		}
 	}
+	if err := flagset.Parse(args); err != nil {
+		panic(err)
+	}
 
 	return cli.NewContext(nil, flagset, nil)
 }
@@ -36,10 +36,15 @@ var (
 	// RepositoryExistsFail controls whenether the testconnector should
 	// fail in the RepositoryExists() call
 	RepositoryExistsFail = false
+	// TagDescription is used as description of all tags, if set
+	TagDescription = ""
+	// Published contains the releases published via PublishRelease
+	Published []connectors.PublishOptions
 )
 
 // Connector implements the test connector
 type Connector struct {
+	fetchFiles bool
 }
 
 // RepositoryExists checks if referenced repository is present
@@ -73,6 +78,9 @@ func (c *Connector) Tags(
 		defer close(ctags)
 
 		for _, t := range tags {
+			if TagDescription != "" {
+				t.Description = TagDescription
+			}
 			ctags <- t
 		}
 	}()
@@ -116,7 +124,18 @@ func (c *Connector) MRs(
 	go func() {
 		defer close(cmrs)
 
+		files := testdata.MRFiles()
+		closes := testdata.MRClosedIssues()
+		mergeCommits := map[int]string{}
+		for _, m := range testdata.MRs() {
+			mergeCommits[m.ID] = m.MergeCommitSHA
+		}
 		for _, t := range testdata.DataMRs() {
+			if c.fetchFiles {
+				t.Files = files[t.ID]
+			}
+			t.MergeCommit = mergeCommits[t.ID]
+			t.Closes = closes[t.ID]
 			cmrs <- t
 		}
 	}()
@@ -124,14 +143,53 @@ func (c *Connector) MRs(
 	return cmrs, nil, nil
 }
 
+// Commits implements the connectors.CommitProvider interface
+func (c *Connector) Commits(
+	_ context.Context,
+	cerr chan<- error,
+	_ data.Tags,
+) (
+	<-chan data.Commit,
+	<-chan bool,
+	<-chan int,
+) {
+	ccommits := make(chan data.Commit)
+
+	go func() {
+		defer close(ccommits)
+
+		for _, t := range testdata.DataCommits() {
+			ccommits <- t
+		}
+	}()
+
+	return ccommits, nil, nil
+}
+
 // GetNewTagURL implements the connectors.Connector interface
 func (*Connector) GetNewTagURL(TagName string) (string, error) {
 	return "http://test.example.com/releases/" + TagName, nil
 }
 
+// CompareURL implements the connectors.CompareURLProvider interface
+func (*Connector) CompareURL(from, to string) (string, error) {
+	return "https://test.example.com/compare/" + from + "..." + to, nil
+}
+
+// PublishRelease implements the connectors.Publisher interface
+func (*Connector) PublishRelease(opts connectors.PublishOptions) (connectors.PublishStatus, error) {
+	for _, p := range Published {
+		if p.Tag == opts.Tag {
+			return connectors.PublishUpdated, nil
+		}
+	}
+	Published = append(Published, opts)
+	return connectors.PublishCreated, nil
+}
+
 // New creates a new Connector
 func New(ctx *cli.Context) (connectors.Connector, error) {
-	return &Connector{}, nil
+	return &Connector{fetchFiles: connectors.FetchMRFiles(ctx)}, nil
 }
 
 // CLIFlags describes the flags of connector
@@ -141,4 +199,5 @@ func CLIFlags() []cli.Flag {
 
 func init() { // nolint: gochecknoinits
 	connectors.RegisterConnector("testconnector", "TestConnector", New, CLIFlags)
+	connectors.RegisterConnectorDetails("testconnector", nil, &Connector{})
 }
@@ -16,55 +16,81 @@
 
 package testdata
 
-import "time"
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/artem-sidorenko/chagen/data"
+)
 
 // Commit describes a struct with Commit information
 type Commit struct {
-	SHA          string
-	AuthoredDate time.Time
-	Title        string
+	SHA           string
+	AuthoredDate  time.Time
+	Title         string
+	CommittedDate time.Time
 }
 
 // Commits returns different Commits
 func Commits() []Commit {
 	return []Commit{
 		{"041152be02b2d69141d3a8d2278460f4777474f7", time.Unix(1047094647, 0),
-			"Merge branch 'pr1' into 'master'"},
+			"Merge branch 'pr1' into 'master'", time.Unix(1047094677, 0)},
 		{"1080a10971e4a887ae8a827bb16e0b04801f630b", time.Unix(1047194647, 0),
-			"Merge branch 'pr2' into 'master'"},
+			"Merge branch 'pr2' into 'master'", time.Unix(1047194677, 0)},
 		{"d72866aa0a25e58b7fb0365fba0fd6791d627451", time.Unix(1047294647, 0),
-			"Merge branch 'pr3' into 'master'"},
+			"Merge branch 'pr3' into 'master'", time.Unix(1047294677, 0)},
 		{"433a7f849f0a5c21a0f24886ff72a91e1e74888e", time.Unix(1047494647, 0),
-			"Merge branch 'pr5' into 'master'"},
+			"Merge branch 'pr5' into 'master'", time.Unix(1047494677, 0)},
 		{"e5bc67e0c5d2ed17639a6499d1d0c05d4073dc80", time.Unix(1047594647, 0),
-			"Merge branch 'pr6' into 'master'"},
+			"Merge branch 'pr6' into 'master'", time.Unix(1047594677, 0)},
 		{"d4c421f840e35fb15ae99683df23caf451db7377", time.Unix(1047694647, 0),
-			"Merge branch 'pr7' into 'master'"},
+			"Merge branch 'pr7' into 'master'", time.Unix(1047694677, 0)},
 		{"fd81ac08493e550604dd04fa39b9c2eb1907cea6", time.Unix(1047794647, 0),
-			"Merge branch 'pr8' into 'master'"},
+			"Merge branch 'pr8' into 'master'", time.Unix(1047794677, 0)},
 		{"cc1cf9b1441962bdd6b98a4e09363dffb2037835", time.Unix(1047894647, 0),
-			"Merge branch 'pr9' into 'master'"},
+			"Merge branch 'pr9' into 'master'", time.Unix(1047894677, 0)},
 		{"9772a06643b77ec1a16646df4bb909c771c09fba", time.Unix(1047994647, 0),
-			"Merge branch 'pr10' into 'master'"},
+			"Merge branch 'pr10' into 'master'", time.Unix(1047994677, 0)},
 		{"627b94d1e87e938ea140c592f3ebd115d5a98929", time.Unix(1048094647, 0),
-			"Merge branch 'pr11' into 'master'"},
+			"Merge branch 'pr11' into 'master'", time.Unix(1048094677, 0)},
 		{"c31af03759e2262d99b2c4a7571a8e0115f37d68", time.Unix(1048294647, 0),
-			"Merge branch 'pr13' into 'master'"},
+			"Merge branch 'pr13' into 'master'", time.Unix(1048294677, 0)},
 		{"9618c791ab1f643aeffb7c5e1abe5877223aaa91", time.Unix(1048394647, 0),
-			"Merge branch 'pr14' into 'master'"},
-
-		{"7d84cdb2f7c2d4619cda4b8adeb1897097b5c8fc", time.Unix(1047083647, 0), "Release v0.0.1"},
-		{"b3622b516b8ad70ce5dc3fa422fb90c3b58fa9da", time.Unix(1047183647, 0), "Release v0.0.2"},
-		{"52f214dc3bf6c0e2a87eae6eab363a317c5a665f", time.Unix(1047283647, 0), "Release v0.0.3"},
-		{"d4ff341587bc80a9c897c28340df9fe8f9fc6309", time.Unix(1047383647, 0), "Release v0.0.4"},
-		{"746e45ea014e257bcb7caa2c100ed1e5f63ed234", time.Unix(1047483647, 0), "Release v0.0.5"},
-		{"ddde800c451bae606713ae0f8418badcf31db120", time.Unix(1047583647, 0), "Release v0.0.6"},
-		{"d21438494dd0722c1d13dc496ae1f60fb85084c1", time.Unix(1047683647, 0), "Release v0.0.7"},
-		{"8d8d817a530bc1c3f792d9508c187b5769c434c5", time.Unix(1047783647, 0), "Release v0.0.8"},
-		{"fc9f16ecc043e3fe422834cd127311d11d423668", time.Unix(1047883647, 0), "Release v0.0.9"},
-		{"dbbf36ffaae700a2ce03ef849d6f944031f34b95", time.Unix(1047983647, 0), "Release v0.1.0"},
-		{"fc5d68ff1cf691e09f6ead044813274953c9b843", time.Unix(1048083647, 0), "Release v0.1.1"},
-		{"d8351413f688c96c2c5d6fe58ebf5ac17f545bc0", time.Unix(1048183647, 0), "Release v0.1.2"},
+			"Merge branch 'pr14' into 'master'", time.Unix(1048394677, 0)},
+
+		{"7d84cdb2f7c2d4619cda4b8adeb1897097b5c8fc", time.Unix(1047083647, 0),
+			"Release v0.0.1", time.Unix(1047083677, 0)},
+		{"b3622b516b8ad70ce5dc3fa422fb90c3b58fa9da", time.Unix(1047183647, 0),
+			"Release v0.0.2", time.Unix(1047183677, 0)},
+		{"52f214dc3bf6c0e2a87eae6eab363a317c5a665f", time.Unix(1047283647, 0),
+			"Release v0.0.3", time.Unix(1047283677, 0)},
+		{"d4ff341587bc80a9c897c28340df9fe8f9fc6309", time.Unix(1047383647, 0),
+			"Release v0.0.4", time.Unix(1047383677, 0)},
+		{"746e45ea014e257bcb7caa2c100ed1e5f63ed234", time.Unix(1047483647, 0),
+			"Release v0.0.5", time.Unix(1047483677, 0)},
+		{"ddde800c451bae606713ae0f8418badcf31db120", time.Unix(1047583647, 0),
+			"Release v0.0.6", time.Unix(1047583677, 0)},
+		{"d21438494dd0722c1d13dc496ae1f60fb85084c1", time.Unix(1047683647, 0),
+			"Release v0.0.7", time.Unix(1047683677, 0)},
+		{"8d8d817a530bc1c3f792d9508c187b5769c434c5", time.Unix(1047783647, 0),
+			"Release v0.0.8", time.Unix(1047783677, 0)},
+		{"fc9f16ecc043e3fe422834cd127311d11d423668", time.Unix(1047883647, 0),
+			"Release v0.0.9", time.Unix(1047883677, 0)},
+		{"dbbf36ffaae700a2ce03ef849d6f944031f34b95", time.Unix(1047983647, 0),
+			"Release v0.1.0", time.Unix(1047983677, 0)},
+		{"fc5d68ff1cf691e09f6ead044813274953c9b843", time.Unix(1048083647, 0),
+			"Release v0.1.1", time.Unix(1048083677, 0)},
+		{"d8351413f688c96c2c5d6fe58ebf5ac17f545bc0", time.Unix(1048183647, 0),
+			"Release v0.1.2", time.Unix(1048183677, 0)},
+
+		{"5e2c7d0f9b1a4c3e8d6f2a1b0c9e8d7f6a5b4c3d", time.Unix(1048150000, 0),
+			"Fix typo in README", time.Unix(1048150030, 0)},
+		{"8a9b0c1d2e3f405162738495a6b7c8d9e0f1a2b3", time.Unix(1048350000, 0),
+			"Work on PR 14", time.Unix(1048350030, 0)},
+		{"f0e1d2c3b4a5968778695a4b3c2d1e0f9a8b7c6d", time.Unix(1048450000, 0),
+			"Update dependencies", time.Unix(1048450030, 0)},
 	}
 }
 
@@ -78,3 +104,55 @@ func CommitsBySHA() map[string]Commit {
 
 	return rcommits
 }
+
+// commits of PR 14, which is merged with a merge commit
+const (
+	pr14BranchCommit = "8a9b0c1d2e3f405162738495a6b7c8d9e0f1a2b3"
+	pr14MergeCommit  = "9618c791ab1f643aeffb7c5e1abe5877223aaa91"
+)
+
+// History returns the commits of the default branch from newest to oldest.
+// The history is linear in the order of commit dates, PR 14 is the only one
+// merged with a merge commit, all other PRs are squashed
+func History() []Commit {
+	ret := Commits()
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].CommittedDate.After(ret[j].CommittedDate)
+	})
+	return ret
+}
+
+// CommitParents returns the parents of commits, see History()
+func CommitParents() map[string][]string {
+	ret := map[string][]string{}
+	var mainline []string
+	for _, c := range History() {
+		if c.SHA != pr14BranchCommit {
+			mainline = append(mainline, c.SHA)
+		}
+	}
+	for i := 0; i < len(mainline)-1; i++ {
+		ret[mainline[i]] = []string{mainline[i+1]}
+	}
+	ret[pr14BranchCommit] = ret[pr14MergeCommit]
+	ret[pr14MergeCommit] = []string{ret[pr14MergeCommit][0], pr14BranchCommit}
+	return ret
+}
+
+// DataCommits returns the history in the data.Commit format
+func DataCommits() []data.Commit {
+	var r []data.Commit
+	parents := CommitParents()
+	for _, c := range History() {
+		r = append(r, data.Commit{
+			SHA:       c.SHA,
+			Message:   c.Title,
+			URL:       fmt.Sprintf("https://test.example.com/commits/%v", c.SHA),
+			Author:    "test-user",
+			AuthorURL: "https://test.example.com/authors/test-user",
+			Date:      c.AuthoredDate,
+			Parents:   parents[c.SHA],
+		})
+	}
+	return r
+}
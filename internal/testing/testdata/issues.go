@@ -52,6 +52,16 @@ func Issues() []Issue {
 	}
 }
 
+// IssueCloseReasons returns the reasons of closing for some issues
+func IssueCloseReasons() map[int]string {
+	return map[int]string{
+		1244: data.CloseReasonNotPlanned,
+		1294: data.CloseReasonCompleted,
+		1304: data.CloseReasonNotPlanned,
+		1234: data.CloseReasonCompleted,
+	}
+}
+
 // DataIssues returns the tags in the data.Issue format
 func DataIssues() []data.Issue {
 	var r []data.Issue
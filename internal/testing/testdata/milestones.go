@@ -0,0 +1,33 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package testdata
+
+// Milestone describes a struct with milestone information
+type Milestone struct {
+	ID          int
+	Title       string
+	Description string
+}
+
+// Milestones returns different milestones
+func Milestones() []Milestone {
+	return []Milestone{
+		{1, "0.1.0", "First minor release"},
+		{2, "v0.1.2", ""},
+		{3, "Backlog", "Ideas for later"},
+	}
+}
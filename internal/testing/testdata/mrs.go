@@ -69,6 +69,37 @@ func MRs() []MR {
 	}
 }
 
+// MRDescriptions returns the descriptions of PRs/MRs
+func MRDescriptions() map[int]string {
+	return map[int]string{
+		2214: "Fixes #1214",
+		2224: "Some cleanup, closes #1227",
+		2294: "Resolves #1294\n\nSee also #1284",
+		2334: "Closes #1234 and #1224",
+	}
+}
+
+// MRFiles returns the files changed by PRs/MRs
+func MRFiles() map[int][]string {
+	return map[int][]string{
+		2214: {"README.md"},
+		2224: {"cli/cli.go", "cli/commands/commands.go", "docs/cli.md"},
+		2294: {"data/tags.go"},
+		2334: {"docs/usage.md"},
+		2344: {"data/releases.go", "data/releases_test.go"},
+	}
+}
+
+// MRClosedIssues returns the issues, which are closed by PRs/MRs
+func MRClosedIssues() map[int][]int {
+	return map[int][]int{
+		2214: {1214},
+		2224: {1227},
+		2294: {1294},
+		2334: {1234, 1224},
+	}
+}
+
 // DataMRs returns the tags in the data.MR format
 func DataMRs() []data.MR {
 	var r []data.MR
@@ -0,0 +1,53 @@
+/*
+   Copyright 2019 Artem Sidorenko <artem@posteo.de>
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package testdata
+
+// Release describes a struct with details of hosted releases.
+// Releases of existing tags are marked via ReleaseTime in Tags()
+type Release struct {
+	Tag         string
+	Name        string
+	Description string
+	Prerelease  bool
+	Draft       bool
+	Commit      string // target commit of draft releases, the tag does not exist yet
+}
+
+// Releases returns the details of different releases
+func Releases() []Release {
+	return []Release{
+		{"v0.0.1", "First release", "The very first release", false, false, ""},
+		{"v0.0.3", "Release v0.0.3", "", false, false, ""},
+		{"v0.0.7", "Release v0.0.7", "Some bugfixes", false, false, ""},
+		{"v0.1.0", "Preview of v0.1", "Try the new features", true, false, ""},
+		{"v0.1.1", "Release v0.1.1", "", false, false, ""},
+		{"v0.1.2", "Release v0.1.2", "Highlights of v0.1.2", false, false, ""},
+		{"v0.2.0", "Upcoming v0.2.0", "Work in progress", false, true,
+			"9618c791ab1f643aeffb7c5e1abe5877223aaa91"},
+	}
+}
+
+// ReleasesByTag returns a map with tag name as a key
+func ReleasesByTag() map[string]Release {
+	ret := map[string]Release{}
+
+	for _, v := range Releases() {
+		ret[v.Tag] = v
+	}
+
+	return ret
+}